@@ -5,12 +5,20 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/vitovidale/fastfood-app/internal/adapter/driven/config"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/eventbus"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/notifier"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/payment"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/publisher"
 	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/postgres"
 	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/postgres/repository"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/token"
 	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http"
 	"github.com/vitovidale/fastfood-app/internal/adapter/logger"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/port"
 	"github.com/vitovidale/fastfood-app/internal/core/service"
 )
 
@@ -24,6 +32,11 @@ import (
 //	@host			127.0.0.1:8080
 //	@BasePath		/v1
 //	@schemes		http https
+//
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				"Bearer " followed by the access token of a customer, a guest or a staff user
 
 func main() {
 	config, err := config.New()
@@ -38,7 +51,7 @@ func main() {
 	ctx := context.Background()
 	db, err := postgres.New(ctx, config.DB)
 	if err != nil {
-		slog.Error("Error connecting to database")
+		slog.Error("Error connecting to database", "error", err)
 		os.Exit(1)
 	}
 	slog.Info("Successfully connected to the database", "db", config.DB.Connection)
@@ -54,15 +67,64 @@ func main() {
 	productService := service.NewProductService(categoryRepo, productRepo)
 	productHandler := http.NewProductHandler(productService)
 
+	transactor := postgres.NewTransactor(db)
+
+	// Guest
+	guestRepo := repository.NewGuestRepository(db)
+
 	// Customer
+	// there is no mail server yet, the emails are written locally
 	customerRepo := repository.NewCustomerRepository(db)
-	customerService := service.NewCustomerService(customerRepo)
-	customerHandler := http.NewCustomerHandler(customerService)
+	customerTokenRepo := repository.NewCustomerTokenRepository(db)
+	customerNotifier := notifier.NewLocalNotifier(config.Notifier.File)
+	customerService := service.NewCustomerService(customerRepo, customerTokenRepo, guestRepo, customerNotifier, transactor, config.Auth.PasswordResetTTL, config.Auth.EmailVerificationTTL)
+
+	// Staff
+	staffRepo := repository.NewStaffRepository(db)
+	staffService := service.NewStaffService(staffRepo)
+	if config.Staff.AdminUsername != "" && config.Staff.AdminPassword != "" {
+		if err := staffService.Bootstrap(ctx, config.Staff.AdminUsername, config.Staff.AdminPassword); err != nil {
+			slog.Error("Error creating the first admin", "error", err)
+		}
+	}
+
+	// Auth
+	tokenSigner, err := token.NewJWTSigner(config.Auth)
+	if err != nil {
+		slog.Error("Error initializing the token signer", "error", err)
+		os.Exit(1)
+	}
+	revokedTokenRepo := repository.NewRevokedTokenRepository(db)
+	authService := service.NewAuthService(customerRepo, staffRepo, guestRepo, revokedTokenRepo, tokenSigner, config.Auth.AccessTTL, config.Auth.RefreshTTL)
+	customerHandler := http.NewCustomerHandler(customerService, authService)
+	staffHandler := http.NewStaffHandler(staffService, authService)
+
+	// Payment
+	paymentGateway, err := newPaymentGateway(config.Payment)
+	if err != nil {
+		slog.Error("Error initializing the payment gateway", "error", err)
+		os.Exit(1)
+	}
+	paymentRepo := repository.NewPaymentRepository(db)
+	paymentEventRepo := repository.NewPaymentEventRepository(db)
+	refundRepo := repository.NewRefundRepository(db)
 
 	// Order
+	// keeps enough events for clients reconnecting after a few seconds away
+	eventBus := eventbus.NewMemoryBus(1000, 64)
 	orderRepo := repository.NewOrderRepository(db)
-	orderService := service.NewOrderService(orderRepo, productRepo, customerRepo)
+	orderService := service.NewOrderService(orderRepo, productRepo, customerRepo, guestRepo, paymentGateway, paymentRepo, paymentEventRepo, refundRepo, transactor, eventBus)
 	orderHandler := http.NewOrderHandler(orderService)
+	paymentHandler := http.NewPaymentHandler(orderService, config.Payment.WebhookSecret)
+	kitchenHandler := http.NewKitchenHandler(orderService)
+	webSocketHandler := http.NewWebSocketHandler(orderService)
+	guestService := service.NewGuestService(guestRepo, customerRepo, orderRepo, transactor)
+	guestHandler := http.NewGuestHandler(guestService, authService)
+	boardHandler := http.NewBoardHandler(orderService, config.Board.ReadyFor)
+
+	// Outbox
+	outboxRepo := repository.NewOutboxRepository(db)
+	outboxRelay := service.NewOutboxRelay(outboxRepo, newPublisher(config.Outbox), transactor, config.Outbox.BatchSize)
 
 	// Health
 	healthHandler := http.NewHealthHandler()
@@ -70,10 +132,17 @@ func main() {
 	// Router initialization
 	router, err := http.NewRouter(
 		config.HTTP,
+		authService,
 		*productHandler,
 		*categoryHandler,
 		*customerHandler,
+		*staffHandler,
+		*guestHandler,
 		*orderHandler,
+		*paymentHandler,
+		*kitchenHandler,
+		*webSocketHandler,
+		*boardHandler,
 		*healthHandler,
 	)
 
@@ -98,5 +167,69 @@ func main() {
 	http.SetReady(true)
 	http.SetStarted(true)
 
+	// the background jobs act as the app itself
+	jobCtx := domain.WithSystemPrincipal(ctx)
+	go syncPayments(jobCtx, orderService, config.Payment.SyncInterval)
+	go resetTrackingNumbers(jobCtx, orderService, config.Store)
+	go relayOutbox(jobCtx, outboxRelay, config.Outbox.RelayInterval)
+
 	select {}
 }
+
+// syncPayments periodically settles the orders waiting for payment.
+func syncPayments(ctx context.Context, orderService *service.OrderService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := orderService.SyncPayments(ctx); err != nil {
+			slog.Error("Error syncing payments", "error", err)
+		}
+	}
+}
+
+// newPaymentGateway returns the gateway of the payment provider. The fake
+// gateway is the only provider available for now.
+func newPaymentGateway(config *config.Payment) (port.PaymentGateway, error) {
+	if config.Provider != "fake" {
+		return nil, fmt.Errorf("unknown payment provider %q", config.Provider)
+	}
+	return payment.NewFakeGateway(config), nil
+}
+
+// newPublisher returns where the order events are published to. There is no
+// message broker yet, so events are written to a file unless kept in memory.
+func newPublisher(config *config.Outbox) port.EventPublisher {
+	if config.Publisher == "memory" {
+		return publisher.NewMemoryPublisher()
+	}
+	return publisher.NewFilePublisher(config.File)
+}
+
+// relayOutbox periodically publishes the order events waiting in the outbox.
+func relayOutbox(ctx context.Context, relay *service.OutboxRelay, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := relay.Relay(ctx); err != nil {
+			slog.Error("Error relaying the outbox", "error", err)
+		}
+	}
+}
+
+// resetTrackingNumbers starts the tracking numbers over every day at the store
+// opening. Numbers still held by active orders are skipped when given again,
+// so resetting from more than one instance is harmless.
+func resetTrackingNumbers(ctx context.Context, orderService *service.OrderService, store *config.Store) {
+	for {
+		next := domain.NextStoreOpening(time.Now().In(store.Location), store.OpensAt)
+		time.Sleep(time.Until(next))
+
+		if err := orderService.ResetTrackingNumbers(ctx); err != nil {
+			slog.Error("Error resetting tracking numbers", "error", err)
+			continue
+		}
+		slog.Info("Reset the tracking numbers")
+	}
+}
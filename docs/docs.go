@@ -12,13 +12,39 @@ const docTemplate = `{
         "contact": {},
         "license": {
             "name": "MIT",
-            "url": "https://github.com/vitovidale/TECH-CHALLENGE/blob/main/LICENSE"
+            "url": "https://github.com/vitovidale/fastfood-app/blob/main/LICENSE"
         },
         "version": "{{.Version}}"
     },
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/board": {
+            "get": {
+                "description": "Returns the tracking numbers of the orders being prepared, first paid first, and of the orders ready for pickup, first done first, without any customer data\nReady orders drop off the board once delivered, or after a configured time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Board"
+                ],
+                "summary": "Pickup board",
+                "responses": {
+                    "200": {
+                        "description": "Pickup board",
+                        "schema": {
+                            "$ref": "#/definitions/response.PickupBoardResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/categories": {
             "get": {
                 "description": "Get a complete list of all categories",
@@ -51,7 +77,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new category with the given name",
+                "description": "Creates a new category with the given name and the kitchen station preparing its products",
                 "consumes": [
                     "application/json"
                 ],
@@ -239,7 +265,7 @@ const docTemplate = `{
         },
         "/customers": {
             "post": {
-                "description": "Creates a new customer with first name, last name, email and password",
+                "description": "Creates a new customer with CPF, first name, last name, email and password, mailing them a code to verify their email with",
                 "consumes": [
                     "application/json"
                 ],
@@ -273,13 +299,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "CPF or email already registered",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
                     }
                 }
             }
         },
         "/customers/auth": {
             "post": {
-                "description": "Authenticates a customer with email or id, and password",
+                "description": "Authenticates a customer with email or CPF, and password, returning an access token to send as a bearer token and a refresh token to get the next one",
                 "consumes": [
                     "application/json"
                 ],
@@ -305,7 +337,7 @@ const docTemplate = `{
                     "200": {
                         "description": "Customer authenticated",
                         "schema": {
-                            "$ref": "#/definitions/response.CustomerResponse"
+                            "$ref": "#/definitions/response.AuthResponse"
                         }
                     },
                     "400": {
@@ -313,13 +345,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Wrong password",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/customers/{id}": {
-            "get": {
-                "description": "Returns a single customer by its ID",
+        "/customers/auth/refresh": {
+            "post": {
+                "description": "Exchanges a refresh token for a new pair of tokens. Each refresh token can only be used once",
                 "consumes": [
                     "application/json"
                 ],
@@ -329,21 +367,23 @@ const docTemplate = `{
                 "tags": [
                     "Customers"
                 ],
-                "summary": "Get a single customer",
+                "summary": "Refresh an access token",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Customer ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token request",
+                        "name": "RefreshTokenRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RefreshTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Customer found",
+                        "description": "Tokens refreshed",
                         "schema": {
-                            "$ref": "#/definitions/response.CustomerResponse"
+                            "$ref": "#/definitions/response.AuthResponse"
                         }
                     },
                     "400": {
@@ -352,16 +392,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found error",
+                    "401": {
+                        "description": "Invalid, expired or used refresh token",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates a single customer based on its ID",
+            }
+        },
+        "/customers/auth/revoke": {
+            "post": {
+                "description": "Stops accepting an access or refresh token before it expires, such as when signing out",
                 "consumes": [
                     "application/json"
                 ],
@@ -371,30 +413,23 @@ const docTemplate = `{
                 "tags": [
                     "Customers"
                 ],
-                "summary": "Update a customer",
+                "summary": "Revoke a token",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Customer ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update customer request",
-                        "name": "UpdateCustomerRequest",
+                        "description": "Revoke token request",
+                        "name": "RevokeTokenRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateCustomerRequest"
+                            "$ref": "#/definitions/request.RevokeTokenRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Customer updated",
+                        "description": "Token revoked",
                         "schema": {
-                            "$ref": "#/definitions/response.CustomerResponse"
+                            "type": "boolean"
                         }
                     },
                     "400": {
@@ -403,44 +438,66 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found error",
+                    "401": {
+                        "description": "Invalid or expired token",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a customer by its ID",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/customers/cpf/{cpf}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Finds the customer with the CPF, with or without punctuation, for the kiosk to greet them. Only the first name and the masked CPF are returned, to staff users, the customer themselves, and guests not attached to a customer yet",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Customers"
                 ],
-                "summary": "Deletes a customer",
+                "summary": "Identify a customer by CPF",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Customer ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "CPF",
+                        "name": "cpf",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Customer deleted",
+                        "description": "Customer found",
                         "schema": {
-                            "type": "boolean"
+                            "$ref": "#/definitions/response.CustomerIdentityResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request error",
+                        "description": "Invalid CPF",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Another customer, or a guest attached already",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -448,25 +505,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/health/liveness": {
-            "get": {
-                "description": "Checks if the app is alive",
+        "/customers/email-verification/confirm": {
+            "post": {
+                "description": "Verifies the email of a customer with the code mailed to them. Each code can only be used once",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Health"
+                    "Customers"
+                ],
+                "summary": "Verify an email",
+                "parameters": [
+                    {
+                        "description": "Verify email request",
+                        "name": "VerifyEmailRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.VerifyEmailRequest"
+                        }
+                    }
                 ],
-                "summary": "Liveness check",
                 "responses": {
                     "200": {
-                        "description": "App alive",
+                        "description": "Email verified",
                         "schema": {
-                            "type": "boolean"
+                            "$ref": "#/definitions/response.CustomerResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Invalid, expired or used code",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -474,25 +545,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/health/readiness": {
-            "get": {
-                "description": "Checks if the app is ready to serve requests",
+        "/customers/password-reset": {
+            "post": {
+                "description": "Mails a code to the customer with the email to choose a new password with. The response is the same whether a customer has the email or not",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Health"
+                    "Customers"
+                ],
+                "summary": "Request a password reset",
+                "parameters": [
+                    {
+                        "description": "Request password reset request",
+                        "name": "RequestPasswordResetRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RequestPasswordResetRequest"
+                        }
+                    }
                 ],
-                "summary": "Readiness check",
                 "responses": {
                     "200": {
-                        "description": "App ready",
+                        "description": "Code mailed, if a customer has the email",
                         "schema": {
                             "type": "boolean"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Bad Request error",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -500,25 +585,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/health/start": {
-            "get": {
-                "description": "Run a warmup request",
+        "/customers/password-reset/confirm": {
+            "post": {
+                "description": "Sets the password of a customer with the code mailed to them. Each code can only be used once",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Health"
+                    "Customers"
+                ],
+                "summary": "Reset a password",
+                "parameters": [
+                    {
+                        "description": "Reset password request",
+                        "name": "ResetPasswordRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResetPasswordRequest"
+                        }
+                    }
                 ],
-                "summary": "Start the app",
                 "responses": {
                     "200": {
-                        "description": "App started",
+                        "description": "Password reset",
                         "schema": {
                             "type": "boolean"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Invalid, expired or used code",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -526,9 +625,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders": {
+        "/customers/{id}": {
             "get": {
-                "description": "Returns all orders, sorted by status ASC, and ignores inactive or completed orders",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a single customer by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -536,17 +640,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Customers"
+                ],
+                "summary": "Get a single customer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List orders",
                 "responses": {
                     "200": {
-                        "description": "Order found",
+                        "description": "Customer found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.OrderResponse"
-                            }
+                            "$ref": "#/definitions/response.CustomerResponse"
                         }
                     },
                     "400": {
@@ -560,17 +670,16 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
                     }
                 }
             },
-            "post": {
-                "description": "Creates an order for a customer with a list of products in a single request",
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates a single customer based on its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -578,25 +687,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Customers"
                 ],
-                "summary": "Create an order",
+                "summary": "Update a customer",
                 "parameters": [
                     {
-                        "description": "Payload with customer ID and products",
-                        "name": "CreateOrderRequest",
+                        "type": "string",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update customer request",
+                        "name": "UpdateCustomerRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateOrderRequest"
+                            "$ref": "#/definitions/request.UpdateCustomerRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the customer version to change",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order created",
+                        "description": "Customer updated",
                         "schema": {
-                            "$ref": "#/definitions/response.OrderResponse"
+                            "$ref": "#/definitions/response.CustomerResponse"
                         }
                     },
                     "400": {
@@ -611,18 +733,27 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "409": {
+                        "description": "Changed by someone else meanwhile",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/orders/customer/{customerId}": {
-            "get": {
-                "description": "Gets an active order by its customer ID, used for incremental orders, allowing the addition and removal of products",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a customer by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -630,23 +761,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Customers"
                 ],
-                "summary": "Get an order by its customer ID",
+                "summary": "Deletes a customer",
                 "parameters": [
                     {
-                        "type": "integer",
+                        "type": "string",
                         "description": "Customer ID",
-                        "name": "customerId",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the customer version to change",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order found",
+                        "description": "Customer deleted",
                         "schema": {
-                            "$ref": "#/definitions/response.OrderResponse"
+                            "type": "boolean"
                         }
                     },
                     "400": {
@@ -655,8 +792,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found error",
+                    "409": {
+                        "description": "Changed by someone else meanwhile",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -664,39 +807,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/products": {
+        "/customers/{id}/email-verification": {
             "post": {
-                "description": "Adds a product to an existing **active** order, based on its CustomerID. If the order doesn't exist, it will be created.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Mails a code to the customer to verify their email with, such as when the one mailed on sign up expired or the email changed",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Customers"
                 ],
-                "summary": "Add a product to an order",
+                "summary": "Request an email verification",
                 "parameters": [
                     {
-                        "description": "Add product request",
-                        "name": "AddProductRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.AddProductRequest"
-                        }
+                        "type": "string",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product added",
+                        "description": "Code mailed",
                         "schema": {
-                            "$ref": "#/definitions/response.OrderResponse"
+                            "type": "boolean"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request error",
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Another customer",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -707,8 +856,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "409": {
+                        "description": "Email already verified",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -716,33 +865,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}": {
+        "/customers/{id}/orders": {
             "get": {
-                "description": "Returns an order based on its ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns the past and active orders of a customer with their lines, newest first, a page at a time. Pass the ` + "`" + `nextCursor` + "`" + ` of a page as ` + "`" + `cursor` + "`" + ` to get the next one",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Customers"
                 ],
-                "summary": "Get an order",
+                "summary": "List the orders of a customer",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "Customer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Orders created at or after, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Orders created before, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Where the page starts",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Orders per page, 20 by default",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order found",
+                        "description": "Orders found",
                         "schema": {
-                            "$ref": "#/definitions/response.OrderResponse"
+                            "$ref": "#/definitions/response.OrderPageResponse"
                         }
                     },
                     "400": {
@@ -756,13 +937,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/orders/{id}/complete": {
-            "patch": {
-                "description": "Changes the state of the order to ` + "`" + `done` + "`" + `, where the customer needs to **take out** the order from the counter, based on the order ID",
+        "/guests": {
+            "post": {
+                "description": "Starts a session for someone ordering at the kiosk without signing up, optionally with a name to call the orders out by. The tokens returned are used to place and follow the orders of the guest",
                 "consumes": [
                     "application/json"
                 ],
@@ -770,23 +957,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Guests"
                 ],
-                "summary": "Complete an order",
+                "summary": "Start a guest session",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Create guest request",
+                        "name": "CreateGuestRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateGuestRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order found",
+                        "description": "Guest session started",
                         "schema": {
-                            "$ref": "#/definitions/response.OrderResponse"
+                            "$ref": "#/definitions/response.GuestAuthResponse"
                         }
                     },
                     "400": {
@@ -794,25 +983,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not found error",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/orders/{id}/pay": {
-            "patch": {
-                "description": "Marked the order as paid, setting its status to ` + "`" + `confirmed` + "`" + `, based on the order ID",
+        "/guests/{id}/attach": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attaches a guest, and the orders the guest placed, to the customer holding the access token given, signed in at the kiosk. Orders placed by the guest afterwards belong to the customer too",
                 "consumes": [
                     "application/json"
                 ],
@@ -820,23 +1002,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Guests"
                 ],
-                "summary": "Pays an order",
+                "summary": "Attach a guest to a customer",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "Guest ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Attach guest request",
+                        "name": "AttachGuestRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AttachGuestRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order found",
+                        "description": "Guest attached",
                         "schema": {
-                            "$ref": "#/definitions/response.OrderResponse"
+                            "$ref": "#/definitions/response.GuestResponse"
                         }
                     },
                     "400": {
@@ -845,14 +1036,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Missing or invalid token of the guest or the customer",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Another guest",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Not found error",
+                        "description": "Guest or customer not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "409": {
+                        "description": "Guest attached to another customer",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -860,45 +1063,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/prepare": {
-            "patch": {
-                "description": "Signal that the kitchen has started preparing the order, changing its status to ` + "`" + `started` + "`" + `, based on the order ID",
-                "consumes": [
-                    "application/json"
-                ],
+        "/health/liveness": {
+            "get": {
+                "description": "Checks if the app is alive",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
-                ],
-                "summary": "Prepare an order",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Health"
                 ],
+                "summary": "Liveness check",
                 "responses": {
                     "200": {
-                        "description": "Order found",
-                        "schema": {
-                            "$ref": "#/definitions/response.OrderResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request error",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not found error",
+                        "description": "App alive",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "type": "boolean"
                         }
                     },
                     "500": {
@@ -910,42 +1089,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/status": {
+        "/health/readiness": {
             "get": {
-                "description": "Returns the order status by its ID",
+                "description": "Checks if the app is ready to serve requests",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
-                ],
-                "summary": "Get the order status",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Health"
                 ],
+                "summary": "Readiness check",
                 "responses": {
                     "200": {
-                        "description": "Order status",
+                        "description": "App ready",
                         "schema": {
-                            "$ref": "#/definitions/response.DefaultResponse"
+                            "type": "boolean"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request error",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not found error",
+                    }
+                }
+            }
+        },
+        "/health/start": {
+            "get": {
+                "description": "Run a warmup request",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Start the app",
+                "responses": {
+                    "200": {
+                        "description": "App started",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "type": "boolean"
                         }
                     },
                     "500": {
@@ -957,40 +1141,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{orderId}/products/{orderProductId}": {
-            "delete": {
-                "description": "Removes a product from an existing **active** order, based on its OrderID and OrderProductID.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/kitchen/queue": {
+            "get": {
+                "description": "Returns the confirmed and started orders, first paid first, with their items, notes, tracking number and how long the customer has been waiting since paying\nFiltering by station, set on the product categories, returns only the orders, and items, that station prepares",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Kitchen"
                 ],
-                "summary": "Remove a product from an order",
+                "summary": "Kitchen queue",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
-                        "name": "orderId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "orderProductId",
-                        "in": "path",
-                        "required": true
+                        "description": "Kitchen station, e.g. grill or drinks",
+                        "name": "station",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order found",
+                        "description": "Kitchen queue",
                         "schema": {
-                            "$ref": "#/definitions/response.OrderResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.KitchenTicketResponse"
+                            }
                         }
                     },
                     "400": {
@@ -999,12 +1175,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found error",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1014,9 +1184,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
+        "/orders": {
             "get": {
-                "description": "Returns a list of all products",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns all orders, sorted by status ASC, and ignores inactive, cancelled or delivered orders",
                 "consumes": [
                     "application/json"
                 ],
@@ -1024,16 +1199,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Get all products",
+                "summary": "List orders",
                 "responses": {
                     "200": {
-                        "description": "Product list",
+                        "description": "Order found",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/response.ProductResponse"
+                                "$ref": "#/definitions/response.OrderResponse"
                             }
                         }
                     },
@@ -1058,7 +1233,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new product with name, price and descripton",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates an order for a customer, or a guest, with a list of products in a single request",
                 "consumes": [
                     "application/json"
                 ],
@@ -1066,25 +1246,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Create a new product",
+                "summary": "Create an order",
                 "parameters": [
                     {
-                        "description": "Create product request",
-                        "name": "CreateProductRequest",
+                        "description": "Payload with customer or guest ID and products",
+                        "name": "CreateOrderRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateProductRequest"
+                            "$ref": "#/definitions/request.CreateOrderRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product created",
+                        "description": "Order created",
                         "schema": {
-                            "$ref": "#/definitions/response.ProductResponse"
+                            "$ref": "#/definitions/response.OrderResponse"
                         }
                     },
                     "400": {
@@ -1099,12 +1279,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict error",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1114,9 +1288,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/category/{id}": {
+        "/orders/customer/{customerId}": {
             "get": {
-                "description": "Returns all products by category",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gets the pending order of a customer by its ID, used for incremental orders, allowing the addition and removal of products until it is paid for",
                 "consumes": [
                     "application/json"
                 ],
@@ -1124,26 +1303,74 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Get products by category",
+                "summary": "Get an order by its customer ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Category ID",
-                        "name": "id",
+                        "description": "Customer ID",
+                        "name": "customerId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product",
+                        "description": "Order found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.ProductResponse"
-                            }
+                            "$ref": "#/definitions/response.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/products": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a product to the **pending** order of the customer, based on its CustomerID, or GuestID for guests. If there is no pending order, it will be created.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Add a product to an order",
+                "parameters": [
+                    {
+                        "description": "Add product request",
+                        "name": "AddProductRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AddProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product added",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrderResponse"
                         }
                     },
                     "400": {
@@ -1158,6 +1385,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Order paid for meanwhile",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1167,33 +1400,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}": {
+        "/orders/reports/uncollected": {
             "get": {
-                "description": "Retrieves a product by its ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns the ` + "`" + `done` + "`" + ` orders waiting at the counter, longest waiting first, and how long the orders delivered since the given time, by default the last 24 hours, waited to be collected",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Get a product",
+                "summary": "Report uncollected orders",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Start of the period, RFC 3339",
+                        "name": "since",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product found",
+                        "description": "Uncollected orders report",
                         "schema": {
-                            "$ref": "#/definitions/response.ProductResponse"
+                            "$ref": "#/definitions/response.UncollectedReportResponse"
                         }
                     },
                     "400": {
@@ -1202,11 +1436,72 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found error",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/orders/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams the status changes of every order as Server-Sent Events named status, with heartbeat comments while idle\nClients reconnecting with the Last-Event-ID header get the recent events they missed, or an event named reset when those are no longer retained, and should reload the orders",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Stream order status changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order status events",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrderStatusEventResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/totals/mismatches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the orders whose stored total disagrees with the sum of their lines, quantity times the unit price captured when each line was added",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Check the order totals",
+                "responses": {
+                    "200": {
+                        "description": "Mismatching orders",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.OrderTotalMismatchResponse"
+                            }
+                        }
                     },
                     "500": {
                         "description": "Internal server error",
@@ -1215,9 +1510,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates a product by its ID",
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns an order based on its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1225,32 +1527,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Updates a product",
+                "summary": "Get an order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update product request",
-                        "name": "CreateProductRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateProductRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product updated",
+                        "description": "Order found",
                         "schema": {
-                            "$ref": "#/definitions/response.ProductResponse"
+                            "$ref": "#/definitions/response.OrderResponse"
                         }
                     },
                     "400": {
@@ -1264,17 +1557,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a single product by its ID",
+            }
+        },
+        "/orders/{id}/cancel": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels an order that the kitchen has not started yet, saving the reason and who cancelled it. Orders whose charge is still being settled cannot be cancelled, and paid orders are flagged for refund",
                 "consumes": [
                     "application/json"
                 ],
@@ -1282,23 +1576,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Deletes a product",
+                "summary": "Cancel an order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Cancel order request",
+                        "name": "CancelOrderRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CancelOrderRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the order version to change",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product deleted",
+                        "description": "Order cancelled",
                         "schema": {
-                            "type": "boolean"
+                            "$ref": "#/definitions/response.OrderResponse"
                         }
                     },
                     "400": {
@@ -1313,6 +1622,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1321,107 +1642,2211 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "request.AddProductRequest": {
-            "type": "object",
-            "required": [
-                "productId",
-                "quantity"
-            ],
-            "properties": {
-                "customerId": {
-                    "type": "integer",
-                    "example": 0
+        },
+        "/orders/{id}/complete": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the state of the order to ` + "`" + `done` + "`" + `, where the customer needs to **take out** the order from the counter, based on the order ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Complete an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the order version to change",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order found",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/deliver": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the state of a ` + "`" + `done` + "`" + ` order to ` + "`" + `delivered` + "`" + `, once the customer takes it out from the counter, taking it off the pickup board",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Deliver an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the order version to change",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order delivered",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns every change made to an order, oldest first: its creation, status changes, products added or removed and total changes, with who made each change, the authenticated user or ` + "`" + `system` + "`" + ` for the payment provider and the background jobs, and the values before and after",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get the history of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order history",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.OrderEventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/pay": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a charge for the order at the payment provider, setting its status to ` + "`" + `processing` + "`" + `, based on the order ID. The order is ` + "`" + `confirmed` + "`" + ` once the charge is approved, or moves back to ` + "`" + `pending` + "`" + ` if it cannot be created, is declined or expires.\nPaying with ` + "`" + `pix` + "`" + ` returns the BR Code (\"copia e cola\") and its QR code as a base64 PNG. The method defaults to ` + "`" + `card` + "`" + `",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Pays an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment method",
+                        "name": "PayOrderRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.PayOrderRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the order version to change",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment started",
+                        "schema": {
+                            "$ref": "#/definitions/response.PayOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Order without products",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns every payment made for an order, oldest first, with its status, attempts and the reason it failed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "List the payments of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payments found",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.PaymentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/prepare": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Signal that the kitchen has started preparing the order, changing its status to ` + "`" + `started` + "`" + `, based on the order ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Prepare an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the order version to change",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order found",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/refunds": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the refund ledger of an order, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "List the refunds of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refunds found",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.RefundResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gives back part or all of what was paid for an order that will not be fulfilled. An amount of zero refunds everything not refunded yet. Refunds the payment provider cannot be asked for right away stay ` + "`" + `pending` + "`" + ` in the ledger, and are given back later",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Refund an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Refund order request",
+                        "name": "RefundOrderRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.RefundOrderRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the order version to change",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order refunded",
+                        "schema": {
+                            "$ref": "#/definitions/response.RefundResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order or payment cannot be refunded",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Amount exceeds what is left to refund",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the order status by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get the order status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order status",
+                        "schema": {
+                            "$ref": "#/definitions/response.DefaultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams the status changes of an order as Server-Sent Events named status, starting with its current status, with heartbeat comments while idle\nClients reconnecting with the Last-Event-ID header get the recent events they missed instead of the current status, or an event named reset when those are no longer retained, and should reload the order",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Stream an order status changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order status events",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrderStatusEventResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/totals": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the order total to the sum of its lines, fixing orders reported by the totals check",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Recalculate an order total",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order total recalculated",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{orderId}/products/{orderProductId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a product from an existing **pending** order, based on its OrderID and OrderProductID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Remove a product from an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "orderProductId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the order version to change",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order found",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Changed by someone else meanwhile, or no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/webhook": {
+            "post": {
+                "description": "Receives a notification from the payment provider about a charge, confirming the order or moving it back to ` + "`" + `pending` + "`" + `. The body must be signed with HMAC-SHA256 using the webhook secret, hex encoded in the ` + "`" + `X-Signature` + "`" + ` header. Redeliveries of the same event are ignored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Receive a payment notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 signature of the body",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment notification",
+                        "name": "PaymentWebhookRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PaymentWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification handled",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No tracking number available for the paid order, to be sent again",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Returns a list of all products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get all products",
+                "responses": {
+                    "200": {
+                        "description": "Product list",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new product with name, price and descripton",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Create product request",
+                        "name": "CreateProductRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product created",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/category/{id}": {
+            "get": {
+                "description": "Returns all products by category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get products by category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Retrieves a product by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a product by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Updates a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update product request",
+                        "name": "CreateProductRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateProductRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the product version to change",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product updated",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Changed by someone else meanwhile",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a single product by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Deletes a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the product version to change",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product deleted",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Changed by someone else meanwhile",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/staff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "List the staff users",
+                "responses": {
+                    "200": {
+                        "description": "Staff users",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.StaffUserResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to manage staff users",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a staff user with one of the roles cashier, kitchen, manager or admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Create a staff user",
+                "parameters": [
+                    {
+                        "description": "Create staff user request",
+                        "name": "CreateStaffRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateStaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Staff user created",
+                        "schema": {
+                            "$ref": "#/definitions/response.StaffUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation errors or unknown role",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to manage staff users",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Username already taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/staff/auth": {
+            "post": {
+                "description": "Authenticates a staff user with username and password, returning tokens carrying the role of the user. Tokens are refreshed and revoked as the customer ones",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Authenticate a staff user",
+                "parameters": [
+                    {
+                        "description": "Authenticate staff user request",
+                        "name": "AuthStaffRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AuthStaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Staff user authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/response.StaffAuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Wrong username or password",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/staff/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a staff user, whose tokens stop being refreshed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Delete a staff user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staff user ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the staff user version to delete",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Staff user deleted",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to manage staff users",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upgrades the request to a WebSocket, authenticated with the access token of a staff user allowed to view orders, as a bearer token or in the access_token query parameter\nThe server sends a message of type status for every order status change, starting after lastEventId when reconnecting\nClients reconnecting after events no longer retained get a message of type reset instead, and should reload the orders\nThe client sends commands of type prepare or complete, with the orderId, an optional ref and an optional version to expect, and gets back a message of type ack or error with the same ref\nConnections falling behind are closed with code 1013, and should reconnect from the last event received",
+                "tags": [
+                    "Kitchen"
+                ],
+                "summary": "Order updates and kitchen commands over WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the last event received",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols",
+                        "schema": {
+                            "$ref": "#/definitions/response.WebSocketMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.Role": {
+            "type": "string",
+            "enum": [
+                "customer",
+                "guest",
+                "cashier",
+                "kitchen",
+                "manager",
+                "admin",
+                "system"
+            ],
+            "x-enum-varnames": [
+                "RoleCustomer",
+                "RoleGuest",
+                "RoleCashier",
+                "RoleKitchen",
+                "RoleManager",
+                "RoleAdmin",
+                "RoleSystem"
+            ]
+        },
+        "request.AddProductRequest": {
+            "type": "object",
+            "required": [
+                "productId",
+                "quantity"
+            ],
+            "properties": {
+                "customerId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "guestId": {
+                    "description": "instead of customerId, for guests ordering without signing up",
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "notes"
+                },
+                "productId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "request.AttachGuestRequest": {
+            "type": "object",
+            "required": [
+                "customerToken"
+            ],
+            "properties": {
+                "customerToken": {
+                    "description": "access token of the customer, signed in at the kiosk",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "request.AuthCustomerRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "cpf": {
+                    "type": "string",
+                    "example": "123.456.789-09"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "12345678"
+                }
+            }
+        },
+        "request.AuthStaffRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "12345678"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "jane"
+                }
+            }
+        },
+        "request.CancelOrderRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "customer gave up"
+                }
+            }
+        },
+        "request.CreateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Snacks"
+                },
+                "station": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "grill"
+                }
+            }
+        },
+        "request.CreateCustomerRequest": {
+            "type": "object",
+            "required": [
+                "cpf",
+                "email",
+                "firstName",
+                "lastName",
+                "password"
+            ],
+            "properties": {
+                "cpf": {
+                    "type": "string",
+                    "example": "123.456.789-09"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "12345678"
+                }
+            }
+        },
+        "request.CreateGuestRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "optional, to call the orders of the guest out by",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "John"
+                }
+            }
+        },
+        "request.CreateOrderProductRequest": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "example": "notes"
+                },
+                "productId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "request.CreateOrderRequest": {
+            "type": "object",
+            "required": [
+                "products"
+            ],
+            "properties": {
+                "customerId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "guestId": {
+                    "description": "instead of customerId, for guests ordering without signing up",
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.CreateOrderProductRequest"
+                    }
+                }
+            }
+        },
+        "request.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "categoryId": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Potato chips with cheese flavor"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Potato Chips"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 10.5
+                }
+            }
+        },
+        "request.CreateStaffRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "password",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Jane Doe"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "12345678"
+                },
+                "role": {
+                    "description": "one of cashier, kitchen, manager or admin",
+                    "type": "string",
+                    "example": "cashier"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "jane"
+                }
+            }
+        },
+        "request.PayOrderRequest": {
+            "type": "object",
+            "properties": {
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "card",
+                        "pix"
+                    ],
+                    "example": "pix"
+                }
+            }
+        },
+        "request.PaymentWebhookRequest": {
+            "type": "object",
+            "required": [
+                "eventId",
+                "orderId",
+                "reference",
+                "status"
+            ],
+            "properties": {
+                "eventId": {
+                    "type": "string",
+                    "example": "evt_000001"
+                },
+                "orderId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "fake_ch_000001"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "approved",
+                        "declined",
+                        "expired"
+                    ],
+                    "example": "approved"
+                }
+            }
+        },
+        "request.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refreshToken"
+            ],
+            "properties": {
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "request.RefundOrderRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "amount to give back, zero refunds everything not refunded yet",
+                    "type": "number",
+                    "example": 10.5
+                }
+            }
+        },
+        "request.RequestPasswordResetRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                }
+            }
+        },
+        "request.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "12345678"
+                },
+                "token": {
+                    "description": "the code mailed to the customer",
+                    "type": "string",
+                    "example": "nC1cB8v0yBqVn2Qm5R4rZ7Wc3kP6tX9aL0sD2fG4hJ8"
+                }
+            }
+        },
+        "request.RevokeTokenRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "description": "access or refresh token",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "request.UpdateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "New snack"
+                },
+                "station": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "grill"
+                }
+            }
+        },
+        "request.UpdateCustomerRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Doe"
+                }
+            }
+        },
+        "request.VerifyEmailRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "description": "the code mailed to the customer",
+                    "type": "string",
+                    "example": "nC1cB8v0yBqVn2Qm5R4rZ7Wc3kP6tX9aL0sD2fG4hJ8"
+                }
+            }
+        },
+        "response.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "customer": {
+                    "description": "only when authenticating with a password",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.CustomerResponse"
+                        }
+                    ]
+                },
+                "expiresAt": {
+                    "description": "when the access token expires",
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "tokenType": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "response.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string"
                 },
-                "notes": {
+                "name": {
                     "type": "string",
-                    "example": "notes"
+                    "example": "Snacks"
                 },
-                "productId": {
+                "station": {
                     "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "example": "grill"
                 },
-                "quantity": {
-                    "type": "integer",
-                    "example": 1
+                "updatedAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
                 }
             }
         },
-        "request.AuthCustomerRequest": {
+        "response.CustomerIdentityResponse": {
+            "type": "object",
+            "properties": {
+                "cpf": {
+                    "type": "string",
+                    "example": "***.456.789-**"
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "John"
+                }
+            }
+        },
+        "response.CustomerResponse": {
             "type": "object",
-            "required": [
-                "password"
-            ],
             "properties": {
+                "cpf": {
+                    "type": "string",
+                    "example": "***.456.789-**"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
                 "email": {
                     "type": "string",
                     "example": "john.doe@example.com"
                 },
+                "emailVerified": {
+                    "description": "whether the customer proved to own the email",
+                    "type": "boolean",
+                    "example": true
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "John"
+                },
                 "id": {
+                    "type": "string"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "version": {
                     "type": "integer",
-                    "example": 12345678910
+                    "example": 1
+                }
+            }
+        },
+        "response.DefaultResponse": {
+            "type": "object",
+            "properties": {
+                "data": {}
+            }
+        },
+        "response.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Error message 1",
+                        " Error message 2"
+                    ]
+                }
+            }
+        },
+        "response.GuestAuthResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 },
-                "password": {
+                "expiresAt": {
                     "type": "string",
-                    "example": "12345678"
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "guest": {
+                    "$ref": "#/definitions/response.GuestResponse"
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "tokenType": {
+                    "type": "string",
+                    "example": "Bearer"
                 }
             }
         },
-        "request.CreateCategoryRequest": {
+        "response.GuestResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
+                "attachedAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "customerId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string",
-                    "example": "Snacks"
+                    "example": "John"
                 }
             }
         },
-        "request.CreateCustomerRequest": {
+        "response.KitchenItemResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "firstName",
-                "id",
-                "lastName",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "name": {
                     "type": "string",
-                    "example": "john.doe@example.com"
+                    "example": "Cheeseburger"
                 },
-                "firstName": {
+                "notes": {
                     "type": "string",
-                    "example": "John"
+                    "example": "no onions"
                 },
-                "id": {
+                "productId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "quantity": {
                     "type": "integer",
-                    "minimum": 1,
-                    "example": 12345678910
+                    "example": 1
                 },
-                "lastName": {
+                "station": {
                     "type": "string",
-                    "example": "Doe"
+                    "example": "grill"
+                }
+            }
+        },
+        "response.KitchenTicketResponse": {
+            "type": "object",
+            "properties": {
+                "elapsedSeconds": {
+                    "type": "integer",
+                    "example": 300
                 },
-                "password": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.KitchenItemResponse"
+                    }
+                },
+                "orderId": {
                     "type": "string",
-                    "minLength": 8,
-                    "example": "12345678"
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "paidAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "startedAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "confirmed"
+                },
+                "trackingNumber": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "request.CreateOrderProductRequest": {
+        "response.OrderEventResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "example": "staff:kitchen"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "newValue": {
+                    "type": "string",
+                    "example": "started"
+                },
+                "occurredAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "oldValue": {
+                    "type": "string",
+                    "example": "confirmed"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "status_changed"
+                }
+            }
+        },
+        "response.OrderPageResponse": {
+            "type": "object",
+            "properties": {
+                "nextCursor": {
+                    "description": "absent on the last page",
+                    "type": "string",
+                    "example": "MTcxNTM0NDIwMDAwMDAwMDAwMDow"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.OrderResponse"
+                    }
+                }
+            }
+        },
+        "response.OrderProductResponse": {
             "type": "object",
             "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
                 "notes": {
                     "type": "string",
                     "example": "notes"
                 },
+                "product": {
+                    "$ref": "#/definitions/response.ProductResponse"
+                },
                 "productId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
@@ -1429,251 +3854,451 @@ const docTemplate = `{
                 "quantity": {
                     "type": "integer",
                     "example": 1
-                }
-            }
-        },
-        "request.CreateOrderRequest": {
-            "type": "object",
-            "required": [
-                "customerID",
-                "products"
-            ],
-            "properties": {
-                "customerID": {
+                },
+                "total": {
+                    "type": "number",
+                    "example": 21
+                },
+                "unitPrice": {
+                    "type": "number",
+                    "example": 10.5
+                }
+            }
+        },
+        "response.OrderResponse": {
+            "type": "object",
+            "properties": {
+                "cancelReason": {
+                    "type": "string",
+                    "example": "customer gave up"
+                },
+                "cancelledAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "cancelledBy": {
+                    "type": "string",
+                    "example": "kiosk"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "customerId": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "guestId": {
+                    "type": "string"
+                },
+                "guestName": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.OrderProductResponse"
+                    }
+                },
+                "readyAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "refundPending": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "startedAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "total": {
+                    "type": "number",
+                    "example": 100
+                },
+                "trackingNumber": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "version": {
                     "type": "integer",
-                    "minimum": 1,
                     "example": 1
-                },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/request.CreateOrderProductRequest"
-                    }
                 }
             }
         },
-        "request.CreateProductRequest": {
+        "response.OrderStatusEventResponse": {
             "type": "object",
-            "required": [
-                "name",
-                "price"
-            ],
             "properties": {
-                "categoryId": {
-                    "type": "string"
+                "occurredAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
                 },
-                "description": {
+                "orderId": {
                     "type": "string",
-                    "example": "Potato chips with cheese flavor"
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "name": {
+                "status": {
                     "type": "string",
-                    "example": "Potato Chips"
+                    "example": "confirmed"
                 },
-                "price": {
-                    "type": "number",
-                    "minimum": 0,
-                    "example": 10000
+                "trackingNumber": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "request.UpdateCategoryRequest": {
+        "response.OrderTotalMismatchResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
+                "computed": {
+                    "type": "number",
+                    "example": 31.5
+                },
+                "orderId": {
                     "type": "string",
-                    "example": "New snack"
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "stored": {
+                    "type": "number",
+                    "example": 21
                 }
             }
         },
-        "request.UpdateCustomerRequest": {
+        "response.PayOrderResponse": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "john.doe@example.com"
-                },
-                "firstName": {
-                    "type": "string",
-                    "example": "John"
+                "order": {},
+                "payment": {
+                    "$ref": "#/definitions/response.PaymentResponse"
                 },
-                "lastName": {
+                "pixQrCode": {
+                    "description": "PNG image of the PIX QR code, base64 encoded",
                     "type": "string",
-                    "example": "Doe"
+                    "example": "iVBORw0KGgoAAAANSUhEUgAA..."
                 }
             }
         },
-        "response.CategoryResponse": {
+        "response.PaymentResponse": {
             "type": "object",
             "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 100
+                },
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "createdAt": {
                     "type": "string",
                     "example": "1970-01-01T00:00:00Z"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
+                "currency": {
                     "type": "string",
-                    "example": "Snacks"
+                    "example": "BRL"
                 },
-                "updatedAt": {
+                "expiresAt": {
                     "type": "string",
                     "example": "1970-01-01T00:00:00Z"
-                }
-            }
-        },
-        "response.CustomerResponse": {
-            "type": "object",
-            "properties": {
-                "createdAt": {
+                },
+                "failureReason": {
                     "type": "string",
-                    "example": "1970-01-01T00:00:00Z"
+                    "example": "insufficient funds"
                 },
-                "email": {
+                "id": {
                     "type": "string",
-                    "example": "john.doe@example.com"
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "firstName": {
+                "method": {
                     "type": "string",
-                    "example": "John"
+                    "example": "card"
                 },
-                "id": {
-                    "type": "integer"
+                "orderId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "lastName": {
+                "pixCode": {
                     "type": "string",
-                    "example": "Doe"
+                    "example": "00020101021226580014br.gov.bcb.pix..."
                 },
-                "updatedAt": {
+                "providerRef": {
+                    "type": "string",
+                    "example": "fake_ch_000001"
+                },
+                "settledAt": {
                     "type": "string",
                     "example": "1970-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "declined"
                 }
             }
         },
-        "response.DefaultResponse": {
-            "type": "object",
-            "properties": {
-                "data": {}
-            }
-        },
-        "response.ErrorResponse": {
+        "response.PickupBoardResponse": {
             "type": "object",
             "properties": {
-                "messages": {
+                "preparing": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "integer"
                     },
                     "example": [
-                        "Error message 1",
-                        " Error message 2"
+                        12,
+                        13
+                    ]
+                },
+                "ready": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        10,
+                        11
                     ]
                 }
             }
         },
-        "response.OrderProductResponse": {
+        "response.ProductResponse": {
             "type": "object",
             "properties": {
+                "category": {
+                    "$ref": "#/definitions/response.CategoryResponse"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "1970-01-01T00:00:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Potato chips with cheese flavor"
+                },
                 "id": {
                     "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "example": "1"
                 },
-                "notes": {
+                "name": {
                     "type": "string",
-                    "example": "notes"
+                    "example": "Potato Chips"
                 },
-                "product": {
-                    "$ref": "#/definitions/response.ProductResponse"
+                "price": {
+                    "type": "number",
+                    "example": 10.5
                 },
-                "productId": {
+                "updatedAt": {
                     "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "example": "1970-01-01T00:00:00Z"
                 },
-                "quantity": {
+                "version": {
                     "type": "integer",
                     "example": 1
                 }
             }
         },
-        "response.OrderResponse": {
+        "response.RefundResponse": {
             "type": "object",
             "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 10.5
+                },
                 "createdAt": {
                     "type": "string",
                     "example": "1970-01-01T00:00:00Z"
                 },
-                "customerId": {
-                    "type": "integer",
-                    "example": 1
+                "createdBy": {
+                    "type": "string",
+                    "example": "manager"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
                 },
                 "id": {
                     "type": "string",
-                    "example": "1"
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.OrderProductResponse"
-                    }
+                "orderId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "readyAt": {
+                "paymentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "providerRef": {
+                    "type": "string",
+                    "example": "fake_re_000001"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "completed"
+                }
+            }
+        },
+        "response.StaffAuthResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expiresAt": {
                     "type": "string",
                     "example": "1970-01-01T00:00:00Z"
                 },
-                "startedAt": {
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "staff": {
+                    "$ref": "#/definitions/response.StaffUserResponse"
+                },
+                "tokenType": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "response.StaffUserResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string",
                     "example": "1970-01-01T00:00:00Z"
                 },
-                "status": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string",
-                    "example": "pending"
+                    "example": "Jane Doe"
                 },
-                "total": {
-                    "type": "number",
-                    "example": 100
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Role"
+                        }
+                    ],
+                    "example": "cashier"
                 },
-                "trackingNumber": {
+                "username": {
+                    "type": "string",
+                    "example": "jane"
+                },
+                "version": {
                     "type": "integer",
                     "example": 1
                 }
             }
         },
-        "response.ProductResponse": {
+        "response.UncollectedOrderResponse": {
             "type": "object",
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/response.CategoryResponse"
+                "orderId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "createdAt": {
+                "readyAt": {
                     "type": "string",
                     "example": "1970-01-01T00:00:00Z"
                 },
-                "description": {
+                "trackingNumber": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "waitingSeconds": {
+                    "type": "integer",
+                    "example": 300
+                }
+            }
+        },
+        "response.UncollectedReportResponse": {
+            "type": "object",
+            "properties": {
+                "averageWaitSeconds": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "delivered": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "maxWaitSeconds": {
+                    "type": "integer",
+                    "example": 600
+                },
+                "since": {
                     "type": "string",
-                    "example": "Potato chips with cheese flavor"
+                    "example": "1970-01-01T00:00:00Z"
                 },
-                "id": {
+                "waiting": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.UncollectedOrderResponse"
+                    }
+                }
+            }
+        },
+        "response.WebSocketMessage": {
+            "type": "object",
+            "properties": {
+                "eventId": {
                     "type": "string",
                     "example": "1"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "Potato Chips"
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Error message 1",
+                        " Error message 2"
+                    ]
                 },
-                "price": {
-                    "type": "number",
-                    "example": 10000
+                "order": {
+                    "$ref": "#/definitions/response.OrderStatusEventResponse"
                 },
-                "updatedAt": {
+                "ref": {
                     "type": "string",
-                    "example": "1970-01-01T00:00:00Z"
+                    "example": "1"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "status"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "\"Bearer \" followed by the access token of a customer, a guest or a staff user",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
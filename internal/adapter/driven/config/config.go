@@ -2,15 +2,24 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type (
 	Container struct {
-		App  *App
-		DB   *DB
-		HTTP *HTTP
+		App      *App
+		DB       *DB
+		HTTP     *HTTP
+		Payment  *Payment
+		Board    *Board
+		Store    *Store
+		Outbox   *Outbox
+		Auth     *Auth
+		Staff    *Staff
+		Notifier *Notifier
 	}
 
 	App struct {
@@ -33,6 +42,68 @@ type (
 		Port           string
 		AllowedOrigins string
 	}
+
+	Payment struct {
+		// the provider charging the orders, only "fake" for now
+		Provider      string
+		WebhookSecret string
+		SettleAfter   time.Duration
+		SyncInterval  time.Duration
+		Pix           *Pix
+	}
+
+	Pix struct {
+		Mode         string
+		Key          string
+		MerchantName string
+		MerchantCity string
+		ExpiresAfter time.Duration
+	}
+
+	Board struct {
+		ReadyFor time.Duration
+	}
+
+	Store struct {
+		// time of day, since midnight, the store opens
+		OpensAt  time.Duration
+		Location *time.Location
+	}
+
+	Auth struct {
+		// signing algorithm of the tokens, "HS256" or "EdDSA"
+		Algorithm string
+		// HS256 shared secret
+		Secret string
+		// EdDSA PEM encoded PKCS #8 Ed25519 private key file
+		PrivateKeyFile string
+		Issuer         string
+		AccessTTL      time.Duration
+		RefreshTTL     time.Duration
+		// how long the tokens mailed to the customers are valid
+		PasswordResetTTL     time.Duration
+		EmailVerificationTTL time.Duration
+	}
+
+	Staff struct {
+		// first admin account, created when there are no staff users yet
+		AdminUsername string
+		AdminPassword string
+	}
+
+	Notifier struct {
+		// where the notifications to the customers are written to, as there
+		// is no mail server yet, the standard output when empty
+		File string
+	}
+
+	Outbox struct {
+		// where order events are published to, "file" or "memory"
+		Publisher     string
+		File          string
+		RelayInterval time.Duration
+		BatchSize     int
+	}
 )
 
 func New() (*Container, error) {
@@ -64,9 +135,117 @@ func New() (*Container, error) {
 		AllowedOrigins: os.Getenv("HTTP_ALLOWED_ORIGINS"),
 	}
 
+	payment := &Payment{
+		Provider:      os.Getenv("PAYMENT_PROVIDER"),
+		WebhookSecret: os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+		SettleAfter:   durationFromEnv("PAYMENT_SETTLE_AFTER", 5*time.Second),
+		SyncInterval:  durationFromEnv("PAYMENT_SYNC_INTERVAL", 5*time.Second),
+		Pix: &Pix{
+			Mode:         os.Getenv("PIX_MODE"),
+			Key:          os.Getenv("PIX_KEY"),
+			MerchantName: os.Getenv("PIX_MERCHANT_NAME"),
+			MerchantCity: os.Getenv("PIX_MERCHANT_CITY"),
+			ExpiresAfter: durationFromEnv("PIX_EXPIRES_AFTER", 15*time.Minute),
+		},
+	}
+
+	board := &Board{
+		ReadyFor: durationFromEnv("BOARD_READY_FOR", 10*time.Minute),
+	}
+
+	store := &Store{
+		OpensAt:  clockFromEnv("STORE_OPENS_AT", 6*time.Hour),
+		Location: locationFromEnv("STORE_TIMEZONE"),
+	}
+
+	outbox := &Outbox{
+		Publisher:     os.Getenv("OUTBOX_PUBLISHER"),
+		File:          os.Getenv("OUTBOX_FILE"),
+		RelayInterval: durationFromEnv("OUTBOX_RELAY_INTERVAL", time.Second),
+		BatchSize:     intFromEnv("OUTBOX_BATCH_SIZE", 100),
+	}
+	if outbox.File == "" {
+		outbox.File = "outbox.jsonl"
+	}
+
+	auth := &Auth{
+		Algorithm:      os.Getenv("AUTH_ALGORITHM"),
+		Secret:         os.Getenv("AUTH_SECRET"),
+		PrivateKeyFile: os.Getenv("AUTH_PRIVATE_KEY_FILE"),
+		Issuer:         os.Getenv("AUTH_ISSUER"),
+		AccessTTL:      durationFromEnv("AUTH_ACCESS_TTL", 15*time.Minute),
+		RefreshTTL:     durationFromEnv("AUTH_REFRESH_TTL", 30*24*time.Hour),
+
+		PasswordResetTTL:     durationFromEnv("AUTH_PASSWORD_RESET_TTL", time.Hour),
+		EmailVerificationTTL: durationFromEnv("AUTH_EMAIL_VERIFICATION_TTL", 48*time.Hour),
+	}
+	if auth.Algorithm == "" {
+		auth.Algorithm = "HS256"
+	}
+	if auth.Issuer == "" {
+		auth.Issuer = app.Name
+	}
+
+	staff := &Staff{
+		AdminUsername: os.Getenv("STAFF_ADMIN_USERNAME"),
+		AdminPassword: os.Getenv("STAFF_ADMIN_PASSWORD"),
+	}
+
+	notifier := &Notifier{
+		File: os.Getenv("NOTIFIER_FILE"),
+	}
+
 	return &Container{
 		app,
 		db,
 		http,
+		payment,
+		board,
+		store,
+		outbox,
+		auth,
+		staff,
+		notifier,
 	}, nil
 }
+
+// durationFromEnv parses a duration such as "5s" from the environment,
+// returning the fallback when it is not set or invalid.
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return d
+}
+
+// intFromEnv parses a positive number from the environment, returning the
+// fallback when it is not set or invalid.
+func intFromEnv(key string, fallback int) int {
+	n, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
+
+// clockFromEnv parses a time of day such as "06:30" from the environment, as
+// the time since midnight, returning the fallback when it is not set or
+// invalid.
+func clockFromEnv(key string, fallback time.Duration) time.Duration {
+	t, err := time.Parse("15:04", os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+// locationFromEnv loads a time zone such as "America/Sao_Paulo" from the
+// environment, returning the local time zone when it is not set or unknown.
+func locationFromEnv(key string) *time.Location {
+	loc, err := time.LoadLocation(os.Getenv(key))
+	if err != nil || os.Getenv(key) == "" {
+		return time.Local
+	}
+	return loc
+}
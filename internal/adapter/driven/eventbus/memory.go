@@ -0,0 +1,104 @@
+// Package eventbus delivers order events within the app process.
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// MemoryBus is an in-process port.OrderEventBus.
+//
+// It keeps the last historySize events to replay them to subscribers
+// resuming from an earlier event, and sends a reset event instead to those
+// resuming from an event older than that. Each subscriber has a buffer of bufferSize
+// events, and is dropped when it fills up instead of blocking publishers.
+type MemoryBus struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []*domain.OrderStatusEvent
+	historySize int
+	bufferSize  int
+	subscribers map[chan *domain.OrderStatusEvent]struct{}
+}
+
+func NewMemoryBus(historySize int, bufferSize int) *MemoryBus {
+	return &MemoryBus{
+		// event IDs keep increasing across restarts, so clients resuming
+		// from an event of an earlier process get every event of this one
+		lastID:      uint64(time.Now().UnixMicro()),
+		historySize: historySize,
+		bufferSize:  bufferSize,
+		subscribers: make(map[chan *domain.OrderStatusEvent]struct{}),
+	}
+}
+
+func (b *MemoryBus) Publish(ctx context.Context, event *domain.OrderStatusEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+
+	b.history = append(b.history, event)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			b.unsubscribe(ch)
+		}
+	}
+}
+
+func (b *MemoryBus) Subscribe(ctx context.Context, lastEventID uint64) <-chan *domain.OrderStatusEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan *domain.OrderStatusEvent, b.bufferSize+len(b.history))
+	if b.missed(lastEventID) {
+		ch <- domain.NewOrderStatusResetEvent(b.lastID)
+	} else if lastEventID > 0 {
+		for _, event := range b.history {
+			if event.ID > lastEventID {
+				ch <- event
+			}
+		}
+	}
+	b.subscribers[ch] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.unsubscribe(ch)
+	}()
+
+	return ch
+}
+
+// missed tells whether events published after lastEventID are no longer in
+// the history, including those of an earlier process. It must be called with
+// the lock held.
+func (b *MemoryBus) missed(lastEventID uint64) bool {
+	if lastEventID == 0 || lastEventID >= b.lastID {
+		return false
+	}
+	return len(b.history) == 0 || b.history[0].ID > lastEventID+1
+}
+
+// unsubscribe closes the channel of a subscriber, if it was not dropped yet.
+// It must be called with the lock held.
+func (b *MemoryBus) unsubscribe(ch chan *domain.OrderStatusEvent) {
+	if _, ok := b.subscribers[ch]; !ok {
+		return
+	}
+	delete(b.subscribers, ch)
+	close(ch)
+}
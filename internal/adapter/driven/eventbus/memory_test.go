@@ -0,0 +1,83 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+func TestMemoryBus_Subscribe(t *testing.T) {
+	ctx := context.Background()
+	bus := NewMemoryBus(2, 10)
+
+	first := &domain.OrderStatusEvent{OrderID: domain.NewID()}
+	second := &domain.OrderStatusEvent{OrderID: domain.NewID()}
+	third := &domain.OrderStatusEvent{OrderID: domain.NewID()}
+
+	bus.Publish(ctx, first)
+	bus.Publish(ctx, second)
+	require.Greater(t, second.ID, first.ID)
+
+	t.Run("Receive new events only", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		events := bus.Subscribe(ctx, 0)
+		require.Empty(t, events)
+
+		cancel()
+		_, ok := <-events
+		require.False(t, ok)
+	})
+
+	t.Run("Replay events after the last one received", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		events := bus.Subscribe(ctx, first.ID)
+		require.Equal(t, second, <-events)
+
+		bus.Publish(ctx, third)
+		require.Equal(t, third, <-events)
+	})
+
+	t.Run("Reset when the events after the last one received are gone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		fourth := &domain.OrderStatusEvent{OrderID: domain.NewID()}
+		bus.Publish(ctx, fourth)
+
+		// second is no longer retained
+		events := bus.Subscribe(ctx, first.ID)
+		event := <-events
+		require.True(t, event.Reset)
+		require.Equal(t, fourth.ID, event.ID)
+		require.Empty(t, events)
+	})
+
+	t.Run("Reset when resuming from an earlier process", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		events := NewMemoryBus(2, 10).Subscribe(ctx, first.ID)
+		require.True(t, (<-events).Reset)
+	})
+}
+
+func TestMemoryBus_Publish(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := NewMemoryBus(10, 1)
+	slow := bus.Subscribe(ctx, 0)
+
+	// the publisher does not wait for the subscriber to catch up
+	bus.Publish(ctx, &domain.OrderStatusEvent{})
+	bus.Publish(ctx, &domain.OrderStatusEvent{})
+
+	_, ok := <-slow
+	require.True(t, ok)
+	_, ok = <-slow
+	require.False(t, ok)
+}
@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// LocalNotifier writes the notifications to a file, one JSON object per line,
+// or to the standard output when no file is given, to be used in development
+// in place of a mail server.
+type LocalNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewLocalNotifier(path string) *LocalNotifier {
+	return &LocalNotifier{path: path}
+}
+
+// localNotification is a notification as written by LocalNotifier.
+type localNotification struct {
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sentAt"`
+}
+
+func (n *LocalNotifier) Notify(ctx context.Context, m *domain.Notification) error {
+	line, err := json.Marshal(localNotification{
+		To:      m.To,
+		Subject: m.Subject,
+		Body:    m.Body,
+		SentAt:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.path == "" {
+		return write(os.Stdout, line)
+	}
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if err := write(f, line); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func write(w io.Writer, line []byte) error {
+	_, err := w.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,41 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+func TestLocalNotifier_Notify(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "notifications.jsonl")
+	notifier := NewLocalNotifier(path)
+
+	require.NoError(t, notifier.Notify(ctx, &domain.Notification{To: "john.doe@example.com", Subject: "Verify your email", Body: "secret"}))
+	require.NoError(t, notifier.Notify(ctx, &domain.Notification{To: "jane.doe@example.com", Subject: "Reset your password", Body: "other secret"}))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var lines []localNotification
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line localNotification
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+
+	require.Len(t, lines, 2)
+	require.Equal(t, "john.doe@example.com", lines[0].To)
+	require.Equal(t, "Verify your email", lines[0].Subject)
+	require.Equal(t, "secret", lines[0].Body)
+	require.False(t, lines[0].SentAt.IsZero())
+	require.Equal(t, "jane.doe@example.com", lines[1].To)
+}
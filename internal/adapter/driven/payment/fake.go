@@ -0,0 +1,161 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/config"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/payment/pix"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// declinedCents is the cents part of the amounts the fake gateway declines,
+// mirroring the test cards offered by real providers.
+const declinedCents = 13
+
+// fakePixLocation is where the fake PSP would serve dynamic PIX payloads.
+const fakePixLocation = "pix.example.com/qr/v2/"
+
+// FakeGateway is an in-memory payment gateway that settles charges
+// deterministically, to be used in development and tests.
+//
+// Charges stay pending until settleAfter has passed since their creation and
+// are then approved, except those whose cents are declinedCents: card charges
+// are declined and PIX charges are never paid, expiring after the configured
+// PIX expiry.
+type FakeGateway struct {
+	mu          sync.Mutex
+	charges     map[string]*domain.Charge
+	refunds     map[string]domain.Money
+	refundRefs  map[string]string
+	sequence    int
+	settleAfter time.Duration
+	pix         *config.Pix
+	now         func() time.Time
+}
+
+func NewFakeGateway(config *config.Payment) *FakeGateway {
+	return &FakeGateway{
+		charges:     make(map[string]*domain.Charge),
+		refunds:     make(map[string]domain.Money),
+		refundRefs:  make(map[string]string),
+		settleAfter: config.SettleAfter,
+		pix:         config.Pix,
+		now:         time.Now,
+	}
+}
+
+func (g *FakeGateway) CreateCharge(ctx context.Context, orderID domain.ID, amount domain.Money, method domain.PaymentMethod) (*domain.Charge, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.sequence++
+	charge := &domain.Charge{
+		Reference: fmt.Sprintf("fake_ch_%06d", g.sequence),
+		OrderID:   orderID,
+		Amount:    amount,
+		Method:    method,
+		Status:    domain.PaymentStatusPending,
+		CreatedAt: g.now(),
+	}
+
+	if method == domain.PaymentMethodPix {
+		expiresAt := charge.CreatedAt.Add(g.pix.ExpiresAfter)
+		charge.ExpiresAt = &expiresAt
+		charge.PixCode = g.pixCode(charge)
+	}
+
+	g.charges[charge.Reference] = charge
+
+	c := *charge
+	return &c, nil
+}
+
+func (g *FakeGateway) GetCharge(ctx context.Context, reference string) (*domain.Charge, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	charge, ok := g.charges[reference]
+	if !ok {
+		return nil, domain.ErrorDataNotFound
+	}
+
+	g.settle(charge)
+
+	c := *charge
+	return &c, nil
+}
+
+func (g *FakeGateway) Refund(ctx context.Context, reference string, amount domain.Money, idempotencyKey string) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if refundRef, ok := g.refundRefs[idempotencyKey]; ok {
+		return refundRef, nil
+	}
+
+	charge, ok := g.charges[reference]
+	if !ok {
+		return "", domain.ErrorDataNotFound
+	}
+
+	g.settle(charge)
+
+	if charge.Status != domain.PaymentStatusApproved || charge.Amount.Sub(g.refunds[reference]).Sub(amount).IsNegative() {
+		return "", domain.ErrorPaymentNotRefundable
+	}
+
+	g.refunds[reference] = g.refunds[reference].Add(amount)
+	g.sequence++
+
+	refundRef := fmt.Sprintf("fake_re_%06d", g.sequence)
+	g.refundRefs[idempotencyKey] = refundRef
+	return refundRef, nil
+}
+
+func (g *FakeGateway) settle(charge *domain.Charge) {
+	if charge.Status != domain.PaymentStatusPending {
+		return
+	}
+
+	now := g.now()
+	unpaid := charge.Amount.Cents%100 == declinedCents
+
+	if charge.Method == domain.PaymentMethodPix {
+		if charge.IsExpired(now) {
+			charge.Status = domain.PaymentStatusExpired
+			charge.FailureReason = "pix code expired"
+			return
+		}
+		if unpaid {
+			return
+		}
+	}
+
+	if now.Sub(charge.CreatedAt) < g.settleAfter {
+		return
+	}
+
+	charge.Status = domain.PaymentStatusApproved
+	if unpaid {
+		charge.Status = domain.PaymentStatusDeclined
+		charge.FailureReason = "declined by the fake gateway"
+	}
+}
+
+// pixCode returns the BR Code of a PIX charge: a static code for the merchant
+// key, or a dynamic code pointing to the fake PSP.
+func (g *FakeGateway) pixCode(charge *domain.Charge) string {
+	merchant := pix.Merchant{
+		Key:  g.pix.Key,
+		Name: g.pix.MerchantName,
+		City: g.pix.MerchantCity,
+	}
+
+	if g.pix.Mode == "static" {
+		return pix.StaticCode(merchant, charge.Amount, charge.Reference)
+	}
+	return pix.DynamicCode(merchant, fakePixLocation+charge.Reference)
+}
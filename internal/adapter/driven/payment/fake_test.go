@@ -0,0 +1,119 @@
+package payment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/config"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+func newTestGateway(settleAfter time.Duration, pixMode string) *FakeGateway {
+	return NewFakeGateway(&config.Payment{
+		SettleAfter: settleAfter,
+		Pix: &config.Pix{
+			Mode:         pixMode,
+			Key:          "123e4567-e12b-12d1-a456-426655440000",
+			MerchantName: "Fulano de Tal",
+			MerchantCity: "BRASILIA",
+			ExpiresAfter: 10 * time.Minute,
+		},
+	})
+}
+
+func TestFakeGateway_Settle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	g := newTestGateway(time.Minute, "dynamic")
+	g.now = func() time.Time { return now }
+
+	approved, err := g.CreateCharge(ctx, domain.NewID(), domain.NewMoney(2550), domain.PaymentMethodCard)
+	require.NoError(t, err)
+	declined, err := g.CreateCharge(ctx, domain.NewID(), domain.NewMoney(1013), domain.PaymentMethodCard)
+	require.NoError(t, err)
+	require.NotEqual(t, approved.Reference, declined.Reference)
+
+	charge, err := g.GetCharge(ctx, approved.Reference)
+	require.NoError(t, err)
+	require.Equal(t, domain.PaymentStatusPending, charge.Status)
+
+	now = now.Add(time.Minute)
+
+	charge, err = g.GetCharge(ctx, approved.Reference)
+	require.NoError(t, err)
+	require.Equal(t, domain.PaymentStatusApproved, charge.Status)
+
+	charge, err = g.GetCharge(ctx, declined.Reference)
+	require.NoError(t, err)
+	require.Equal(t, domain.PaymentStatusDeclined, charge.Status)
+
+	_, err = g.GetCharge(ctx, "unknown")
+	require.ErrorIs(t, err, domain.ErrorDataNotFound)
+}
+
+func TestFakeGateway_Refund(t *testing.T) {
+	ctx := context.Background()
+	g := newTestGateway(0, "dynamic")
+
+	charge, err := g.CreateCharge(ctx, domain.NewID(), domain.NewMoney(2000), domain.PaymentMethodCard)
+	require.NoError(t, err)
+
+	first, err := g.Refund(ctx, charge.Reference, domain.NewMoney(1500), "re_key_1")
+	require.NoError(t, err)
+
+	// asked for again, the money is not given back twice
+	again, err := g.Refund(ctx, charge.Reference, domain.NewMoney(1500), "re_key_1")
+	require.NoError(t, err)
+	require.Equal(t, first, again)
+
+	_, err = g.Refund(ctx, charge.Reference, domain.NewMoney(1000), "re_key_2")
+	require.ErrorIs(t, err, domain.ErrorPaymentNotRefundable)
+
+	_, err = g.Refund(ctx, charge.Reference, domain.NewMoney(500), "re_key_3")
+	require.NoError(t, err)
+}
+
+func TestFakeGateway_Pix(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("dynamic code", func(t *testing.T) {
+		g := newTestGateway(time.Minute, "dynamic")
+		g.now = func() time.Time { return now }
+
+		charge, err := g.CreateCharge(ctx, domain.NewID(), domain.NewMoney(2550), domain.PaymentMethodPix)
+		require.NoError(t, err)
+		require.Contains(t, charge.PixCode, fakePixLocation+charge.Reference)
+		require.Equal(t, now.Add(10*time.Minute), *charge.ExpiresAt)
+	})
+
+	t.Run("static code", func(t *testing.T) {
+		g := newTestGateway(time.Minute, "static")
+
+		charge, err := g.CreateCharge(ctx, domain.NewID(), domain.NewMoney(2550), domain.PaymentMethodPix)
+		require.NoError(t, err)
+		require.Contains(t, charge.PixCode, "0136123e4567-e12b-12d1-a456-426655440000")
+		require.Contains(t, charge.PixCode, "540525.50")
+	})
+
+	t.Run("unpaid code expires", func(t *testing.T) {
+		g := newTestGateway(time.Minute, "dynamic")
+		g.now = func() time.Time { return now }
+
+		charge, err := g.CreateCharge(ctx, domain.NewID(), domain.NewMoney(1013), domain.PaymentMethodPix)
+		require.NoError(t, err)
+
+		now = now.Add(5 * time.Minute)
+		charge, err = g.GetCharge(ctx, charge.Reference)
+		require.NoError(t, err)
+		require.Equal(t, domain.PaymentStatusPending, charge.Status)
+
+		now = now.Add(10 * time.Minute)
+		charge, err = g.GetCharge(ctx, charge.Reference)
+		require.NoError(t, err)
+		require.Equal(t, domain.PaymentStatusExpired, charge.Status)
+	})
+}
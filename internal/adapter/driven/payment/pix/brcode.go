@@ -0,0 +1,146 @@
+// Package pix builds BR Code payloads, the EMV QR Code "copia e cola" strings
+// defined by Banco Central do Brasil for PIX payments.
+package pix
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"golang.org/x/text/unicode/norm"
+)
+
+// EMV field IDs used by the BR Code.
+const (
+	idPayloadFormat       = "00"
+	idPointOfInitiation   = "01"
+	idMerchantAccount     = "26"
+	idMerchantAccountGUI  = "00"
+	idMerchantAccountKey  = "01"
+	idMerchantAccountURL  = "25"
+	idMerchantCategory    = "52"
+	idTransactionCurrency = "53"
+	idTransactionAmount   = "54"
+	idCountryCode         = "58"
+	idMerchantName        = "59"
+	idMerchantCity        = "60"
+	idAdditionalData      = "62"
+	idAdditionalDataTxID  = "05"
+	idCRC16               = "63"
+)
+
+const (
+	gui            = "br.gov.bcb.pix"
+	currencyBRL    = "986"
+	maxNameLength  = 25
+	maxCityLength  = 15
+	maxTxIDLength  = 25
+	reusableCode   = "11"
+	singleUseCode  = "12"
+	noTxID         = "***"
+	crc16Length    = "04"
+	payloadVersion = "01"
+)
+
+// Merchant identifies who receives the PIX payments.
+type Merchant struct {
+	Key  string
+	Name string
+	City string
+}
+
+// StaticCode returns a BR Code paying amount to the merchant PIX key. The
+// txid identifies the payment in the merchant bank statement.
+func StaticCode(m Merchant, amount domain.Money, txid string) string {
+	account := field(idMerchantAccountGUI, gui) + field(idMerchantAccountKey, m.Key)
+
+	var amountField string
+	if amount.IsPositive() {
+		amountField = field(idTransactionAmount, amount.String())
+	}
+
+	return withCRC16(
+		field(idPayloadFormat, payloadVersion) +
+			field(idMerchantAccount, account) +
+			field(idMerchantCategory, "0000") +
+			field(idTransactionCurrency, currencyBRL) +
+			amountField +
+			field(idCountryCode, "BR") +
+			field(idMerchantName, sanitize(m.Name, maxNameLength)) +
+			field(idMerchantCity, sanitize(m.City, maxCityLength)) +
+			field(idAdditionalData, field(idAdditionalDataTxID, txID(txid))),
+	)
+}
+
+// DynamicCode returns a single use BR Code whose payment details, amount
+// included, are served by the PSP at location, an URL without its scheme.
+func DynamicCode(m Merchant, location string) string {
+	account := field(idMerchantAccountGUI, gui) + field(idMerchantAccountURL, location)
+
+	return withCRC16(
+		field(idPayloadFormat, payloadVersion) +
+			field(idPointOfInitiation, singleUseCode) +
+			field(idMerchantAccount, account) +
+			field(idMerchantCategory, "0000") +
+			field(idTransactionCurrency, currencyBRL) +
+			field(idCountryCode, "BR") +
+			field(idMerchantName, sanitize(m.Name, maxNameLength)) +
+			field(idMerchantCity, sanitize(m.City, maxCityLength)) +
+			field(idAdditionalData, field(idAdditionalDataTxID, noTxID)),
+	)
+}
+
+// CRC16 returns the CRC16-CCITT (polynomial 0x1021, initial value 0xFFFF)
+// of the payload, as the 4 uppercase hex digits used by the BR Code.
+func CRC16(payload string) string {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(payload); i++ {
+		crc ^= uint16(payload[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
+
+func withCRC16(payload string) string {
+	payload += idCRC16 + crc16Length
+	return payload + CRC16(payload)
+}
+
+// field encodes an EMV ID-length-value field.
+func field(id string, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// txID keeps the alphanumeric characters allowed in a static BR Code txid.
+func txID(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) && b.Len() < maxTxIDLength {
+			b.WriteRune(r)
+		}
+	}
+
+	if b.Len() == 0 {
+		return noTxID
+	}
+	return b.String()
+}
+
+// sanitize removes accents and non ASCII characters, which are not allowed
+// in the merchant name and city, and truncates the value to max characters.
+func sanitize(s string, max int) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if r < unicode.MaxASCII && b.Len() < max {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
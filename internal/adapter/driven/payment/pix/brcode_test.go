@@ -0,0 +1,44 @@
+package pix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+func TestCRC16(t *testing.T) {
+	require.Equal(t, "29B1", CRC16("123456789"))
+}
+
+func TestStaticCode(t *testing.T) {
+	merchant := Merchant{
+		Key:  "123e4567-e12b-12d1-a456-426655440000",
+		Name: "Fulano de Tal",
+		City: "BRASILIA",
+	}
+
+	t.Run("without amount", func(t *testing.T) {
+		// example from the BR Code manual of Banco Central do Brasil
+		code := StaticCode(merchant, domain.NewMoney(0), "")
+		require.Equal(t, "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D", code)
+	})
+
+	t.Run("with amount and txid", func(t *testing.T) {
+		code := StaticCode(merchant, domain.NewMoney(2550), "fake_ch_000001")
+		require.Contains(t, code, "540525.50")
+		require.Contains(t, code, "62160512fakech000001")
+		require.Equal(t, CRC16(code[:len(code)-4]), code[len(code)-4:])
+	})
+}
+
+func TestDynamicCode(t *testing.T) {
+	merchant := Merchant{Name: "Lanchonete São João", City: "São Paulo"}
+
+	code := DynamicCode(merchant, "pix.example.com/qr/v2/fake_ch_000001")
+	require.Contains(t, code, "010212")
+	require.Contains(t, code, "2536pix.example.com/qr/v2/fake_ch_000001")
+	require.Contains(t, code, "5919Lanchonete Sao Joao")
+	require.Contains(t, code, "6009Sao Paulo")
+	require.Equal(t, CRC16(code[:len(code)-4]), code[len(code)-4:])
+}
@@ -0,0 +1,58 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// FilePublisher appends the published messages to a file, one JSON object per
+// line, to be used in development in place of a message broker.
+type FilePublisher struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFilePublisher(path string) *FilePublisher {
+	return &FilePublisher{path: path}
+}
+
+// fileMessage is a message as written to the file.
+type fileMessage struct {
+	ID          uint64          `json:"id"`
+	AggregateID domain.ID       `json:"aggregateId"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+func (p *FilePublisher) Publish(ctx context.Context, m *domain.OutboxMessage) error {
+	line, err := json.Marshal(fileMessage{
+		ID:          m.ID,
+		AggregateID: m.AggregateID,
+		Type:        m.Type,
+		Payload:     json.RawMessage(m.Payload),
+		CreatedAt:   m.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, err := os.OpenFile(p.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
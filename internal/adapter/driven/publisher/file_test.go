@@ -0,0 +1,47 @@
+package publisher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+func TestFilePublisher_Publish(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	publisher := NewFilePublisher(path)
+
+	orderID := domain.NewID()
+	first := domain.NewOutboxMessage(orderID, domain.OutboxOrderCreated, `{"status":"pending"}`)
+	first.ID = 1
+	second := domain.NewOutboxMessage(orderID, domain.OutboxOrderPaid, `{"status":"confirmed"}`)
+	second.ID = 2
+
+	require.NoError(t, publisher.Publish(ctx, first))
+	require.NoError(t, publisher.Publish(ctx, second))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var lines []fileMessage
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line fileMessage
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+
+	require.Len(t, lines, 2)
+	require.Equal(t, uint64(1), lines[0].ID)
+	require.Equal(t, orderID, lines[0].AggregateID)
+	require.Equal(t, domain.OutboxOrderCreated, lines[0].Type)
+	require.JSONEq(t, `{"status":"pending"}`, string(lines[0].Payload))
+	require.Equal(t, domain.OutboxOrderPaid, lines[1].Type)
+}
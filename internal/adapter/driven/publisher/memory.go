@@ -0,0 +1,35 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// MemoryPublisher keeps the published messages in memory, to be used in
+// development and tests.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []*domain.OutboxMessage
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, m *domain.OutboxMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, m)
+	return nil
+}
+
+// Messages returns the messages published so far, oldest first.
+func (p *MemoryPublisher) Messages() []*domain.OutboxMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]*domain.OutboxMessage(nil), p.messages...)
+}
@@ -0,0 +1,58 @@
+// Package memory has in-memory implementations of the storage ports, to be
+// used in unit tests.
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+// txKey is the context key marking calls made within a transaction.
+type txKey struct{}
+
+// Transactor is an in-memory port.Transactor. It runs fn right away, keeping
+// count of the transactions committed and rolled back, so tests can check
+// what the services ran atomically.
+type Transactor struct {
+	mu        sync.Mutex
+	commits   int
+	rollbacks int
+}
+
+func NewTransactor() *Transactor {
+	return &Transactor{}
+}
+
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(context.WithValue(ctx, txKey{}, true))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err != nil {
+		t.rollbacks++
+		return err
+	}
+	t.commits++
+	return nil
+}
+
+// Commits returns how many transactions were committed.
+func (t *Transactor) Commits() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.commits
+}
+
+// Rollbacks returns how many transactions were rolled back.
+func (t *Transactor) Rollbacks() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rollbacks
+}
+
+// InTransaction reports whether ctx is within a transaction of a Transactor.
+func InTransaction(ctx context.Context) bool {
+	in, _ := ctx.Value(txKey{}).(bool)
+	return in
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/vitovidale/fastfood-app/internal/adapter/driven/config"
 	"github.com/vitovidale/fastfood-app/internal/core/domain"
@@ -15,21 +16,175 @@ type DB struct {
 	*gorm.DB
 }
 
+// MaxTrackingNumber is the highest tracking number given to an order, after
+// which the numbers start over from 1.
+const MaxTrackingNumber = 999
+
+// TrackingNumberHoldTime is how long after being paid an order holds its
+// tracking number, after which the number can be given to another order.
+const TrackingNumberHoldTime = 24 * time.Hour
+
 func CreateOrderTrackingNumberSequence(db *gorm.DB) error {
-	return db.Exec(`
+	return db.Exec(fmt.Sprintf(`
 		CREATE SEQUENCE IF NOT EXISTS order_tracking_number_sequence
 		START 1
 		INCREMENT 1
 		MINVALUE 1
-		MAXVALUE 999
+		MAXVALUE %d
 		CYCLE
-	`).Error
+	`, MaxTrackingNumber)).Error
 }
 
 func ResetOrderTrackingNumberSequence(db *gorm.DB) error {
 	return db.Exec(`ALTER SEQUENCE order_tracking_number_sequence RESTART WITH 1`).Error
 }
 
+// RenamePaymentEventsTable moves the payment provider events, first stored in
+// the payments table, to payment_events, leaving payments to domain.Payment.
+func RenamePaymentEventsTable(db *gorm.DB) error {
+	m := db.Migrator()
+	if !m.HasTable("payments") || m.HasTable("payment_events") || !m.HasColumn("payments", "received_at") {
+		return nil
+	}
+	return m.RenameTable("payments", "payment_events")
+}
+
+// MigrateCustomerIDs moves the customers, first identified by their CPF as a
+// number, to domain.ID identifiers, keeping the CPF in its own column and
+// pointing the orders and guest sessions to the new identifiers.
+func MigrateCustomerIDs(db *gorm.DB) error {
+	var dataType string
+	err := db.Raw(`
+		SELECT data_type FROM information_schema.columns
+		WHERE table_schema = CURRENT_SCHEMA() AND table_name = 'customers' AND column_name = 'id'
+	`).Scan(&dataType).Error
+	if err != nil || dataType != "bigint" {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`ALTER TABLE customers ADD COLUMN cpf varchar(11)`,
+			`UPDATE customers SET cpf = LPAD(id::text, 11, '0')`,
+			`ALTER TABLE customers ADD COLUMN uuid varchar(36) NOT NULL DEFAULT gen_random_uuid()::text`,
+			`ALTER TABLE customers ALTER COLUMN uuid DROP DEFAULT`,
+		}
+
+		for _, table := range []string{"orders", "guest_sessions"} {
+			if !tx.Migrator().HasTable(table) {
+				continue
+			}
+			statements = append(statements,
+				fmt.Sprintf(`ALTER TABLE %s DROP CONSTRAINT IF EXISTS fk_%s_customer`, table, table),
+				fmt.Sprintf(`ALTER TABLE %s ADD COLUMN customer_uuid varchar(36)`, table),
+				fmt.Sprintf(`UPDATE %s t SET customer_uuid = c.uuid FROM customers c WHERE t.customer_id = c.id`, table),
+				fmt.Sprintf(`ALTER TABLE %s DROP COLUMN customer_id`, table),
+				fmt.Sprintf(`ALTER TABLE %s RENAME COLUMN customer_uuid TO customer_id`, table),
+			)
+		}
+
+		statements = append(statements,
+			`ALTER TABLE customers DROP COLUMN id`,
+			`ALTER TABLE customers RENAME COLUMN uuid TO id`,
+			`ALTER TABLE customers ADD PRIMARY KEY (id)`,
+		)
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// moneyColumns are the floating point columns, per table, replaced by the
+// integer cents columns of domain.Money, named after them plus "_cents".
+var moneyColumns = []struct{ table, column string }{
+	{"products", "price"},
+	{"orders", "total"},
+	{"order_products", "total"},
+	{"payments", "amount"},
+	{"refunds", "amount"},
+}
+
+// MigrateMoneyColumns copies the amounts stored as floating point columns to
+// the cents columns created by the auto migration, dropping the old columns.
+func MigrateMoneyColumns(db *gorm.DB) error {
+	for _, c := range moneyColumns {
+		if !db.Migrator().HasColumn(c.table, c.column) {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Exec(fmt.Sprintf(`UPDATE %s SET %s_cents = ROUND(%s * 100)`, c.table, c.column, c.column))
+			if result.Error != nil {
+				return result.Error
+			}
+			return tx.Migrator().DropColumn(c.table, c.column)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BackfillOrderProductUnitPrices sets the unit price of the order lines added
+// before it was captured, from their total and quantity.
+func BackfillOrderProductUnitPrices(db *gorm.DB) error {
+	return db.Exec(`
+		UPDATE order_products
+		SET unit_price_cents = total_cents / quantity, unit_price_currency = total_currency
+		WHERE unit_price_cents = 0 AND quantity > 0
+	`).Error
+}
+
+// MigratePickedUpOrders moves the done orders acknowledged as picked up,
+// before orders had a delivered status, to delivered, dropping the picked up
+// column.
+func MigratePickedUpOrders(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("orders", "picked_up_at") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+			UPDATE orders
+			SET status = ?, delivered_at = picked_up_at, version = version + 1
+			WHERE status = ? AND picked_up_at IS NOT NULL
+		`, domain.OrderStatusDelivered.String(), domain.OrderStatusDone.String())
+		if result.Error != nil {
+			return result.Error
+		}
+		return tx.Migrator().DropColumn("orders", "picked_up_at")
+	})
+}
+
+// ProtectOrderEvents makes the order history append-only, refusing to change
+// or delete the events recorded.
+func ProtectOrderEvents(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`CREATE OR REPLACE FUNCTION order_events_append_only() RETURNS trigger AS $$
+			BEGIN
+				RAISE EXCEPTION 'order_events is append-only';
+			END;
+			$$ LANGUAGE plpgsql`,
+			`DROP TRIGGER IF EXISTS order_events_append_only ON order_events`,
+			`CREATE TRIGGER order_events_append_only BEFORE UPDATE OR DELETE ON order_events
+			FOR EACH ROW EXECUTE FUNCTION order_events_append_only()`,
+		}
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func New(ctx context.Context, config *config.DB) (*DB, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		config.Host,
@@ -45,17 +200,52 @@ func New(ctx context.Context, config *config.DB) (*DB, error) {
 	}
 
 	// custom table definitions
-	db.SetupJoinTable(&domain.Order{}, "Products", &domain.OrderProduct{})
+	if err := db.SetupJoinTable(&domain.Order{}, "Products", &domain.OrderProduct{}); err != nil {
+		return nil, fmt.Errorf("setting up the order products table: %w", err)
+	}
+
+	if err := RenamePaymentEventsTable(db); err != nil {
+		return nil, fmt.Errorf("renaming the payment events table: %w", err)
+	}
+	if err := MigrateCustomerIDs(db); err != nil {
+		return nil, fmt.Errorf("migrating the customer ids: %w", err)
+	}
 
 	// run GORMs auto migration process
-	db.AutoMigrate(
+	err = db.AutoMigrate(
 		&domain.Category{},
 		&domain.Product{},
 		&domain.Customer{},
+		&domain.CustomerToken{},
+		&domain.GuestSession{},
 		&domain.Order{},
+		&domain.OrderEvent{},
+		&domain.PaymentEvent{},
+		&domain.Payment{},
+		&domain.Refund{},
+		&domain.OutboxMessage{},
+		&domain.RevokedToken{},
+		&domain.StaffUser{},
 	)
+	if err != nil {
+		return nil, fmt.Errorf("migrating the tables: %w", err)
+	}
 
-	CreateOrderTrackingNumberSequence(db)
+	if err := MigrateMoneyColumns(db); err != nil {
+		return nil, fmt.Errorf("migrating the money columns: %w", err)
+	}
+	if err := BackfillOrderProductUnitPrices(db); err != nil {
+		return nil, fmt.Errorf("backfilling the order product unit prices: %w", err)
+	}
+	if err := MigratePickedUpOrders(db); err != nil {
+		return nil, fmt.Errorf("migrating the picked up orders: %w", err)
+	}
+	if err := ProtectOrderEvents(db); err != nil {
+		return nil, fmt.Errorf("protecting the order events: %w", err)
+	}
+	if err := CreateOrderTrackingNumberSequence(db); err != nil {
+		return nil, fmt.Errorf("creating the tracking number sequence: %w", err)
+	}
 
 	return &DB{db}, nil
 }
@@ -2,30 +2,46 @@ package dtos
 
 import (
 	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
 )
 
 type Order struct {
 	ID             string         `json:"id" example:"00000000-0000-0000-0000-000000000000"`
-	CustomerID     uint64         `json:"customerId" example:"1"`
+	CustomerID     *string        `json:"customerId" example:"00000000-0000-0000-0000-000000000000"`
+	GuestID        *string        `json:"guestId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	GuestName      string         `json:"guestName,omitempty" example:"John"`
 	Status         string         `json:"status" example:"pending"`
-	Total          float64        `json:"total" example:"100"`
+	Total          domain.Money   `json:"total" gorm:"embedded;embeddedPrefix:total_" swaggertype:"number" example:"100"`
 	TrackingNumber *uint16        `json:"trackingNumber" example:"1"`
 	CreatedAt      time.Time      `json:"createdAt" example:"1970-01-01T00:00:00Z"`
+	DeliveredAt    *time.Time     `json:"deliveredAt,omitempty" example:"1970-01-01T00:00:00Z"`
+	CancelledAt    *time.Time     `json:"cancelledAt,omitempty" example:"1970-01-01T00:00:00Z"`
+	CancelReason   string         `json:"cancelReason,omitempty" example:"customer gave up"`
+	CancelledBy    string         `json:"cancelledBy,omitempty" example:"kiosk"`
+	RefundPending  bool           `json:"refundPending" example:"false"`
 	Products       []OrderProduct `json:"products" gorm:"foreignKey:OrderID"`
+	Version        uint64         `json:"version" example:"1"`
+}
+
+func (o Order) GetVersion() uint64 {
+	return o.Version
 }
 
 type OrderProduct struct {
-	ID        string  `json:"id" example:"00000000-0000-0000-0000-000000000000"`
-	OrderID   string  `json:"orderId" example:"00000000-0000-0000-0000-000000000000"`
-	ProductID string  `json:"productId" example:"00000000-0000-0000-0000-000000000000"`
-	Product   Product `json:"product"`
-	Quantity  uint16  `json:"quantity" example:"1"`
-	Notes     string  `json:"notes" example:"notes"`
+	ID        string       `json:"id" example:"00000000-0000-0000-0000-000000000000"`
+	OrderID   string       `json:"orderId" example:"00000000-0000-0000-0000-000000000000"`
+	ProductID string       `json:"productId" example:"00000000-0000-0000-0000-000000000000"`
+	Product   Product      `json:"product"`
+	Quantity  uint16       `json:"quantity" example:"1"`
+	UnitPrice domain.Money `json:"unitPrice" gorm:"embedded;embeddedPrefix:unit_price_" swaggertype:"number" example:"10.5"`
+	Total     domain.Money `json:"total" gorm:"embedded;embeddedPrefix:total_" swaggertype:"number" example:"21"`
+	Notes     string       `json:"notes" example:"notes"`
 }
 
 type Product struct {
-	ID          string  `json:"id" example:"00000000-0000-0000-0000-000000000000"`
-	Name        string  `json:"name" example:"product name"`
-	Description string  `json:"description" example:"product description"`
-	Price       float64 `json:"price" example:"100"`
+	ID          string       `json:"id" example:"00000000-0000-0000-0000-000000000000"`
+	Name        string       `json:"name" example:"product name"`
+	Description string       `json:"description" example:"product description"`
+	Price       domain.Money `json:"price" gorm:"embedded;embeddedPrefix:price_" swaggertype:"number" example:"100"`
 }
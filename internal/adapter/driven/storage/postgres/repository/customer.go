@@ -23,38 +23,51 @@ func (r *CustomerRepository) Create(ctx context.Context, c *domain.Customer) err
 	return nil
 }
 
-func (r *CustomerRepository) FindByID(ctx context.Context, id uint64) (*domain.Customer, error) {
+func (r *CustomerRepository) FindByID(ctx context.Context, id domain.ID) (*domain.Customer, error) {
 	c := &domain.Customer{}
 	result := r.db.WithContext(ctx).
 		Where("deleted_at IS NULL").
-		First(&c, id)
+		First(&c, "id = ?", id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return c, nil
 }
 
-func (r *CustomerRepository) FindByKeys(ctx context.Context, id uint64, email string) (*domain.Customer, error) {
+func (r *CustomerRepository) FindByCPF(ctx context.Context, cpf domain.CPF) (*domain.Customer, error) {
 	c := &domain.Customer{}
 	result := r.db.WithContext(ctx).
-		Where("id = ? OR email = ?", id, email).
 		Where("deleted_at IS NULL").
-		First(&c)
-
+		First(&c, "cpf = ?", cpf)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return c, nil
 }
 
-func (r *CustomerRepository) Patch(ctx context.Context, id uint64, data *domain.Customer) error {
+func (r *CustomerRepository) FindByKeys(ctx context.Context, cpf domain.CPF, email string) (*domain.Customer, error) {
+	c := &domain.Customer{}
 	result := r.db.WithContext(ctx).
-		Model(&domain.Customer{}).
-		Where("id = ?", id).
-		Updates(data)
+		Where("cpf = ? OR email = ?", cpf, email).
+		Where("deleted_at IS NULL").
+		First(&c)
 
 	if result.Error != nil {
-		return result.Error
+		return nil, result.Error
+	}
+	return c, nil
+}
+
+// Patch updates the non-zero fields of data, as long as the customer is still
+// at data.Version, and sets data.Version to the new version of the customer.
+func (r *CustomerRepository) Patch(ctx context.Context, id domain.ID, data *domain.Customer) error {
+	version := data.Version
+	data.Version++
+
+	err := updateVersioned(r.db.WithContext(ctx), &domain.Customer{}, id, version, data)
+	if err != nil {
+		data.Version = version
+		return err
 	}
 	return nil
 }
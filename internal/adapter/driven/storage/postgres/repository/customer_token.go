@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/postgres"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+type CustomerTokenRepository struct {
+	db *postgres.DB
+}
+
+func NewCustomerTokenRepository(db *postgres.DB) *CustomerTokenRepository {
+	return &CustomerTokenRepository{db: db}
+}
+
+func (r *CustomerTokenRepository) Create(ctx context.Context, t *domain.CustomerToken) error {
+	return r.db.WithContext(ctx).Create(t).Error
+}
+
+func (r *CustomerTokenRepository) FindByHash(ctx context.Context, hash string) (*domain.CustomerToken, error) {
+	t := &domain.CustomerToken{}
+	result := r.db.WithContext(ctx).
+		First(t, "hash = ?", hash)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return t, nil
+}
+
+func (r *CustomerTokenRepository) Use(ctx context.Context, t *domain.CustomerToken) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.CustomerToken{}).
+		Where("id = ? AND used_at IS NULL", t.ID).
+		Update("used_at", t.UsedAt)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
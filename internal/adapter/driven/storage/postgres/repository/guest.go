@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/postgres"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+type GuestRepository struct {
+	db *postgres.DB
+}
+
+func NewGuestRepository(db *postgres.DB) *GuestRepository {
+	return &GuestRepository{db: db}
+}
+
+func (r *GuestRepository) Create(ctx context.Context, g *domain.GuestSession) error {
+	return r.db.WithContext(ctx).Create(g).Error
+}
+
+func (r *GuestRepository) FindByID(ctx context.Context, id domain.ID) (*domain.GuestSession, error) {
+	g := &domain.GuestSession{}
+	result := r.db.WithContext(ctx).
+		First(g, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return g, nil
+}
+
+func (r *GuestRepository) Attach(ctx context.Context, g *domain.GuestSession) error {
+	return r.db.WithContext(ctx).
+		Model(g).
+		Select("customer_id", "attached_at").
+		Updates(g).Error
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/postgres"
 	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/postgres/dtos"
 	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderRepository struct {
@@ -17,11 +19,48 @@ func NewOrderRepository(db *postgres.DB) *OrderRepository {
 	return &OrderRepository{db: db}
 }
 
+// Save creates a new order, or writes every field of an existing order as long
+// as it is still at the version it was read at, recording the changes in the
+// order history and the outbox.
 func (r *OrderRepository) Save(ctx context.Context, o *domain.Order) (*domain.Order, error) {
-	result := r.db.WithContext(ctx).Save(&o)
-	if result.Error != nil {
-		return nil, result.Error
+	if o.Version == 0 {
+		o.Version = 1
+
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&o).Error; err != nil {
+				return err
+			}
+			if err := recordEvent(tx, o.ID, domain.OrderEventCreated, "", o.Status); err != nil {
+				return err
+			}
+			return enqueueMessage(tx, o, domain.OutboxOrderCreated)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return o, nil
 	}
+
+	next := *o
+	next.Version++
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := findOrder(tx, o.ID)
+		if err != nil {
+			return err
+		}
+
+		err = updateVersioned(tx.Select("*").Omit(clause.Associations), &domain.Order{}, o.ID, o.Version, &next)
+		if err != nil {
+			return err
+		}
+		return recordChanges(tx, before, &next)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	o.Version = next.Version
 	return o, nil
 }
 
@@ -54,7 +93,21 @@ func (r *OrderRepository) List(ctx context.Context) ([]*domain.Order, error) {
 
 	result := r.db.WithContext(ctx).
 		Order("status ASC").
-		Where("deleted_at IS NULL AND status NOT IN (?, ?)", domain.OrderStatusCancelled.String(), domain.OrderStatusDone.String()).
+		Where("deleted_at IS NULL AND status NOT IN ?", releasedTrackingStatuses).
+		Find(&orders)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return orders, nil
+}
+
+func (r *OrderRepository) FindByStatus(ctx context.Context, status domain.OrderStatus) ([]*domain.Order, error) {
+	var orders []*domain.Order
+
+	result := r.db.WithContext(ctx).
+		Order("created_at ASC").
+		Where("deleted_at IS NULL AND status = ?", status.String()).
 		Find(&orders)
 
 	if result.Error != nil {
@@ -78,11 +131,11 @@ func (r *OrderRepository) FindNestedByID(ctx context.Context, id domain.ID) (any
 	return data, nil
 }
 
-func (r *OrderRepository) FindByCustomer(ctx context.Context, id uint64) (*domain.Order, error) {
+func (r *OrderRepository) FindByCustomer(ctx context.Context, id domain.ID) (*domain.Order, error) {
 	o := &domain.Order{}
 
 	result := r.db.WithContext(ctx).
-		First(&o, "customer_id = ? AND deleted_at IS NULL AND status NOT IN (?, ?)", id, domain.OrderStatusCancelled.String(), domain.OrderStatusDone.String())
+		First(&o, "customer_id = ? AND deleted_at IS NULL AND status = ?", id, domain.OrderStatusPending.String())
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -90,6 +143,84 @@ func (r *OrderRepository) FindByCustomer(ctx context.Context, id uint64) (*domai
 	return o, nil
 }
 
+func (r *OrderRepository) FindByGuest(ctx context.Context, guestId domain.ID) (*domain.Order, error) {
+	o := &domain.Order{}
+
+	result := r.db.WithContext(ctx).
+		First(&o, "guest_id = ? AND deleted_at IS NULL AND status = ?", guestId, domain.OrderStatusPending.String())
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return o, nil
+}
+
+// FindPageByCustomer reads one more order than the limit to tell whether there
+// is a next page.
+func (r *OrderRepository) FindPageByCustomer(ctx context.Context, customerId domain.ID, filter domain.OrderFilter) (*domain.OrderPage, error) {
+	query := r.db.WithContext(ctx).
+		Where("customer_id = ? AND deleted_at IS NULL", customerId)
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if filter.After != nil {
+		query = query.Where("(created_at, id) < (?, ?)", filter.After.CreatedAt, filter.After.ID)
+	}
+
+	var orders []*domain.Order
+	result := query.
+		Order("created_at DESC, id DESC").
+		Limit(filter.Limit + 1).
+		Find(&orders)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	page := &domain.OrderPage{Orders: []*domain.OrderDetail{}}
+	if len(orders) > filter.Limit {
+		orders = orders[:filter.Limit]
+		last := orders[len(orders)-1]
+		page.Next = &domain.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	if len(orders) == 0 {
+		return page, nil
+	}
+
+	ids := make([]domain.ID, 0, len(orders))
+	for _, o := range orders {
+		ids = append(ids, o.ID)
+	}
+
+	var lines []*domain.OrderProduct
+	result = r.db.WithContext(ctx).
+		Preload("Product").
+		Order("created_at ASC").
+		Where("order_id IN ?", ids).
+		Find(&lines)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	byOrder := map[domain.ID][]*domain.OrderProduct{}
+	for _, line := range lines {
+		byOrder[line.OrderID] = append(byOrder[line.OrderID], line)
+	}
+
+	for _, o := range orders {
+		page.Orders = append(page.Orders, &domain.OrderDetail{Order: *o, Lines: byOrder[o.ID]})
+	}
+	return page, nil
+}
+
 func (r *OrderRepository) FindOrderProduct(ctx context.Context, orderProductId domain.ID) (*domain.OrderProduct, error) {
 	p := &domain.OrderProduct{}
 
@@ -102,56 +233,432 @@ func (r *OrderRepository) FindOrderProduct(ctx context.Context, orderProductId d
 	return p, nil
 }
 
+// AddProduct adds a line to an order and recalculates the order total in the
+// same transaction, so concurrent changes to an order are never lost.
 func (r *OrderRepository) AddProduct(ctx context.Context, p *domain.OrderProduct) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockCart(tx, p.OrderID); err != nil {
+			return err
+		}
+
+		result := tx.Create(&p)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := recordEvent(tx, p.OrderID, domain.OrderEventProductAdded, "", lineValue(p)); err != nil {
+			return err
+		}
+
+		_, err := recalculateTotals(tx, p.OrderID)
+		return err
+	})
+}
+
+// RemoveProduct removes a line from an order and recalculates the order total
+// in the same transaction.
+func (r *OrderRepository) RemoveProduct(ctx context.Context, p *domain.OrderProduct) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockCart(tx, p.OrderID); err != nil {
+			return err
+		}
+
+		line := &domain.OrderProduct{}
+		result := tx.First(line, "id = ?", p.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Delete(&domain.OrderProduct{}, p.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := recordEvent(tx, p.OrderID, domain.OrderEventProductRemoved, lineValue(line), ""); err != nil {
+			return err
+		}
+
+		_, err := recalculateTotals(tx, p.OrderID)
+		return err
+	})
+}
+
+func (r *OrderRepository) RecalculateTotals(ctx context.Context, id domain.ID) (domain.Money, error) {
+	var total domain.Money
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockOrder(tx, id); err != nil {
+			return err
+		}
+
+		var err error
+		total, err = recalculateTotals(tx, id)
+		return err
+	})
+
+	return total, err
+}
+
+func (r *OrderRepository) FindKitchenQueue(ctx context.Context, station string) ([]*domain.KitchenTicket, error) {
+	var rows []struct {
+		OrderID        domain.ID
+		TrackingNumber *uint16
+		Status         string
+		PaidAt         time.Time
+		StartedAt      *time.Time
+		ProductID      domain.ID
+		Name           string
+		Quantity       uint16
+		Notes          string
+		Station        string
+	}
+
+	// orders paid before the payment time was recorded are queued by creation
 	result := r.db.WithContext(ctx).
-		Create(&p)
+		Raw(`
+			SELECT o.id AS order_id, o.tracking_number, o.status, COALESCE(o.paid_at, o.created_at) AS paid_at, o.started_at,
+				op.product_id, p.name, op.quantity, op.notes, COALESCE(c.station, '') AS station
+			FROM orders o
+			JOIN order_products op ON op.order_id = o.id
+			JOIN products p ON p.id = op.product_id
+			LEFT JOIN categories c ON c.id = p.category_id
+			WHERE o.deleted_at IS NULL AND o.status IN (?, ?) AND (? = '' OR c.station = ?)
+			ORDER BY COALESCE(o.paid_at, o.created_at) ASC, o.id ASC, op.created_at ASC
+		`, domain.OrderStatusConfirmed.String(), domain.OrderStatusStarted.String(), station, station).
+		Scan(&rows)
 
 	if result.Error != nil {
-		return result.Error
+		return nil, result.Error
 	}
 
-	return nil
+	tickets := []*domain.KitchenTicket{}
+	for _, row := range rows {
+		if len(tickets) == 0 || tickets[len(tickets)-1].OrderID != row.OrderID {
+			tickets = append(tickets, &domain.KitchenTicket{
+				OrderID:        row.OrderID,
+				TrackingNumber: row.TrackingNumber,
+				Status:         row.Status,
+				PaidAt:         row.PaidAt,
+				StartedAt:      row.StartedAt,
+			})
+		}
+
+		ticket := tickets[len(tickets)-1]
+		ticket.Items = append(ticket.Items, domain.KitchenItem{
+			ProductID: row.ProductID,
+			Name:      row.Name,
+			Quantity:  row.Quantity,
+			Notes:     row.Notes,
+			Station:   row.Station,
+		})
+	}
+	return tickets, nil
 }
 
-func (r *OrderRepository) RemoveProduct(ctx context.Context, p *domain.OrderProduct) error {
+func (r *OrderRepository) FindPickupBoard(ctx context.Context, readySince time.Time) (*domain.PickupBoard, error) {
+	var rows []struct {
+		TrackingNumber uint16
+		Status         string
+	}
+
 	result := r.db.WithContext(ctx).
-		Delete(&domain.OrderProduct{}, p.ID)
+		Raw(`
+			SELECT tracking_number, status
+			FROM orders
+			WHERE deleted_at IS NULL AND tracking_number IS NOT NULL AND (
+				status IN (?, ?) OR
+				(status = ? AND ready_at >= ?)
+			)
+			ORDER BY COALESCE(ready_at, paid_at, created_at) ASC, id ASC
+		`, domain.OrderStatusConfirmed.String(), domain.OrderStatusStarted.String(), domain.OrderStatusDone.String(), readySince).
+		Scan(&rows)
 
 	if result.Error != nil {
-		return result.Error
+		return nil, result.Error
 	}
 
-	return nil
+	board := &domain.PickupBoard{Preparing: []uint16{}, Ready: []uint16{}}
+	for _, row := range rows {
+		if row.Status == domain.OrderStatusDone.String() {
+			board.Ready = append(board.Ready, row.TrackingNumber)
+		} else {
+			board.Preparing = append(board.Preparing, row.TrackingNumber)
+		}
+	}
+	return board, nil
 }
 
-func (r *OrderRepository) Patch(ctx context.Context, id domain.ID, data *domain.Order) error {
+func (r *OrderRepository) FindUncollectedReport(ctx context.Context, since time.Time) (*domain.UncollectedReport, error) {
+	report := &domain.UncollectedReport{Waiting: []*domain.UncollectedOrder{}, Since: since}
+
+	var rows []struct {
+		OrderID        domain.ID
+		TrackingNumber *uint16
+		ReadyAt        time.Time
+	}
+
 	result := r.db.WithContext(ctx).
+		Raw(`
+			SELECT id AS order_id, tracking_number, COALESCE(ready_at, created_at) AS ready_at
+			FROM orders
+			WHERE deleted_at IS NULL AND status = ?
+			ORDER BY COALESCE(ready_at, created_at) ASC, id ASC
+		`, domain.OrderStatusDone.String()).
+		Scan(&rows)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, row := range rows {
+		report.Waiting = append(report.Waiting, &domain.UncollectedOrder{
+			OrderID:        row.OrderID,
+			TrackingNumber: row.TrackingNumber,
+			ReadyAt:        row.ReadyAt,
+		})
+	}
+
+	var stats struct {
+		Delivered   int64
+		AverageWait float64
+		MaxWait     float64
+	}
+
+	result = r.db.WithContext(ctx).
+		Raw(`
+			SELECT COUNT(*) AS delivered,
+				COALESCE(AVG(EXTRACT(EPOCH FROM delivered_at - ready_at)), 0) AS average_wait,
+				COALESCE(MAX(EXTRACT(EPOCH FROM delivered_at - ready_at)), 0) AS max_wait
+			FROM orders
+			WHERE deleted_at IS NULL AND status = ? AND ready_at IS NOT NULL AND delivered_at >= ?
+		`, domain.OrderStatusDelivered.String(), since).
+		Scan(&stats)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	report.Delivered = stats.Delivered
+	report.AverageWait = time.Duration(stats.AverageWait * float64(time.Second))
+	report.MaxWait = time.Duration(stats.MaxWait * float64(time.Second))
+	return report, nil
+}
+
+func (r *OrderRepository) FindTotalMismatches(ctx context.Context) ([]*domain.OrderTotalMismatch, error) {
+	var rows []struct {
+		OrderID       domain.ID
+		StoredCents   int64
+		ComputedCents int64
+	}
+
+	result := r.db.WithContext(ctx).
+		Raw(`
+			SELECT o.id AS order_id, o.total_cents AS stored_cents, COALESCE(SUM(op.quantity * op.unit_price_cents), 0) AS computed_cents
+			FROM orders o
+			LEFT JOIN order_products op ON op.order_id = o.id
+			WHERE o.deleted_at IS NULL
+			GROUP BY o.id, o.total_cents
+			HAVING o.total_cents <> COALESCE(SUM(op.quantity * op.unit_price_cents), 0)
+			ORDER BY o.created_at ASC
+		`).
+		Scan(&rows)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	mismatches := make([]*domain.OrderTotalMismatch, 0, len(rows))
+	for _, row := range rows {
+		mismatches = append(mismatches, &domain.OrderTotalMismatch{
+			OrderID:  row.OrderID,
+			Stored:   domain.NewMoney(row.StoredCents),
+			Computed: domain.NewMoney(row.ComputedCents),
+		})
+	}
+	return mismatches, nil
+}
+
+// lockOrder locks the order row until the end of the transaction, making
+// changes to the same order wait for each other.
+func lockOrder(tx *gorm.DB, id domain.ID) error {
+	result := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&domain.Order{}, "id = ?", id)
+
+	return result.Error
+}
+
+// lockCart locks an order as lockOrder does, returning
+// domain.ErrorOrderNotEditable when it is no longer pending, so lines are never
+// changed once the order was paid for meanwhile.
+func lockCart(tx *gorm.DB, id domain.ID) error {
+	var status string
+
+	result := tx.
 		Model(&domain.Order{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("status").
 		Where("id = ?", id).
-		Updates(data)
+		Scan(&status)
 
 	if result.Error != nil {
 		return result.Error
 	}
+	if status != domain.OrderStatusPending.String() {
+		return domain.ErrorOrderNotEditable
+	}
 	return nil
 }
 
-// GetTrackingNumber returns the next tracking number available for an order or the existing one
-// TODO create a reset heuristic
-func (r *OrderRepository) GetTrackingNumber(ctx context.Context, num *uint16) *uint16 {
-	if num != nil && *num > 0 {
-		return num
+// recalculateTotals sets the order total to the sum of its lines, quantity
+// times the unit price captured when each line was added, recording the change
+// in the order history.
+func recalculateTotals(tx *gorm.DB, id domain.ID) (domain.Money, error) {
+	var current, cents int64
+
+	result := tx.
+		Raw(`SELECT total_cents FROM orders WHERE id = ?`, id).
+		Scan(&current)
+
+	if result.Error != nil {
+		return domain.Money{}, result.Error
 	}
 
-	num = new(uint16)
+	result = tx.
+		Raw(`SELECT COALESCE(SUM(quantity * unit_price_cents), 0) FROM order_products WHERE order_id = ?`, id).
+		Scan(&cents)
 
-	result := r.db.WithContext(ctx).
-		Raw(`SELECT nextval('order_tracking_number_sequence');`).
-		Scan(num)
+	if result.Error != nil {
+		return domain.Money{}, result.Error
+	}
+
+	result = tx.
+		Model(&domain.Order{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"total_cents": cents, "version": gorm.Expr("version + 1")})
 
 	if result.Error != nil {
+		return domain.Money{}, result.Error
+	}
+
+	if cents != current {
+		err := recordEvent(tx, id, domain.OrderEventTotalChanged, domain.NewMoney(current).String(), domain.NewMoney(cents).String())
+		if err != nil {
+			return domain.Money{}, err
+		}
+	}
+	return domain.NewMoney(cents), nil
+}
+
+// Patch updates the non-zero fields of data, as long as the order is still at
+// data.Version, and sets data.Version to the new version of the order. The
+// changes are recorded in the order history.
+func (r *OrderRepository) Patch(ctx context.Context, id domain.ID, data *domain.Order) error {
+	version := data.Version
+	data.Version++
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := findOrder(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = updateVersioned(tx, &domain.Order{}, id, version, data); err != nil {
+			return err
+		}
+		return recordChanges(tx, before, data)
+	})
+	if err != nil {
+		data.Version = version
+		return err
+	}
+	return nil
+}
+
+// AttachGuest sets the customer of every order of the guest without one,
+// recording the change in the history of each order.
+func (r *OrderRepository) AttachGuest(ctx context.Context, guestId domain.ID, customerId domain.ID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []domain.ID
+
+		result := tx.
+			Model(&domain.Order{}).
+			Where("guest_id = ? AND customer_id IS NULL", guestId).
+			Pluck("id", &ids)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		for _, id := range ids {
+			result = tx.
+				Model(&domain.Order{}).
+				Where("id = ?", id).
+				Updates(map[string]any{"customer_id": customerId, "version": gorm.Expr("version + 1")})
+
+			if result.Error != nil {
+				return result.Error
+			}
+
+			err := recordEvent(tx, id, domain.OrderEventCustomerAttached, "", customerId.String())
+			if err != nil {
+				return err
+			}
+		}
 		return nil
+	})
+}
+
+// releasedTrackingStatuses are the statuses of the orders no longer holding
+// their tracking number, which can be given to another order.
+var releasedTrackingStatuses = []string{
+	domain.OrderStatusDelivered.String(),
+	domain.OrderStatusCancelled.String(),
+	domain.OrderStatusRefunded.String(),
+	domain.OrderStatusPartiallyRefunded.String(),
+}
+
+// GetTrackingNumber returns the existing tracking number of an order, or the
+// next one not held by an active order, so no two live orders show the same
+// number on the pickup board. Orders hold their number for at most
+// postgres.TrackingNumberHoldTime since they were paid, so orders never
+// collected do not hold theirs forever.
+func (r *OrderRepository) GetTrackingNumber(ctx context.Context, num *uint16) (*uint16, error) {
+	if num != nil && *num > 0 {
+		return num, nil
 	}
 
-	return num
+	// each number of the sequence is tried at most once
+	for i := 0; i < postgres.MaxTrackingNumber; i++ {
+		num = new(uint16)
+
+		result := r.db.WithContext(ctx).
+			Raw(`SELECT nextval('order_tracking_number_sequence');`).
+			Scan(num)
+
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		var held int64
+		result = r.db.WithContext(ctx).
+			Model(&domain.Order{}).
+			Where("tracking_number = ? AND deleted_at IS NULL AND status NOT IN ? AND paid_at > ?", *num, releasedTrackingStatuses, time.Now().Add(-postgres.TrackingNumberHoldTime)).
+			Count(&held)
+
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		if held == 0 {
+			return num, nil
+		}
+	}
+
+	return nil, domain.ErrorOrderTrackingNumbersExhausted
+}
+
+func (r *OrderRepository) ResetTrackingNumbers(ctx context.Context) error {
+	return postgres.ResetOrderTrackingNumberSequence(r.db.WithContext(ctx))
 }
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+func (r *OrderRepository) FindEvents(ctx context.Context, id domain.ID) ([]*domain.OrderEvent, error) {
+	var events []*domain.OrderEvent
+
+	result := r.db.WithContext(ctx).
+		Order("occurred_at ASC").
+		Where("order_id = ?", id).
+		Find(&events)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return events, nil
+}
+
+// findOrder reads an order as it is before being changed.
+func findOrder(tx *gorm.DB, id domain.ID) (*domain.Order, error) {
+	o := &domain.Order{}
+
+	result := tx.First(o, "id = ?", id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrorDataNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return o, nil
+}
+
+// recordChanges records in the order history the status and total changes
+// written over before by data, whose zero fields were left unchanged. Status
+// changes other systems are told about are added to the outbox.
+func recordChanges(tx *gorm.DB, before *domain.Order, data *domain.Order) error {
+	if data.Status != "" && data.Status != before.Status {
+		err := recordEvent(tx, before.ID, domain.OrderEventStatusChanged, before.Status, data.Status)
+		if err != nil {
+			return err
+		}
+
+		if messageType, ok := domain.OutboxOrderStatusType(data.Status); ok {
+			after, err := findOrder(tx.Session(&gorm.Session{NewDB: true}), before.ID)
+			if err != nil {
+				return err
+			}
+			if err := enqueueMessage(tx, after, messageType); err != nil {
+				return err
+			}
+		}
+	}
+
+	if data.Total.Currency != "" && data.Total.Cents != before.Total.Cents {
+		err := recordEvent(tx, before.ID, domain.OrderEventTotalChanged, before.Total.String(), data.Total.String())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordEvent appends a change to the order history, made by the actor found
+// in the context of the transaction.
+func recordEvent(tx *gorm.DB, id domain.ID, eventType string, oldValue string, newValue string) error {
+	actor := domain.ActorFromContext(tx.Statement.Context)
+	event := domain.NewOrderEvent(id, eventType, actor, oldValue, newValue)
+
+	return tx.Session(&gorm.Session{NewDB: true}).Create(event).Error
+}
+
+// lineValue describes an order line in the order history.
+func lineValue(p *domain.OrderProduct) string {
+	data, _ := json.Marshal(struct {
+		ID        domain.ID    `json:"id"`
+		ProductID domain.ID    `json:"productId"`
+		Quantity  uint16       `json:"quantity"`
+		UnitPrice domain.Money `json:"unitPrice"`
+		Notes     string       `json:"notes,omitempty"`
+	}{p.ID, p.ProductID, p.Quantity, p.UnitPrice, p.Notes})
+
+	return string(data)
+}
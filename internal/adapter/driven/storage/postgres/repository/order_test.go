@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/postgres"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	driver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDB returns a database that never reaches postgres, recording the
+// queries it would run instead.
+func dryRunDB(t *testing.T) (*postgres.DB, *[]string) {
+	db, err := gorm.Open(driver.New(driver.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	queries := []string{}
+	err = db.Callback().Query().After("gorm:query").Register("record", func(tx *gorm.DB) {
+		queries = append(queries, tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...))
+	})
+	require.NoError(t, err)
+
+	return &postgres.DB{DB: db}, &queries
+}
+
+func TestOrderRepository_ActiveOrders(t *testing.T) {
+	id := domain.NewID()
+	cart := []string{"status = 'pending'"}
+
+	testCases := []struct {
+		title string
+		query func(r *OrderRepository) error
+		// parts of the query leaving closed orders out
+		filters []string
+	}{
+		{
+			title: "Cart of a customer",
+			query: func(r *OrderRepository) error {
+				_, err := r.FindByCustomer(context.Background(), id)
+				return err
+			},
+			filters: cart,
+		},
+		{
+			title: "Cart of a guest",
+			query: func(r *OrderRepository) error {
+				_, err := r.FindByGuest(context.Background(), id)
+				return err
+			},
+			filters: cart,
+		},
+		{
+			title: "Listed orders",
+			query: func(r *OrderRepository) error {
+				_, err := r.List(context.Background())
+				return err
+			},
+			filters: []string{"'delivered'", "'cancelled'", "'refunded'", "'partially_refunded'"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			db, queries := dryRunDB(t)
+
+			require.NoError(t, tc.query(NewOrderRepository(db)))
+			require.Len(t, *queries, 1)
+			// a paid or refunded order is never taken for the cart
+			for _, filter := range tc.filters {
+				require.Contains(t, (*queries)[0], filter)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/postgres"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+// outboxRelayLock is the key of the advisory lock taken by the outbox relay.
+const outboxRelayLock = 7310
+
+type OutboxRepository struct {
+	db *postgres.DB
+}
+
+func NewOutboxRepository(db *postgres.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Lock takes a transaction level advisory lock, so it must be called within a
+// transaction of postgres.Transactor.
+func (r *OutboxRepository) Lock(ctx context.Context) (bool, error) {
+	var locked bool
+
+	result := r.db.WithContext(ctx).
+		Raw(`SELECT pg_try_advisory_xact_lock(?)`, outboxRelayLock).
+		Scan(&locked)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return locked, nil
+}
+
+// FindPending leaves out the messages written after an unpublished message of
+// the same aggregate that is not due yet, so messages waiting for a retry
+// neither fill the batch nor let the later messages of their aggregate by.
+func (r *OutboxRepository) FindPending(ctx context.Context, limit int) ([]*domain.OutboxMessage, error) {
+	var messages []*domain.OutboxMessage
+
+	result := r.db.WithContext(ctx).
+		Order("id ASC").
+		Where("published_at IS NULL AND next_attempt_at <= now()").
+		Where(`NOT EXISTS (
+			SELECT 1 FROM outbox_messages waiting
+			WHERE waiting.aggregate_id = outbox_messages.aggregate_id
+			AND waiting.id < outbox_messages.id
+			AND waiting.published_at IS NULL
+			AND waiting.next_attempt_at > now()
+		)`).
+		Limit(limit).
+		Find(&messages)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return messages, nil
+}
+
+func (r *OutboxRepository) Update(ctx context.Context, m *domain.OutboxMessage) error {
+	result := r.db.WithContext(ctx).
+		Model(m).
+		Select("attempts", "next_attempt_at", "last_error", "published_at").
+		Updates(m)
+
+	return result.Error
+}
+
+// enqueueMessage adds an event about the order to the outbox, in the same
+// transaction as the change it tells about.
+func enqueueMessage(tx *gorm.DB, o *domain.Order, messageType string) error {
+	payload, err := json.Marshal(struct {
+		OrderID        domain.ID    `json:"orderId"`
+		CustomerID     *domain.ID   `json:"customerId"`
+		GuestID        *domain.ID   `json:"guestId,omitempty"`
+		Status         string       `json:"status"`
+		TrackingNumber *uint16      `json:"trackingNumber"`
+		Total          domain.Money `json:"total"`
+		OccurredAt     time.Time    `json:"occurredAt"`
+	}{o.ID, o.CustomerID, o.GuestID, o.Status, o.TrackingNumber, o.Total, time.Now()})
+	if err != nil {
+		return err
+	}
+
+	m := domain.NewOutboxMessage(o.ID, messageType, string(payload))
+	return tx.Session(&gorm.Session{NewDB: true}).Create(m).Error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepository_FindPending(t *testing.T) {
+	db, queries := dryRunDB(t)
+
+	_, err := NewOutboxRepository(db).FindPending(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, *queries, 1)
+
+	// the messages waiting for a retry do not fill the batch, nor let the
+	// later messages of their aggregate by
+	require.Contains(t, (*queries)[0], "published_at IS NULL AND next_attempt_at <= now()")
+	require.Contains(t, (*queries)[0], "waiting.next_attempt_at > now()")
+	require.Contains(t, (*queries)[0], "LIMIT 10")
+}
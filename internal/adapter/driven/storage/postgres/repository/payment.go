@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/postgres"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+type PaymentEventRepository struct {
+	db *postgres.DB
+}
+
+func NewPaymentEventRepository(db *postgres.DB) *PaymentEventRepository {
+	return &PaymentEventRepository{db: db}
+}
+
+func (r *PaymentEventRepository) Exists(ctx context.Context, id string) (bool, error) {
+	var count int64
+
+	result := r.db.WithContext(ctx).
+		Model(&domain.PaymentEvent{}).
+		Where("id = ?", id).
+		Count(&count)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+func (r *PaymentEventRepository) Create(ctx context.Context, e *domain.PaymentEvent) error {
+	result := r.db.WithContext(ctx).Create(&e)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+type PaymentRepository struct {
+	db *postgres.DB
+}
+
+func NewPaymentRepository(db *postgres.DB) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+func (r *PaymentRepository) Create(ctx context.Context, p *domain.Payment) error {
+	result := r.db.WithContext(ctx).Create(&p)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *PaymentRepository) FindByOrder(ctx context.Context, orderID domain.ID) ([]*domain.Payment, error) {
+	var payments []*domain.Payment
+
+	result := r.db.WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("created_at ASC").
+		Find(&payments)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return payments, nil
+}
+
+func (r *PaymentRepository) FindByProviderRef(ctx context.Context, providerRef string) (*domain.Payment, error) {
+	p := &domain.Payment{}
+
+	result := r.db.WithContext(ctx).
+		First(&p, "provider_ref = ?", providerRef)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return p, nil
+}
+
+func (r *PaymentRepository) Patch(ctx context.Context, id domain.ID, data *domain.Payment) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Payment{}).
+		Where("id = ?", id).
+		Updates(data)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+type RefundRepository struct {
+	db *postgres.DB
+}
+
+func NewRefundRepository(db *postgres.DB) *RefundRepository {
+	return &RefundRepository{db: db}
+}
+
+func (r *RefundRepository) Create(ctx context.Context, refund *domain.Refund) error {
+	result := r.db.WithContext(ctx).Create(&refund)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *RefundRepository) Patch(ctx context.Context, id domain.ID, data *domain.Refund) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Refund{}).
+		Where("id = ?", id).
+		Updates(data)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *RefundRepository) FindByOrder(ctx context.Context, orderID domain.ID) ([]*domain.Refund, error) {
+	var refunds []*domain.Refund
+
+	result := r.db.WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("created_at ASC").
+		Find(&refunds)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return refunds, nil
+}
+
+func (r *RefundRepository) FindPending(ctx context.Context, createdBefore time.Time) ([]*domain.Refund, error) {
+	var refunds []*domain.Refund
+
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND created_at < ?", domain.RefundStatusPending, createdBefore).
+		Order("created_at ASC").
+		Find(&refunds)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return refunds, nil
+}
@@ -23,14 +23,16 @@ func (r *ProductRepository) Create(ctx context.Context, p *domain.Product) error
 	return nil
 }
 
+// Patch updates the non-zero fields of p, as long as the product is still at
+// p.Version, and sets p.Version to the new version of the product.
 func (r *ProductRepository) Patch(ctx context.Context, id domain.ID, p *domain.Product) error {
+	version := p.Version
+	p.Version++
 
-	result := r.db.WithContext(ctx).
-		Model(&domain.Product{ID: id}).
-		Updates(p)
-
-	if result.Error != nil {
-		return result.Error
+	err := updateVersioned(r.db.WithContext(ctx), &domain.Product{}, id, version, p)
+	if err != nil {
+		p.Version = version
+		return err
 	}
 	return nil
 }
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/postgres"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+type StaffRepository struct {
+	db *postgres.DB
+}
+
+func NewStaffRepository(db *postgres.DB) *StaffRepository {
+	return &StaffRepository{db: db}
+}
+
+func (r *StaffRepository) Create(ctx context.Context, u *domain.StaffUser) error {
+	return r.db.WithContext(ctx).Create(u).Error
+}
+
+func (r *StaffRepository) FindByID(ctx context.Context, id domain.ID) (*domain.StaffUser, error) {
+	u := &domain.StaffUser{}
+	result := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL").
+		First(u, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return u, nil
+}
+
+func (r *StaffRepository) FindByUsername(ctx context.Context, username string) (*domain.StaffUser, error) {
+	u := &domain.StaffUser{}
+	result := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL").
+		First(u, "username = ?", username)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return u, nil
+}
+
+func (r *StaffRepository) List(ctx context.Context) ([]*domain.StaffUser, error) {
+	var users []*domain.StaffUser
+	result := r.db.WithContext(ctx).
+		Order("username ASC").
+		Where("deleted_at IS NULL").
+		Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return users, nil
+}
+
+// Patch updates the non-zero fields of data, as long as the staff user is
+// still at data.Version, and sets data.Version to the new version of the user.
+func (r *StaffRepository) Patch(ctx context.Context, id domain.ID, data *domain.StaffUser) error {
+	version := data.Version
+	data.Version++
+
+	err := updateVersioned(r.db.WithContext(ctx), &domain.StaffUser{}, id, version, data)
+	if err != nil {
+		data.Version = version
+		return err
+	}
+	return nil
+}
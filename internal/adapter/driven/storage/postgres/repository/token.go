@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/postgres"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"gorm.io/gorm/clause"
+)
+
+type RevokedTokenRepository struct {
+	db *postgres.DB
+}
+
+func NewRevokedTokenRepository(db *postgres.DB) *RevokedTokenRepository {
+	return &RevokedTokenRepository{db: db}
+}
+
+func (r *RevokedTokenRepository) Revoke(ctx context.Context, t *domain.RevokedToken) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(t)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *RevokedTokenRepository) IsRevoked(ctx context.Context, id domain.ID) (bool, error) {
+	var count int64
+
+	result := r.db.WithContext(ctx).
+		Model(&domain.RevokedToken{}).
+		Where("id = ?", id).
+		Count(&count)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
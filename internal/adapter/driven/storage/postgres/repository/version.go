@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+// updateVersioned updates the row of model with the given id to data, as long
+// as the row is still at version, the version data was read at. data must
+// hold the next version, so every write moves the row to a new version.
+//
+// Rows changed in the meantime are not updated and a *domain.ErrVersionConflict
+// is returned instead.
+func updateVersioned(tx *gorm.DB, model any, id any, version uint64, data any) error {
+	result := tx.
+		Model(model).
+		Where("id = ? AND version = ?", id, version).
+		Updates(data)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var count int64
+	result = tx.Session(&gorm.Session{NewDB: true}).
+		Model(model).
+		Where("id = ?", id).
+		Count(&count)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if count == 0 {
+		return domain.ErrorDataNotFound
+	}
+	return &domain.ErrVersionConflict{Version: version}
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txKey is the context key of the transaction started by a Transactor.
+type txKey struct{}
+
+// Transactor implements port.Transactor with gorm.DB.Transaction.
+type Transactor struct {
+	db *DB
+}
+
+func NewTransactor(db *DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// WithContext returns a session bound to ctx, joining the transaction of a
+// Transactor when ctx is within one. Repositories always go through it, so
+// they take part in transactions without knowing about them.
+func (db *DB) WithContext(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.DB.WithContext(ctx)
+}
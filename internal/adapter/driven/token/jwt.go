@@ -0,0 +1,174 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/config"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// minSecretSize is the shortest HS256 secret accepted, as long as the hash.
+const minSecretSize = 32
+
+// JWTSigner signs the tokens as JWTs, with a shared secret (HS256) or an
+// Ed25519 key pair (EdDSA).
+type JWTSigner struct {
+	method    jwt.SigningMethod
+	signKey   crypto.PrivateKey
+	verifyKey crypto.PublicKey
+	issuer    string
+}
+
+// claims are the claims of the JWTs, the customer or staff user being the
+// subject.
+type claims struct {
+	jwt.RegisteredClaims
+	TokenType domain.TokenType `json:"token_type"`
+	Role      domain.Role      `json:"role,omitempty"`
+	Username  string           `json:"preferred_username,omitempty"`
+}
+
+func NewJWTSigner(config *config.Auth) (*JWTSigner, error) {
+	s := &JWTSigner{issuer: config.Issuer}
+
+	switch config.Algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		if len(config.Secret) < minSecretSize {
+			return nil, fmt.Errorf("auth secret must have at least %d bytes", minSecretSize)
+		}
+		s.method = jwt.SigningMethodHS256
+		s.signKey = []byte(config.Secret)
+		s.verifyKey = []byte(config.Secret)
+
+	case jwt.SigningMethodEdDSA.Alg():
+		key, err := readEd25519Key(config.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		s.method = jwt.SigningMethodEdDSA
+		s.signKey = key
+		s.verifyKey = key.Public()
+
+	default:
+		return nil, fmt.Errorf("unknown auth algorithm %q", config.Algorithm)
+	}
+	return s, nil
+}
+
+// readEd25519Key reads a PEM encoded PKCS #8 Ed25519 private key, as written
+// by `openssl genpkey -algorithm ed25519`.
+func readEd25519Key(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("auth private key is not PEM encoded")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	ed25519Key, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("auth private key is not an Ed25519 key")
+	}
+	return ed25519Key, nil
+}
+
+func (s *JWTSigner) Sign(t *domain.Token) (string, error) {
+	subject := t.Principal.CustomerID.String()
+	switch {
+	case t.Principal.Role.IsStaff():
+		subject = t.Principal.StaffID.String()
+	case t.Principal.Role == domain.RoleGuest:
+		subject = t.Principal.GuestID.String()
+	}
+
+	token := jwt.NewWithClaims(s.method, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        t.ID.String(),
+			Issuer:    s.issuer,
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(t.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(t.ExpiresAt),
+		},
+		TokenType: t.Type,
+		Role:      t.Principal.Role,
+		Username:  t.Principal.Username,
+	})
+	return token.SignedString(s.signKey)
+}
+
+func (s *JWTSigner) Parse(signed string) (*domain.Token, error) {
+	c := &claims{}
+
+	_, err := jwt.ParseWithClaims(signed, c,
+		func(*jwt.Token) (any, error) { return s.verifyKey, nil },
+		jwt.WithValidMethods([]string{s.method.Alg()}),
+		jwt.WithIssuer(s.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, domain.ErrorUnauthorized
+	}
+
+	id, err := domain.ParseID(c.ID)
+	if err != nil {
+		return nil, domain.ErrorUnauthorized
+	}
+
+	principal, err := parsePrincipal(c)
+	if err != nil {
+		return nil, domain.ErrorUnauthorized
+	}
+
+	return &domain.Token{
+		ID:        id,
+		Type:      c.TokenType,
+		Principal: *principal,
+		IssuedAt:  c.IssuedAt.Time,
+		ExpiresAt: c.ExpiresAt.Time,
+	}, nil
+}
+
+// parsePrincipal returns the subject of the claims. Tokens issued before roles
+// were introduced are held by customers.
+func parsePrincipal(c *claims) (*domain.Principal, error) {
+	if c.Role == "" || c.Role == domain.RoleCustomer {
+		customerId, err := domain.ParseID(c.Subject)
+		if err != nil {
+			return nil, err
+		}
+		return domain.NewCustomerPrincipal(customerId), nil
+	}
+
+	if c.Role == domain.RoleGuest {
+		guestId, err := domain.ParseID(c.Subject)
+		if err != nil {
+			return nil, err
+		}
+		return domain.NewGuestPrincipal(guestId), nil
+	}
+
+	if !c.Role.IsStaff() {
+		return nil, domain.ErrorRoleUnknown
+	}
+
+	staffId, err := domain.ParseID(c.Subject)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Principal{StaffID: staffId, Username: c.Username, Role: c.Role}, nil
+}
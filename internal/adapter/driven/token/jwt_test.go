@@ -0,0 +1,100 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/config"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func TestJWTSigner(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "auth.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	configs := map[string]*config.Auth{
+		"HS256": {Algorithm: "HS256", Secret: testSecret, Issuer: "test"},
+		"EdDSA": {Algorithm: "EdDSA", PrivateKeyFile: keyFile, Issuer: "test"},
+	}
+
+	for alg, config := range configs {
+		t.Run(alg, func(t *testing.T) {
+			signer, err := NewJWTSigner(config)
+			require.NoError(t, err)
+
+			principals := []*domain.Principal{
+				domain.NewCustomerPrincipal(domain.NewID()),
+				domain.NewGuestPrincipal(domain.NewID()),
+				{StaffID: domain.NewID(), Username: "cook", Role: domain.RoleKitchen},
+			}
+
+			for _, principal := range principals {
+				token := domain.NewToken(domain.TokenTypeRefresh, principal, time.Hour)
+				signed, err := signer.Sign(token)
+				require.NoError(t, err)
+
+				parsed, err := signer.Parse(signed)
+				require.NoError(t, err)
+				require.Equal(t, token.ID, parsed.ID)
+				require.Equal(t, token.Type, parsed.Type)
+				require.Equal(t, token.Principal, parsed.Principal)
+				require.Equal(t, token.ExpiresAt.Unix(), parsed.ExpiresAt.Unix())
+			}
+		})
+	}
+
+	t.Run("Reject expired tokens", func(t *testing.T) {
+		signer, err := NewJWTSigner(configs["HS256"])
+		require.NoError(t, err)
+
+		signed, err := signer.Sign(domain.NewToken(domain.TokenTypeAccess, domain.NewCustomerPrincipal(domain.NewID()), -time.Minute))
+		require.NoError(t, err)
+
+		_, err = signer.Parse(signed)
+		require.ErrorIs(t, err, domain.ErrorUnauthorized)
+	})
+
+	t.Run("Reject tokens signed with another key", func(t *testing.T) {
+		signer, err := NewJWTSigner(configs["HS256"])
+		require.NoError(t, err)
+		other, err := NewJWTSigner(&config.Auth{Algorithm: "HS256", Secret: testSecret + "!", Issuer: "test"})
+		require.NoError(t, err)
+
+		signed, err := other.Sign(domain.NewToken(domain.TokenTypeAccess, domain.NewCustomerPrincipal(domain.NewID()), time.Hour))
+		require.NoError(t, err)
+
+		_, err = signer.Parse(signed)
+		require.ErrorIs(t, err, domain.ErrorUnauthorized)
+	})
+
+	t.Run("Reject tokens signed with another algorithm", func(t *testing.T) {
+		signer, err := NewJWTSigner(configs["HS256"])
+		require.NoError(t, err)
+		other, err := NewJWTSigner(configs["EdDSA"])
+		require.NoError(t, err)
+
+		signed, err := other.Sign(domain.NewToken(domain.TokenTypeAccess, domain.NewCustomerPrincipal(domain.NewID()), time.Hour))
+		require.NoError(t, err)
+
+		_, err = signer.Parse(signed)
+		require.ErrorIs(t, err, domain.ErrorUnauthorized)
+	})
+
+	t.Run("Reject short secrets", func(t *testing.T) {
+		_, err := NewJWTSigner(&config.Auth{Algorithm: "HS256", Secret: "short"})
+		require.Error(t, err)
+	})
+}
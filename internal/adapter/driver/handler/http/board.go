@@ -0,0 +1,39 @@
+package http
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/response"
+	"github.com/vitovidale/fastfood-app/internal/core/service"
+)
+
+// BoardHandler serves the customer-facing pickup board.
+type BoardHandler struct {
+	service  *service.OrderService
+	readyFor time.Duration
+}
+
+func NewBoardHandler(service *service.OrderService, readyFor time.Duration) *BoardHandler {
+	return &BoardHandler{service: service, readyFor: readyFor}
+}
+
+// Get godoc
+//
+//	@Summary		Pickup board
+//	@Description	Returns the tracking numbers of the orders being prepared, first paid first, and of the orders ready for pickup, first done first, without any customer data
+//	@Description	Ready orders drop off the board once delivered, or after a configured time
+//	@Tags			Board
+//	@Produce		json
+//	@Success		200	{object}	response.PickupBoardResponse	"Pickup board"
+//	@Failure		500	{object}	response.ErrorResponse			"Internal server error"
+//	@Router			/board [get]
+func (h *BoardHandler) Get(ctx *gin.Context) {
+	board, err := h.service.PickupBoard(ctx, h.readyFor)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewPickupBoardResponse(board))
+}
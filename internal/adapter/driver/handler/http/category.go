@@ -67,7 +67,7 @@ func (h *CategoryHandler) GetAll(ctx *gin.Context) {
 // Create godoc
 //
 //	@Summary		Create a new category
-//	@Description	Creates a new category with the given name
+//	@Description	Creates a new category with the given name and the kitchen station preparing its products
 //	@Tags			Categories
 //	@Accept			json
 //	@Produce		json
@@ -84,7 +84,10 @@ func (h *CategoryHandler) Create(ctx *gin.Context) {
 		return
 	}
 
-	category, err := h.service.Create(ctx, domain.NewCategory(req.Name))
+	category := domain.NewCategory(req.Name)
+	category.Station = req.Station
+
+	category, err := h.service.Create(ctx, category)
 	if err != nil {
 		response.HandleError(ctx, err)
 		return
@@ -116,8 +119,9 @@ func (h *CategoryHandler) Update(ctx *gin.Context) {
 
 	id, _ := domain.ParseID(ctx.Params.ByName("id"))
 	category, err := h.service.Update(ctx, &domain.Category{
-		ID:   id,
-		Name: req.Name,
+		ID:      id,
+		Name:    req.Name,
+		Station: req.Station,
 	})
 
 	if err != nil {
@@ -1,8 +1,6 @@
 package http
 
 import (
-	"strconv"
-
 	"github.com/gin-gonic/gin"
 	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/request"
 	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/response"
@@ -12,22 +10,24 @@ import (
 
 type CustomerHandler struct {
 	service port.CustomerService
+	auth    port.AuthService
 }
 
-func NewCustomerHandler(service port.CustomerService) *CustomerHandler {
-	return &CustomerHandler{service: service}
+func NewCustomerHandler(service port.CustomerService, auth port.AuthService) *CustomerHandler {
+	return &CustomerHandler{service: service, auth: auth}
 }
 
 // Create godoc
 //
 //	@Summary		Create a new customer
-//	@Description	Creates a new customer with first name, last name, email and password
+//	@Description	Creates a new customer with CPF, first name, last name, email and password, mailing them a code to verify their email with
 //	@Tags			Customers
 //	@Accept			json
 //	@Produce		json
 //	@Param			CreateCustomerRequest	body		request.CreateCustomerRequest	true	"Create customer request"
 //	@Success		200						{object}	response.CustomerResponse		"Customer created"
 //	@Failure		400						{object}	response.ErrorResponse			"Bad Request error"
+//	@Failure		409						{object}	response.ErrorResponse			"CPF or email already registered"
 //	@Router			/customers [post]
 func (h *CustomerHandler) Create(ctx *gin.Context) {
 	var req request.CreateCustomerRequest
@@ -36,15 +36,21 @@ func (h *CustomerHandler) Create(ctx *gin.Context) {
 		return
 	}
 
+	cpf, err := domain.ParseCPF(req.CPF)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
 	customer := &domain.Customer{
-		ID:        req.ID,
+		CPF:       cpf,
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
 		Email:     req.Email,
 		Password:  req.Password,
 	}
 
-	customer, err := h.service.Create(ctx, customer)
+	customer, err = h.service.Create(ctx, customer)
 
 	if err != nil {
 		response.HandleError(ctx, err)
@@ -61,19 +67,20 @@ func (h *CustomerHandler) Create(ctx *gin.Context) {
 //	@Tags			Customers
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		uint64						true	"Customer ID"
+//	@Param			id	path		string						true	"Customer ID"
 //	@Success		200	{object}	response.CustomerResponse	"Customer found"
 //	@Failure		400	{object}	response.ErrorResponse		"Bad Request error"
 //	@Failure		404	{object}	response.ErrorResponse		"Not found error"
+//	@Security		BearerAuth
 //	@Router			/customers/{id} [get]
 func (h *CustomerHandler) GetByID(c *gin.Context) {
 	var req request.GetCustomerByIDRequest
 	if err := c.ShouldBindUri(&req); err != nil {
-		response.HandleError(c, err)
+		response.HandleBadRequest(c, err)
 		return
 	}
 
-	customer, err := h.service.GetByID(c, req.ID)
+	customer, err := h.service.GetByID(c, domain.ParseIDOrNil(req.ID))
 	if err != nil {
 		response.HandleError(c, err)
 		return
@@ -82,16 +89,53 @@ func (h *CustomerHandler) GetByID(c *gin.Context) {
 	response.HandleSuccess(c, response.NewCustomerResponse(customer))
 }
 
+// GetByCPF godoc
+//
+//	@Summary		Identify a customer by CPF
+//	@Description	Finds the customer with the CPF, with or without punctuation, for the kiosk to greet them. Only the first name and the masked CPF are returned, to staff users, the customer themselves, and guests not attached to a customer yet
+//	@Tags			Customers
+//	@Produce		json
+//	@Param			cpf	path		string								true	"CPF"
+//	@Success		200	{object}	response.CustomerIdentityResponse	"Customer found"
+//	@Failure		400	{object}	response.ErrorResponse				"Invalid CPF"
+//	@Failure		401	{object}	response.ErrorResponse				"Missing or invalid token"
+//	@Failure		403	{object}	response.ErrorResponse				"Another customer, or a guest attached already"
+//	@Failure		404	{object}	response.ErrorResponse				"Not found error"
+//	@Security		BearerAuth
+//	@Router			/customers/cpf/{cpf} [get]
+func (h *CustomerHandler) GetByCPF(ctx *gin.Context) {
+	var req request.GetCustomerByCPFRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	cpf, err := domain.ParseCPF(req.CPF)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	customer, err := h.service.GetByCPF(ctx, cpf)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewCustomerIdentityResponse(customer))
+}
+
 // Auth godoc
 //
 //	@Summary		Authenticate a customer
-//	@Description	Authenticates a customer with email or id, and password
+//	@Description	Authenticates a customer with email or CPF, and password, returning an access token to send as a bearer token and a refresh token to get the next one
 //	@Tags			Customers
 //	@Accept			json
 //	@Produce		json
 //	@Param			AuthCustomerRequest	body		request.AuthCustomerRequest	true	"Authenticate customer request"
-//	@Success		200					{object}	response.CustomerResponse	"Customer authenticated"
+//	@Success		200					{object}	response.AuthResponse		"Customer authenticated"
 //	@Failure		400					{object}	response.ErrorResponse		"Bad Request error"
+//	@Failure		401					{object}	response.ErrorResponse		"Wrong password"
 //	@Router			/customers/auth [post]
 func (h *CustomerHandler) Auth(ctx *gin.Context) {
 	var req request.AuthCustomerRequest
@@ -100,9 +144,18 @@ func (h *CustomerHandler) Auth(ctx *gin.Context) {
 		return
 	}
 
+	var cpf domain.CPF
+	if req.CPF != "" {
+		var err error
+		if cpf, err = domain.ParseCPF(req.CPF); err != nil {
+			response.HandleError(ctx, err)
+			return
+		}
+	}
+
 	customer, err := h.service.Authenticate(ctx,
 		&domain.Customer{
-			ID:       req.ID,
+			CPF:      cpf,
 			Email:    req.Email,
 			Password: req.Password,
 		},
@@ -113,7 +166,67 @@ func (h *CustomerHandler) Auth(ctx *gin.Context) {
 		return
 	}
 
-	response.HandleSuccess(ctx, response.NewCustomerResponse(customer))
+	pair, err := h.auth.Issue(ctx, domain.NewCustomerPrincipal(customer.ID))
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewAuthResponse(pair, customer))
+}
+
+// Refresh godoc
+//
+//	@Summary		Refresh an access token
+//	@Description	Exchanges a refresh token for a new pair of tokens. Each refresh token can only be used once
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			RefreshTokenRequest	body		request.RefreshTokenRequest	true	"Refresh token request"
+//	@Success		200					{object}	response.AuthResponse		"Tokens refreshed"
+//	@Failure		400					{object}	response.ErrorResponse		"Bad Request error"
+//	@Failure		401					{object}	response.ErrorResponse		"Invalid, expired or used refresh token"
+//	@Router			/customers/auth/refresh [post]
+func (h *CustomerHandler) Refresh(ctx *gin.Context) {
+	var req request.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	pair, err := h.auth.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewAuthResponse(pair, nil))
+}
+
+// Revoke godoc
+//
+//	@Summary		Revoke a token
+//	@Description	Stops accepting an access or refresh token before it expires, such as when signing out
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			RevokeTokenRequest	body		request.RevokeTokenRequest	true	"Revoke token request"
+//	@Success		200					{boolean}	bool						"Token revoked"
+//	@Failure		400					{object}	response.ErrorResponse		"Bad Request error"
+//	@Failure		401					{object}	response.ErrorResponse		"Invalid or expired token"
+//	@Router			/customers/auth/revoke [post]
+func (h *CustomerHandler) Revoke(ctx *gin.Context) {
+	var req request.RevokeTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	if err := h.auth.Revoke(ctx, req.Token); err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+	response.HandleSuccess(ctx, true)
 }
 
 // Update godoc
@@ -123,11 +236,15 @@ func (h *CustomerHandler) Auth(ctx *gin.Context) {
 //	@Tags			Customers
 //	@Accept			json
 //	@Produce		json
-//	@Param			id						path		uint64							true	"Customer ID"
+//	@Param			id						path		string							true	"Customer ID"
 //	@Param			UpdateCustomerRequest	body		request.UpdateCustomerRequest	true	"Update customer request"
+//	@Param			If-Match				header		string							false	"ETag of the customer version to change"
 //	@Success		200						{object}	response.CustomerResponse		"Customer updated"
 //	@Failure		400						{object}	response.ErrorResponse			"Bad Request error"
 //	@Failure		404						{object}	response.ErrorResponse			"Not found error"
+//	@Failure		409						{object}	response.ErrorResponse			"Changed by someone else meanwhile"
+//	@Failure		412						{object}	response.ErrorResponse			"If-Match does not match the current version"
+//	@Security		BearerAuth
 //	@Router			/customers/{id} [put]
 func (h *CustomerHandler) Update(ctx *gin.Context) {
 	req := domain.Customer{}
@@ -137,9 +254,9 @@ func (h *CustomerHandler) Update(ctx *gin.Context) {
 		return
 	}
 
-	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	id, err := domain.ParseID(ctx.Param("id"))
 	if err != nil {
-		response.HandleError(ctx, err)
+		response.HandleBadRequest(ctx, err)
 		return
 	}
 
@@ -161,14 +278,18 @@ func (h *CustomerHandler) Update(ctx *gin.Context) {
 //	@Tags			Customers
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		uint64					true	"Customer ID"
-//	@Success		200	{boolean}	bool					"Customer deleted"
-//	@Failure		400	{object}	response.ErrorResponse	"Bad Request error"
+//	@Param			id			path		string					true	"Customer ID"
+//	@Param			If-Match	header		string					false	"ETag of the customer version to change"
+//	@Success		200			{boolean}	bool					"Customer deleted"
+//	@Failure		400			{object}	response.ErrorResponse	"Bad Request error"
+//	@Failure		409			{object}	response.ErrorResponse	"Changed by someone else meanwhile"
+//	@Failure		412			{object}	response.ErrorResponse	"If-Match does not match the current version"
+//	@Security		BearerAuth
 //	@Router			/customers/{id} [delete]
 func (h *CustomerHandler) Delete(ctx *gin.Context) {
-	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	id, err := domain.ParseID(ctx.Param("id"))
 	if err != nil {
-		response.HandleError(ctx, err)
+		response.HandleBadRequest(ctx, err)
 		return
 	}
 
@@ -179,3 +300,107 @@ func (h *CustomerHandler) Delete(ctx *gin.Context) {
 	}
 	response.HandleSuccess(ctx, true)
 }
+
+// RequestPasswordReset godoc
+//
+//	@Summary		Request a password reset
+//	@Description	Mails a code to the customer with the email to choose a new password with. The response is the same whether a customer has the email or not
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			RequestPasswordResetRequest	body		request.RequestPasswordResetRequest	true	"Request password reset request"
+//	@Success		200							{boolean}	bool								"Code mailed, if a customer has the email"
+//	@Failure		400							{object}	response.ErrorResponse				"Bad Request error"
+//	@Router			/customers/password-reset [post]
+func (h *CustomerHandler) RequestPasswordReset(ctx *gin.Context) {
+	var req request.RequestPasswordResetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	if err := h.service.RequestPasswordReset(ctx, req.Email); err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+	response.HandleSuccess(ctx, true)
+}
+
+// ResetPassword godoc
+//
+//	@Summary		Reset a password
+//	@Description	Sets the password of a customer with the code mailed to them. Each code can only be used once
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			ResetPasswordRequest	body		request.ResetPasswordRequest	true	"Reset password request"
+//	@Success		200						{boolean}	bool							"Password reset"
+//	@Failure		400						{object}	response.ErrorResponse			"Invalid, expired or used code"
+//	@Router			/customers/password-reset/confirm [post]
+func (h *CustomerHandler) ResetPassword(ctx *gin.Context) {
+	var req request.ResetPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	if err := h.service.ResetPassword(ctx, req.Token, req.Password); err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+	response.HandleSuccess(ctx, true)
+}
+
+// RequestEmailVerification godoc
+//
+//	@Summary		Request an email verification
+//	@Description	Mails a code to the customer to verify their email with, such as when the one mailed on sign up expired or the email changed
+//	@Tags			Customers
+//	@Produce		json
+//	@Param			id	path		string					true	"Customer ID"
+//	@Success		200	{boolean}	bool					"Code mailed"
+//	@Failure		401	{object}	response.ErrorResponse	"Missing or invalid token"
+//	@Failure		403	{object}	response.ErrorResponse	"Another customer"
+//	@Failure		404	{object}	response.ErrorResponse	"Not found error"
+//	@Failure		409	{object}	response.ErrorResponse	"Email already verified"
+//	@Security		BearerAuth
+//	@Router			/customers/{id}/email-verification [post]
+func (h *CustomerHandler) RequestEmailVerification(ctx *gin.Context) {
+	var req request.GetCustomerByIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	if err := h.service.RequestEmailVerification(ctx, domain.ParseIDOrNil(req.ID)); err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+	response.HandleSuccess(ctx, true)
+}
+
+// VerifyEmail godoc
+//
+//	@Summary		Verify an email
+//	@Description	Verifies the email of a customer with the code mailed to them. Each code can only be used once
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			VerifyEmailRequest	body		request.VerifyEmailRequest	true	"Verify email request"
+//	@Success		200					{object}	response.CustomerResponse	"Email verified"
+//	@Failure		400					{object}	response.ErrorResponse		"Invalid, expired or used code"
+//	@Router			/customers/email-verification/confirm [post]
+func (h *CustomerHandler) VerifyEmail(ctx *gin.Context) {
+	var req request.VerifyEmailRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	customer, err := h.service.VerifyEmail(ctx, req.Token)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+	response.HandleSuccess(ctx, response.NewCustomerResponse(customer))
+}
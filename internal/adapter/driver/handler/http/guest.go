@@ -0,0 +1,92 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/request"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/response"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/port"
+)
+
+type GuestHandler struct {
+	service port.GuestService
+	auth    port.AuthService
+}
+
+func NewGuestHandler(service port.GuestService, auth port.AuthService) *GuestHandler {
+	return &GuestHandler{service: service, auth: auth}
+}
+
+// Create godoc
+//
+//	@Summary		Start a guest session
+//	@Description	Starts a session for someone ordering at the kiosk without signing up, optionally with a name to call the orders out by. The tokens returned are used to place and follow the orders of the guest
+//	@Tags			Guests
+//	@Accept			json
+//	@Produce		json
+//	@Param			CreateGuestRequest	body		request.CreateGuestRequest	true	"Create guest request"
+//	@Success		200					{object}	response.GuestAuthResponse	"Guest session started"
+//	@Failure		400					{object}	response.ErrorResponse		"Bad Request error"
+//	@Router			/guests [post]
+func (h *GuestHandler) Create(ctx *gin.Context) {
+	var req request.CreateGuestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	g, err := h.service.Create(ctx, req.Name)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	pair, err := h.auth.Issue(ctx, domain.NewGuestPrincipal(g.ID))
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewGuestAuthResponse(pair, g))
+}
+
+// Attach godoc
+//
+//	@Summary		Attach a guest to a customer
+//	@Description	Attaches a guest, and the orders the guest placed, to the customer holding the access token given, signed in at the kiosk. Orders placed by the guest afterwards belong to the customer too
+//	@Tags			Guests
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		string						true	"Guest ID"
+//	@Param			AttachGuestRequest	body		request.AttachGuestRequest	true	"Attach guest request"
+//	@Success		200					{object}	response.GuestResponse		"Guest attached"
+//	@Failure		400					{object}	response.ErrorResponse		"Bad Request error"
+//	@Failure		401					{object}	response.ErrorResponse		"Missing or invalid token of the guest or the customer"
+//	@Failure		403					{object}	response.ErrorResponse		"Another guest"
+//	@Failure		404					{object}	response.ErrorResponse		"Guest or customer not found"
+//	@Failure		409					{object}	response.ErrorResponse		"Guest attached to another customer"
+//	@Security		BearerAuth
+//	@Router			/guests/{id}/attach [post]
+func (h *GuestHandler) Attach(ctx *gin.Context) {
+	var req request.AttachGuestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	customer, err := h.auth.Verify(ctx, req.CustomerToken)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	id, _ := domain.ParseID(ctx.Params.ByName("id"))
+
+	g, err := h.service.Attach(ctx, id, customer)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewGuestResponse(g))
+}
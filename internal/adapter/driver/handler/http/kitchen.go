@@ -0,0 +1,47 @@
+package http
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/request"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/response"
+	"github.com/vitovidale/fastfood-app/internal/core/service"
+)
+
+// KitchenHandler serves the kitchen display system (KDS).
+type KitchenHandler struct {
+	service *service.OrderService
+}
+
+func NewKitchenHandler(service *service.OrderService) *KitchenHandler {
+	return &KitchenHandler{service: service}
+}
+
+// Queue godoc
+//
+//	@Summary		Kitchen queue
+//	@Description	Returns the confirmed and started orders, first paid first, with their items, notes, tracking number and how long the customer has been waiting since paying
+//	@Description	Filtering by station, set on the product categories, returns only the orders, and items, that station prepares
+//	@Tags			Kitchen
+//	@Produce		json
+//	@Param			station	query		string								false	"Kitchen station, e.g. grill or drinks"
+//	@Success		200		{object}	[]response.KitchenTicketResponse	"Kitchen queue"
+//	@Failure		400		{object}	response.ErrorResponse				"Bad Request error"
+//	@Failure		500		{object}	response.ErrorResponse				"Internal server error"
+//	@Router			/kitchen/queue [get]
+func (h *KitchenHandler) Queue(ctx *gin.Context) {
+	var req request.KitchenQueueRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	tickets, err := h.service.KitchenQueue(ctx, req.Station)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewKitchenQueueResponse(tickets, time.Now()))
+}
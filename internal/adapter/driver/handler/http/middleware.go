@@ -0,0 +1,89 @@
+package http
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/response"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/port"
+)
+
+// versionMiddleware stores the entity version the client expects to change,
+// taken from the If-Match header, in the request context. Tags that cannot
+// match any version fail the request right away.
+func versionMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tag := ctx.GetHeader("If-Match")
+		if tag == "" || tag == "*" {
+			ctx.Next()
+			return
+		}
+
+		version, err := response.ParseETag(tag)
+		if err != nil {
+			response.HandleError(ctx, domain.ErrorPreconditionFailed)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(domain.WithExpectedVersion(ctx.Request.Context(), version))
+		ctx.Next()
+	}
+}
+
+// authMiddleware lets through only the requests carrying an access token of
+// a customer or a staff user, storing who made them in the request context.
+// The principal is always the actor of the changes made.
+func authMiddleware(auth port.AuthService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		given := bearerToken(ctx)
+		if given == "" {
+			response.HandleError(ctx, domain.ErrorUnauthorized)
+			ctx.Abort()
+			return
+		}
+
+		principal, err := auth.Verify(ctx, given)
+		if err != nil {
+			response.HandleError(ctx, err)
+			ctx.Abort()
+			return
+		}
+
+		reqCtx := domain.WithPrincipal(ctx.Request.Context(), principal)
+		ctx.Request = ctx.Request.WithContext(domain.WithActor(reqCtx, principal.String()))
+		ctx.Next()
+	}
+}
+
+// permissionMiddleware lets through only the requests of principals with the
+// permission, see domain.Role. It must come after authMiddleware.
+func permissionMiddleware(p domain.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal := domain.PrincipalFromContext(ctx.Request.Context())
+		if principal == nil {
+			response.HandleError(ctx, domain.ErrorUnauthorized)
+			ctx.Abort()
+			return
+		}
+
+		if !principal.Role.Can(p) {
+			response.HandleError(ctx, domain.ErrorForbidden)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// bearerToken returns the bearer token of the request, or the access_token
+// query parameter for clients that cannot set headers, such as browser
+// WebSockets and EventSources.
+func bearerToken(ctx *gin.Context) string {
+	given := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	if given == "" {
+		given = ctx.Query("access_token")
+	}
+	return given
+}
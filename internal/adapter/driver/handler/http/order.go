@@ -1,6 +1,8 @@
 package http
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/request"
 	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/response"
@@ -8,6 +10,14 @@ import (
 	"github.com/vitovidale/fastfood-app/internal/core/service"
 )
 
+// uncollectedReportPeriod is how far back the uncollected orders report looks
+// when no start is given.
+const uncollectedReportPeriod = 24 * time.Hour
+
+// customerOrdersPageSize is how many orders of a customer are returned at a
+// time when no limit is given.
+const customerOrdersPageSize = 20
+
 type OrderHandler struct {
 	service *service.OrderService
 }
@@ -27,6 +37,7 @@ func NewOrderHandler(service *service.OrderService) *OrderHandler {
 //	@Success		200	{object}	response.OrderResponse	"Order found"
 //	@Failure		400	{object}	response.ErrorResponse	"Bad Request error"
 //	@Failure		404	{object}	response.ErrorResponse	"Not found error"
+//	@Security		BearerAuth
 //	@Router			/orders/{id} [get]
 func (h *OrderHandler) GetByID(ctx *gin.Context) {
 	var req request.GetOrderRequest
@@ -48,23 +59,24 @@ func (h *OrderHandler) GetByID(ctx *gin.Context) {
 // GetByCustomerID godoc
 //
 //	@Summary		Get an order by its customer ID
-//	@Description	Gets an active order by its customer ID, used for incremental orders, allowing the addition and removal of products
+//	@Description	Gets the pending order of a customer by its ID, used for incremental orders, allowing the addition and removal of products until it is paid for
 //	@Tags			Orders
 //	@Accept			json
 //	@Produce		json
-//	@Param			customerId	path		uint64					true	"Customer ID"
+//	@Param			customerId	path		string					true	"Customer ID"
 //	@Success		200			{object}	response.OrderResponse	"Order found"
 //	@Failure		400			{object}	response.ErrorResponse	"Bad Request error"
 //	@Failure		404			{object}	response.ErrorResponse	"Not found error"
+//	@Security		BearerAuth
 //	@Router			/orders/customer/{customerId} [get]
 func (h *OrderHandler) GetByCustomerID(ctx *gin.Context) {
 	var req request.GetOrderByCustomerRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		response.HandleError(ctx, err)
+		response.HandleBadRequest(ctx, err)
 		return
 	}
 
-	order, err := h.service.GetByCustomer(ctx, req.CustomerID)
+	order, err := h.service.GetByCustomer(ctx, domain.ParseIDOrNil(req.CustomerID))
 	if err != nil {
 		response.HandleError(ctx, err)
 		return
@@ -74,10 +86,75 @@ func (h *OrderHandler) GetByCustomerID(ctx *gin.Context) {
 	response.HandleSuccess(ctx, o)
 }
 
+// GetCustomerOrders godoc
+//
+//	@Summary		List the orders of a customer
+//	@Description	Returns the past and active orders of a customer with their lines, newest first, a page at a time. Pass the `nextCursor` of a page as `cursor` to get the next one
+//	@Tags			Customers
+//	@Produce		json
+//	@Param			id		path		string						true	"Customer ID"
+//	@Param			status	query		string						false	"Order status"
+//	@Param			from	query		string						false	"Orders created at or after, RFC 3339"
+//	@Param			to		query		string						false	"Orders created before, RFC 3339"
+//	@Param			cursor	query		string						false	"Where the page starts"
+//	@Param			limit	query		int							false	"Orders per page, 20 by default"
+//	@Success		200		{object}	response.OrderPageResponse	"Orders found"
+//	@Failure		400		{object}	response.ErrorResponse		"Bad Request error"
+//	@Failure		404		{object}	response.ErrorResponse		"Not found error"
+//	@Failure		500		{object}	response.ErrorResponse		"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/customers/{id}/orders [get]
+func (h *OrderHandler) GetCustomerOrders(ctx *gin.Context) {
+	var uri request.GetCustomerByIDRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	var req request.GetCustomerOrdersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	filter := domain.OrderFilter{Status: req.Status, Limit: req.Limit}
+	if filter.Status != "" {
+		if _, err := domain.ParseOrderStatus(filter.Status); err != nil {
+			response.HandleBadRequest(ctx, err)
+			return
+		}
+	}
+	if !req.From.IsZero() {
+		filter.From = &req.From
+	}
+	if !req.To.IsZero() {
+		filter.To = &req.To
+	}
+	if req.Cursor != "" {
+		cursor, err := domain.ParseOrderCursor(req.Cursor)
+		if err != nil {
+			response.HandleError(ctx, err)
+			return
+		}
+		filter.After = cursor
+	}
+	if filter.Limit == 0 {
+		filter.Limit = customerOrdersPageSize
+	}
+
+	page, err := h.service.GetCustomerOrders(ctx, domain.ParseIDOrNil(uri.ID), filter)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewOrderPageResponse(page))
+}
+
 // AddProduct godoc
 //
 //	@Summary		Add a product to an order
-//	@Description	Adds a product to an existing **active** order, based on its CustomerID. If the order doesn't exist, it will be created.
+//	@Description	Adds a product to the **pending** order of the customer, based on its CustomerID, or GuestID for guests. If there is no pending order, it will be created.
 //	@Tags			Orders
 //	@Accept			json
 //	@Produce		json
@@ -85,7 +162,9 @@ func (h *OrderHandler) GetByCustomerID(ctx *gin.Context) {
 //	@Success		200					{object}	response.OrderResponse		"Product added"
 //	@Failure		400					{object}	response.ErrorResponse		"Bad Request error"
 //	@Failure		404					{object}	response.ErrorResponse		"Not found error"
+//	@Failure		409					{object}	response.ErrorResponse		"Order paid for meanwhile"
 //	@Failure		500					{object}	response.ErrorResponse		"Internal server error"
+//	@Security		BearerAuth
 //	@Router			/orders/products [post]
 func (h *OrderHandler) AddProduct(ctx *gin.Context) {
 	var req request.AddProductRequest
@@ -94,7 +173,7 @@ func (h *OrderHandler) AddProduct(ctx *gin.Context) {
 		return
 	}
 
-	id, err := h.service.Create(ctx, req.CustomerID, []domain.OrderProduct{{
+	id, err := h.create(ctx, req.CustomerID, req.GuestID, []domain.OrderProduct{{
 		ProductID: domain.ParseIDOrNil(req.ProductID),
 		Quantity:  req.Quantity,
 		Notes:     req.Notes,
@@ -112,16 +191,20 @@ func (h *OrderHandler) AddProduct(ctx *gin.Context) {
 // RemoveProduct godoc
 //
 //	@Summary		Remove a product from an order
-//	@Description	Removes a product from an existing **active** order, based on its OrderID and OrderProductID.
+//	@Description	Removes a product from an existing **pending** order, based on its OrderID and OrderProductID.
 //	@Tags			Orders
 //	@Accept			json
 //	@Produce		json
 //	@Param			orderId			path		string					true	"Order ID"
 //	@Param			orderProductId	path		string					true	"Product ID"
+//	@Param			If-Match		header		string					false	"ETag of the order version to change"
 //	@Success		200				{object}	response.OrderResponse	"Order found"
 //	@Failure		400				{object}	response.ErrorResponse	"Bad Request error"
 //	@Failure		404				{object}	response.ErrorResponse	"Not found error"
+//	@Failure		409				{object}	response.ErrorResponse	"Changed by someone else meanwhile, or no longer pending"
+//	@Failure		412				{object}	response.ErrorResponse	"If-Match does not match the current version"
 //	@Failure		500				{object}	response.ErrorResponse	"Internal server error"
+//	@Security		BearerAuth
 //	@Router			/orders/{orderId}/products/{orderProductId} [delete]
 func (h *OrderHandler) RemoveProduct(ctx *gin.Context) {
 	var req request.RemoveProductRequest
@@ -146,19 +229,39 @@ func (h *OrderHandler) RemoveProduct(ctx *gin.Context) {
 // Pay godoc
 //
 //	@Summary		Pays an order
-//	@Description	Marked the order as paid, setting its status to `confirmed`, based on the order ID
+//	@Description	Creates a charge for the order at the payment provider, setting its status to `processing`, based on the order ID. The order is `confirmed` once the charge is approved, or moves back to `pending` if it cannot be created, is declined or expires.
+//	@Description	Paying with `pix` returns the BR Code ("copia e cola") and its QR code as a base64 PNG. The method defaults to `card`
 //	@Tags			Orders
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string					true	"Order ID"
-//	@Success		200	{object}	response.OrderResponse	"Order found"
-//	@Failure		400	{object}	response.ErrorResponse	"Bad Request error"
-//	@Failure		404	{object}	response.ErrorResponse	"Not found error"
-//	@Failure		500	{object}	response.ErrorResponse	"Internal server error"
+//	@Param			id				path		string						true	"Order ID"
+//	@Param			PayOrderRequest	body		request.PayOrderRequest		false	"Payment method"
+//	@Param			If-Match		header		string						false	"ETag of the order version to change"
+//	@Success		200				{object}	response.PayOrderResponse	"Payment started"
+//	@Failure		400				{object}	response.ErrorResponse		"Bad Request error"
+//	@Failure		404				{object}	response.ErrorResponse		"Not found error"
+//	@Failure		409				{object}	response.ErrorResponse		"Invalid status transition"
+//	@Failure		412				{object}	response.ErrorResponse		"If-Match does not match the current version"
+//	@Failure		422				{object}	response.ErrorResponse		"Order without products"
+//	@Failure		500				{object}	response.ErrorResponse		"Internal server error"
+//	@Security		BearerAuth
 //	@Router			/orders/{id}/pay [patch]
 func (h *OrderHandler) Pay(ctx *gin.Context) {
+	var req request.PayOrderRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.HandleBadRequest(ctx, err)
+			return
+		}
+	}
+
+	method := domain.PaymentMethodCard
+	if req.Method != "" {
+		method = domain.PaymentMethod(req.Method)
+	}
+
 	id, _ := domain.ParseID(ctx.Param("id"))
-	err := h.service.Pay(ctx, id)
+	payment, err := h.service.Pay(ctx, id, method)
 
 	if err != nil {
 		response.HandleError(ctx, err)
@@ -166,7 +269,13 @@ func (h *OrderHandler) Pay(ctx *gin.Context) {
 	}
 
 	o, _ := h.service.GetNestedByID(ctx, id)
-	response.HandleSuccess(ctx, o)
+	rsp, err := response.NewPayOrderResponse(o, payment)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, rsp)
 }
 
 // Prepare godoc
@@ -176,11 +285,15 @@ func (h *OrderHandler) Pay(ctx *gin.Context) {
 //	@Tags			Orders
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string					true	"Order ID"
-//	@Success		200	{object}	response.OrderResponse	"Order found"
-//	@Failure		400	{object}	response.ErrorResponse	"Bad Request error"
-//	@Failure		404	{object}	response.ErrorResponse	"Not found error"
-//	@Failure		500	{object}	response.ErrorResponse	"Internal server error"
+//	@Param			id			path		string					true	"Order ID"
+//	@Param			If-Match	header		string					false	"ETag of the order version to change"
+//	@Success		200			{object}	response.OrderResponse	"Order found"
+//	@Failure		400			{object}	response.ErrorResponse	"Bad Request error"
+//	@Failure		404			{object}	response.ErrorResponse	"Not found error"
+//	@Failure		409			{object}	response.ErrorResponse	"Invalid status transition"
+//	@Failure		412			{object}	response.ErrorResponse	"If-Match does not match the current version"
+//	@Failure		500			{object}	response.ErrorResponse	"Internal server error"
+//	@Security		BearerAuth
 //	@Router			/orders/{id}/prepare [patch]
 func (h *OrderHandler) Prepare(ctx *gin.Context) {
 	id, _ := domain.ParseID(ctx.Param("id"))
@@ -202,11 +315,15 @@ func (h *OrderHandler) Prepare(ctx *gin.Context) {
 //	@Tags			Orders
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string					true	"Order ID"
-//	@Success		200	{object}	response.OrderResponse	"Order found"
-//	@Failure		400	{object}	response.ErrorResponse	"Bad Request error"
-//	@Failure		404	{object}	response.ErrorResponse	"Not found error"
-//	@Failure		500	{object}	response.ErrorResponse	"Internal server error"
+//	@Param			id			path		string					true	"Order ID"
+//	@Param			If-Match	header		string					false	"ETag of the order version to change"
+//	@Success		200			{object}	response.OrderResponse	"Order found"
+//	@Failure		400			{object}	response.ErrorResponse	"Bad Request error"
+//	@Failure		404			{object}	response.ErrorResponse	"Not found error"
+//	@Failure		409			{object}	response.ErrorResponse	"Invalid status transition"
+//	@Failure		412			{object}	response.ErrorResponse	"If-Match does not match the current version"
+//	@Failure		500			{object}	response.ErrorResponse	"Internal server error"
+//	@Security		BearerAuth
 //	@Router			/orders/{id}/complete [patch]
 func (h *OrderHandler) Complete(ctx *gin.Context) {
 	id, _ := domain.ParseID(ctx.Param("id"))
@@ -221,10 +338,283 @@ func (h *OrderHandler) Complete(ctx *gin.Context) {
 	response.HandleSuccess(ctx, o)
 }
 
+// Deliver godoc
+//
+//	@Summary		Deliver an order
+//	@Description	Changes the state of a `done` order to `delivered`, once the customer takes it out from the counter, taking it off the pickup board
+//	@Tags			Orders
+//	@Produce		json
+//	@Param			id			path		string					true	"Order ID"
+//	@Param			If-Match	header		string					false	"ETag of the order version to change"
+//	@Success		200			{object}	response.OrderResponse	"Order delivered"
+//	@Failure		400			{object}	response.ErrorResponse	"Bad Request error"
+//	@Failure		404			{object}	response.ErrorResponse	"Not found error"
+//	@Failure		409			{object}	response.ErrorResponse	"Invalid status transition"
+//	@Failure		412			{object}	response.ErrorResponse	"If-Match does not match the current version"
+//	@Failure		500			{object}	response.ErrorResponse	"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/orders/{id}/deliver [patch]
+func (h *OrderHandler) Deliver(ctx *gin.Context) {
+	id, _ := domain.ParseID(ctx.Param("id"))
+	err := h.service.Deliver(ctx, id)
+
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	o, _ := h.service.GetNestedByID(ctx, id)
+	response.HandleSuccess(ctx, o)
+}
+
+// Cancel godoc
+//
+//	@Summary		Cancel an order
+//	@Description	Cancels an order that the kitchen has not started yet, saving the reason and who cancelled it. Orders whose charge is still being settled cannot be cancelled, and paid orders are flagged for refund
+//	@Tags			Orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		string						true	"Order ID"
+//	@Param			CancelOrderRequest	body		request.CancelOrderRequest	true	"Cancel order request"
+//	@Param			If-Match			header		string						false	"ETag of the order version to change"
+//	@Success		200					{object}	response.OrderResponse		"Order cancelled"
+//	@Failure		400					{object}	response.ErrorResponse		"Bad Request error"
+//	@Failure		404					{object}	response.ErrorResponse		"Not found error"
+//	@Failure		409					{object}	response.ErrorResponse		"Invalid status transition"
+//	@Failure		412					{object}	response.ErrorResponse		"If-Match does not match the current version"
+//	@Failure		500					{object}	response.ErrorResponse		"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/orders/{id}/cancel [patch]
+func (h *OrderHandler) Cancel(ctx *gin.Context) {
+	var req request.CancelOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	id, _ := domain.ParseID(ctx.Param("id"))
+	err := h.service.Cancel(ctx, id, req.Reason)
+
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	o, _ := h.service.GetNestedByID(ctx, id)
+	response.HandleSuccess(ctx, o)
+}
+
+// GetPayments godoc
+//
+//	@Summary		List the payments of an order
+//	@Description	Returns every payment made for an order, oldest first, with its status, attempts and the reason it failed
+//	@Tags			Orders
+//	@Produce		json
+//	@Param			id	path		string						true	"Order ID"
+//	@Success		200	{object}	[]response.PaymentResponse	"Payments found"
+//	@Failure		400	{object}	response.ErrorResponse		"Bad Request error"
+//	@Failure		404	{object}	response.ErrorResponse		"Not found error"
+//	@Failure		500	{object}	response.ErrorResponse		"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/orders/{id}/payments [get]
+func (h *OrderHandler) GetPayments(ctx *gin.Context) {
+	var req request.GetOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	payments, err := h.service.GetPayments(ctx, domain.ParseIDOrNil(req.ID))
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewPaymentListResponse(payments))
+}
+
+// Refund godoc
+//
+//	@Summary		Refund an order
+//	@Description	Gives back part or all of what was paid for an order that will not be fulfilled. An amount of zero refunds everything not refunded yet. Refunds the payment provider cannot be asked for right away stay `pending` in the ledger, and are given back later
+//	@Tags			Orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		string						true	"Order ID"
+//	@Param			RefundOrderRequest	body		request.RefundOrderRequest	false	"Refund order request"
+//	@Param			If-Match			header		string						false	"ETag of the order version to change"
+//	@Success		200					{object}	response.RefundResponse		"Order refunded"
+//	@Failure		400					{object}	response.ErrorResponse		"Bad Request error"
+//	@Failure		404					{object}	response.ErrorResponse		"Not found error"
+//	@Failure		409					{object}	response.ErrorResponse		"Order or payment cannot be refunded"
+//	@Failure		422					{object}	response.ErrorResponse		"Amount exceeds what is left to refund"
+//	@Failure		412					{object}	response.ErrorResponse		"If-Match does not match the current version"
+//	@Failure		500					{object}	response.ErrorResponse		"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/orders/{id}/refunds [post]
+func (h *OrderHandler) Refund(ctx *gin.Context) {
+	var req request.RefundOrderRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.HandleBadRequest(ctx, err)
+			return
+		}
+	}
+
+	id, _ := domain.ParseID(ctx.Param("id"))
+	refund, err := h.service.Refund(ctx, id, req.Amount)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewRefundResponse(refund))
+}
+
+// GetRefunds godoc
+//
+//	@Summary		List the refunds of an order
+//	@Description	Returns the refund ledger of an order, oldest first
+//	@Tags			Orders
+//	@Produce		json
+//	@Param			id	path		string						true	"Order ID"
+//	@Success		200	{object}	[]response.RefundResponse	"Refunds found"
+//	@Failure		400	{object}	response.ErrorResponse		"Bad Request error"
+//	@Failure		404	{object}	response.ErrorResponse		"Not found error"
+//	@Failure		500	{object}	response.ErrorResponse		"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/orders/{id}/refunds [get]
+func (h *OrderHandler) GetRefunds(ctx *gin.Context) {
+	var req request.GetOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	refunds, err := h.service.GetRefunds(ctx, domain.ParseIDOrNil(req.ID))
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewRefundListResponse(refunds))
+}
+
+// GetHistory godoc
+//
+//	@Summary		Get the history of an order
+//	@Description	Returns every change made to an order, oldest first: its creation, status changes, products added or removed and total changes, with who made each change, the authenticated user or `system` for the payment provider and the background jobs, and the values before and after
+//	@Tags			Orders
+//	@Produce		json
+//	@Param			id	path		string							true	"Order ID"
+//	@Success		200	{object}	[]response.OrderEventResponse	"Order history"
+//	@Failure		400	{object}	response.ErrorResponse			"Bad Request error"
+//	@Failure		404	{object}	response.ErrorResponse			"Not found error"
+//	@Failure		500	{object}	response.ErrorResponse			"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/orders/{id}/history [get]
+func (h *OrderHandler) GetHistory(ctx *gin.Context) {
+	var req request.GetOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	events, err := h.service.GetHistory(ctx, domain.ParseIDOrNil(req.ID))
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewOrderEventListResponse(events))
+}
+
+// GetTotalMismatches godoc
+//
+//	@Summary		Check the order totals
+//	@Description	Returns the orders whose stored total disagrees with the sum of their lines, quantity times the unit price captured when each line was added
+//	@Tags			Orders
+//	@Produce		json
+//	@Success		200	{object}	[]response.OrderTotalMismatchResponse	"Mismatching orders"
+//	@Failure		500	{object}	response.ErrorResponse					"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/orders/totals/mismatches [get]
+func (h *OrderHandler) GetTotalMismatches(ctx *gin.Context) {
+	mismatches, err := h.service.GetTotalMismatches(ctx)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewOrderTotalMismatchListResponse(mismatches))
+}
+
+// UncollectedReport godoc
+//
+//	@Summary		Report uncollected orders
+//	@Description	Returns the `done` orders waiting at the counter, longest waiting first, and how long the orders delivered since the given time, by default the last 24 hours, waited to be collected
+//	@Tags			Orders
+//	@Produce		json
+//	@Param			since	query		string								false	"Start of the period, RFC 3339"
+//	@Success		200		{object}	response.UncollectedReportResponse	"Uncollected orders report"
+//	@Failure		400		{object}	response.ErrorResponse				"Bad Request error"
+//	@Failure		500		{object}	response.ErrorResponse				"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/orders/reports/uncollected [get]
+func (h *OrderHandler) UncollectedReport(ctx *gin.Context) {
+	var req request.UncollectedReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	now := time.Now()
+	if req.Since.IsZero() {
+		req.Since = now.Add(-uncollectedReportPeriod)
+	}
+
+	report, err := h.service.UncollectedReport(ctx, req.Since)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewUncollectedReportResponse(report, now))
+}
+
+// RecalculateTotals godoc
+//
+//	@Summary		Recalculate an order total
+//	@Description	Sets the order total to the sum of its lines, fixing orders reported by the totals check
+//	@Tags			Orders
+//	@Produce		json
+//	@Param			id	path		string					true	"Order ID"
+//	@Success		200	{object}	response.OrderResponse	"Order total recalculated"
+//	@Failure		400	{object}	response.ErrorResponse	"Bad Request error"
+//	@Failure		404	{object}	response.ErrorResponse	"Not found error"
+//	@Failure		500	{object}	response.ErrorResponse	"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/orders/{id}/totals [patch]
+func (h *OrderHandler) RecalculateTotals(ctx *gin.Context) {
+	var req request.GetOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	o, err := h.service.RecalculateTotals(ctx, domain.ParseIDOrNil(req.ID))
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewOrderResponse(o))
+}
+
 // List godoc
 //
 //	@Summary		List orders
-//	@Description	Returns all orders, sorted by status ASC, and ignores inactive or completed orders
+//	@Description	Returns all orders, sorted by status ASC, and ignores inactive, cancelled or delivered orders
 //	@Tags			Orders
 //	@Accept			json
 //	@Produce		json
@@ -232,6 +622,7 @@ func (h *OrderHandler) Complete(ctx *gin.Context) {
 //	@Failure		400	{object}	response.ErrorResponse		"Bad Request error"
 //	@Failure		404	{object}	response.ErrorResponse		"Not found error"
 //	@Failure		500	{object}	response.ErrorResponse		"Internal server error"
+//	@Security		BearerAuth
 //	@Router			/orders [get]
 func (h *OrderHandler) List(ctx *gin.Context) {
 	orders, err := h.service.List(ctx)
@@ -247,15 +638,16 @@ func (h *OrderHandler) List(ctx *gin.Context) {
 // Create godoc
 //
 //	@Summary		Create an order
-//	@Description	Creates an order for a customer with a list of products in a single request
+//	@Description	Creates an order for a customer, or a guest, with a list of products in a single request
 //	@Tags			Orders
 //	@Accept			json
 //	@Produce		json
-//	@Param			CreateOrderRequest	body		request.CreateOrderRequest	true	"Payload with customer ID and products"
+//	@Param			CreateOrderRequest	body		request.CreateOrderRequest	true	"Payload with customer or guest ID and products"
 //	@Success		200					{object}	response.OrderResponse		"Order created"
 //	@Failure		400					{object}	response.ErrorResponse		"Bad Request error"
 //	@Failure		404					{object}	response.ErrorResponse		"Not found error"
 //	@Failure		500					{object}	response.ErrorResponse		"Internal server error"
+//	@Security		BearerAuth
 //	@Router			/orders [post]
 func (h *OrderHandler) Create(ctx *gin.Context) {
 	var req request.CreateOrderRequest
@@ -275,7 +667,7 @@ func (h *OrderHandler) Create(ctx *gin.Context) {
 		})
 	}
 
-	id, err := h.service.Create(ctx, req.CustomerID, products)
+	id, err := h.create(ctx, req.CustomerID, req.GuestID, products)
 
 	if err != nil {
 		response.HandleError(ctx, err)
@@ -297,6 +689,7 @@ func (h *OrderHandler) Create(ctx *gin.Context) {
 //	@Failure		400	{object}	response.ErrorResponse		"Bad Request error"
 //	@Failure		404	{object}	response.ErrorResponse		"Not found error"
 //	@Failure		500	{object}	response.ErrorResponse		"Internal server error"
+//	@Security		BearerAuth
 //	@Router			/orders/{id}/status [get]
 func (h *OrderHandler) GetStatus(ctx *gin.Context) {
 	var req request.GetOrderRequest
@@ -315,3 +708,71 @@ func (h *OrderHandler) GetStatus(ctx *gin.Context) {
 
 	response.HandleSuccess(ctx, order.Status)
 }
+
+// Stream godoc
+//
+//	@Summary		Stream order status changes
+//	@Description	Streams the status changes of every order as Server-Sent Events named status, with heartbeat comments while idle
+//	@Description	Clients reconnecting with the Last-Event-ID header get the recent events they missed, or an event named reset when those are no longer retained, and should reload the orders
+//	@Tags			Orders
+//	@Produce		text/event-stream
+//	@Param			Last-Event-ID	header		string								false	"ID of the last event received"
+//	@Success		200				{object}	response.OrderStatusEventResponse	"Order status events"
+//	@Security		BearerAuth
+//	@Router			/orders/stream [get]
+func (h *OrderHandler) Stream(ctx *gin.Context) {
+	events := h.service.SubscribeStatus(ctx.Request.Context(), lastEventID(ctx))
+
+	startStream(ctx)
+	streamStatus(ctx, events, func(*domain.OrderStatusEvent) bool { return true })
+}
+
+// StreamByID godoc
+//
+//	@Summary		Stream an order status changes
+//	@Description	Streams the status changes of an order as Server-Sent Events named status, starting with its current status, with heartbeat comments while idle
+//	@Description	Clients reconnecting with the Last-Event-ID header get the recent events they missed instead of the current status, or an event named reset when those are no longer retained, and should reload the order
+//	@Tags			Orders
+//	@Produce		text/event-stream
+//	@Param			id				path		string								true	"Order ID"
+//	@Param			Last-Event-ID	header		string								false	"ID of the last event received"
+//	@Success		200				{object}	response.OrderStatusEventResponse	"Order status events"
+//	@Failure		400				{object}	response.ErrorResponse				"Bad Request error"
+//	@Failure		404				{object}	response.ErrorResponse				"Not found error"
+//	@Failure		500				{object}	response.ErrorResponse				"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/orders/{id}/stream [get]
+func (h *OrderHandler) StreamByID(ctx *gin.Context) {
+	var req request.GetOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	id := domain.ParseIDOrNil(req.ID)
+	lastID := lastEventID(ctx)
+
+	// subscribes before reading the order, so no change is missed in between
+	events := h.service.SubscribeStatus(ctx.Request.Context(), lastID)
+
+	order, err := h.service.GetByID(ctx, id)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	startStream(ctx)
+	if lastID == 0 {
+		renderStatusEvent(ctx, domain.NewOrderStatusEvent(order))
+	}
+	streamStatus(ctx, events, func(event *domain.OrderStatusEvent) bool { return event.OrderID == id })
+}
+
+// create creates the order of the guest when guestId is given, otherwise the
+// order of the customer.
+func (h *OrderHandler) create(ctx *gin.Context, customerId, guestId string, products []domain.OrderProduct) (*domain.ID, error) {
+	if guestId != "" {
+		return h.service.CreateForGuest(ctx, domain.ParseIDOrNil(guestId), products)
+	}
+	return h.service.Create(ctx, domain.ParseIDOrNil(customerId), products)
+}
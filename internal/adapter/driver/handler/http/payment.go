@@ -0,0 +1,98 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/request"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/response"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/service"
+)
+
+type PaymentHandler struct {
+	service       *service.OrderService
+	webhookSecret string
+}
+
+func NewPaymentHandler(service *service.OrderService, webhookSecret string) *PaymentHandler {
+	return &PaymentHandler{service: service, webhookSecret: webhookSecret}
+}
+
+// Webhook godoc
+//
+//	@Summary		Receive a payment notification
+//	@Description	Receives a notification from the payment provider about a charge, confirming the order or moving it back to `pending`. The body must be signed with HMAC-SHA256 using the webhook secret, hex encoded in the `X-Signature` header. Redeliveries of the same event are ignored
+//	@Tags			Payments
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Signature				header		string							true	"HMAC-SHA256 signature of the body"
+//	@Param			PaymentWebhookRequest	body		request.PaymentWebhookRequest	true	"Payment notification"
+//	@Success		200						{boolean}	bool							"Notification handled"
+//	@Failure		400						{object}	response.ErrorResponse			"Bad Request error"
+//	@Failure		401						{object}	response.ErrorResponse			"Invalid signature"
+//	@Failure		404						{object}	response.ErrorResponse			"Not found error"
+//	@Failure		500						{object}	response.ErrorResponse			"Internal server error"
+//	@Failure		503						{object}	response.ErrorResponse			"No tracking number available for the paid order, to be sent again"
+//	@Router			/payments/webhook [post]
+func (h *PaymentHandler) Webhook(ctx *gin.Context) {
+	body, err := ctx.GetRawData()
+	if err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	if !h.validSignature(body, ctx.GetHeader("X-Signature")) {
+		response.HandleError(ctx, domain.ErrorPaymentInvalidSignature)
+		return
+	}
+
+	var req request.PaymentWebhookRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	// signed by the payment provider, the notification is applied by the app
+	// itself
+	ctx.Request = ctx.Request.WithContext(domain.WithSystemPrincipal(ctx.Request.Context()))
+
+	err = h.service.HandlePaymentEvent(ctx, &domain.PaymentEvent{
+		ID:        req.EventID,
+		Reference: req.Reference,
+		OrderID:   domain.ParseIDOrNil(req.OrderID),
+		Status:    req.Status,
+	})
+
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, true)
+}
+
+// validSignature checks the hex encoded HMAC-SHA256 signature of the body.
+func (h *PaymentHandler) validSignature(body []byte, signature string) bool {
+	if h.webhookSecret == "" {
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.webhookSecret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -38,6 +38,11 @@ func (handler *ProductHandler) Create(ctx *gin.Context) {
 		return
 	}
 
+	if !request.Price.IsPositive() {
+		response.HandleBadRequest(ctx, domain.ErrorMoneyInvalidAmount)
+		return
+	}
+
 	categoryId, _ := domain.ParseID(request.CategoryID)
 	product := &domain.Product{
 		Name:        request.Name,
@@ -142,9 +147,12 @@ func (h *ProductHandler) GetByCategory(ctx *gin.Context) {
 //	@Produce		json
 //	@Param			id						path		string							true	"Product ID"
 //	@Param			CreateProductRequest	body		request.CreateProductRequest	true	"Update product request"
+//	@Param			If-Match				header		string							false	"ETag of the product version to change"
 //	@Success		200						{object}	response.ProductResponse		"Product updated"
 //	@Failure		400						{object}	response.ErrorResponse			"Bad Request error"
 //	@Failure		404						{object}	response.ErrorResponse			"Not found error"
+//	@Failure		409						{object}	response.ErrorResponse			"Changed by someone else meanwhile"
+//	@Failure		412						{object}	response.ErrorResponse			"If-Match does not match the current version"
 //	@Failure		500						{object}	response.ErrorResponse			"Internal server error"
 //	@Router			/products/{id} [put]
 func (h *ProductHandler) Update(ctx *gin.Context) {
@@ -156,6 +164,11 @@ func (h *ProductHandler) Update(ctx *gin.Context) {
 	}
 	req.ID = domain.ParseIDOrNil(ctx.Param("id"))
 
+	if req.Price.IsNegative() {
+		response.HandleBadRequest(ctx, domain.ErrorMoneyInvalidAmount)
+		return
+	}
+
 	p, err := h.service.Update(ctx, &req)
 	if err != nil {
 		response.HandleError(ctx, err)
@@ -172,11 +185,14 @@ func (h *ProductHandler) Update(ctx *gin.Context) {
 //	@Tags			Products
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string					true	"Product ID"
-//	@Success		200	{object}	bool					"Product deleted"
-//	@Failure		400	{object}	response.ErrorResponse	"Bad Request error"
-//	@Failure		404	{object}	response.ErrorResponse	"Not found error"
-//	@Failure		500	{object}	response.ErrorResponse	"Internal server error"
+//	@Param			id			path		string					true	"Product ID"
+//	@Param			If-Match	header		string					false	"ETag of the product version to change"
+//	@Success		200			{object}	bool					"Product deleted"
+//	@Failure		400			{object}	response.ErrorResponse	"Bad Request error"
+//	@Failure		404			{object}	response.ErrorResponse	"Not found error"
+//	@Failure		409			{object}	response.ErrorResponse	"Changed by someone else meanwhile"
+//	@Failure		412			{object}	response.ErrorResponse	"If-Match does not match the current version"
+//	@Failure		500			{object}	response.ErrorResponse	"Internal server error"
 //	@Router			/products/{id} [delete]
 func (h *ProductHandler) Delete(ctx *gin.Context) {
 	id, _ := domain.ParseID(ctx.Params.ByName("id"))
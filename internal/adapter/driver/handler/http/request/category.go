@@ -1,7 +1,8 @@
 package request
 
 type CreateCategoryRequest struct {
-	Name string `json:"name" binding:"required" example:"Snacks"`
+	Name    string `json:"name" binding:"required" example:"Snacks"`
+	Station string `json:"station" binding:"max=30" example:"grill"`
 }
 
 type GetCategoryRequest struct {
@@ -9,5 +10,6 @@ type GetCategoryRequest struct {
 }
 
 type UpdateCategoryRequest struct {
-	Name string `json:"name" binding:"required" example:"New snack"`
+	Name    string `json:"name" binding:"required" example:"New snack"`
+	Station string `json:"station" binding:"max=30" example:"grill"`
 }
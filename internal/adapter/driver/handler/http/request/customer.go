@@ -1,7 +1,7 @@
 package request
 
 type CreateCustomerRequest struct {
-	ID        uint64 `json:"id" binding:"required,min=1" example:"12345678910"`
+	CPF       string `json:"cpf" binding:"required" example:"123.456.789-09"`
 	FirstName string `json:"firstName" binding:"required" example:"John"`
 	LastName  string `json:"lastName" binding:"required" example:"Doe"`
 	Email     string `json:"email" binding:"required,email" example:"john.doe@example.com"`
@@ -15,11 +15,39 @@ type UpdateCustomerRequest struct {
 }
 
 type AuthCustomerRequest struct {
-	ID       uint64 `json:"id" example:"12345678910"`
+	CPF      string `json:"cpf" example:"123.456.789-09"`
 	Email    string `json:"email" example:"john.doe@example.com"`
 	Password string `json:"password" binding:"required" example:"12345678"`
 }
 
+type RequestPasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email" example:"john.doe@example.com"`
+}
+
+type ResetPasswordRequest struct {
+	// the code mailed to the customer
+	Token    string `json:"token" binding:"required" example:"nC1cB8v0yBqVn2Qm5R4rZ7Wc3kP6tX9aL0sD2fG4hJ8"`
+	Password string `json:"password" binding:"required,min=8" example:"12345678"`
+}
+
+type VerifyEmailRequest struct {
+	// the code mailed to the customer
+	Token string `json:"token" binding:"required" example:"nC1cB8v0yBqVn2Qm5R4rZ7Wc3kP6tX9aL0sD2fG4hJ8"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+type RevokeTokenRequest struct {
+	// access or refresh token
+	Token string `json:"token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
 type GetCustomerByIDRequest struct {
-	ID uint64 `uri:"id" binding:"required,min=1" example:"12345678910"`
+	ID string `uri:"id" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000"`
+}
+
+type GetCustomerByCPFRequest struct {
+	CPF string `uri:"cpf" binding:"required" example:"12345678909"`
 }
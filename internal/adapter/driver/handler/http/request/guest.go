@@ -0,0 +1,11 @@
+package request
+
+type CreateGuestRequest struct {
+	// optional, to call the orders of the guest out by
+	Name string `json:"name" binding:"max=100" example:"John"`
+}
+
+type AttachGuestRequest struct {
+	// access token of the customer, signed in at the kiosk
+	CustomerToken string `json:"customerToken" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
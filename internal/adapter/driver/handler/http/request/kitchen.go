@@ -0,0 +1,5 @@
+package request
+
+type KitchenQueueRequest struct {
+	Station string `form:"station" binding:"max=30" example:"grill"`
+}
@@ -1,11 +1,25 @@
 package request
 
+import (
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
 type GetOrderRequest struct {
 	ID string `uri:"id" binding:"required,min=1" example:"00000000-0000-0000-0000-000000000000"`
 }
 
 type GetOrderByCustomerRequest struct {
-	CustomerID uint64 `uri:"customerId" binding:"required,min=1" example:"1"`
+	CustomerID string `uri:"customerId" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000"`
+}
+
+type GetCustomerOrdersRequest struct {
+	Status string    `form:"status" example:"delivered"`
+	From   time.Time `form:"from" example:"1970-01-01T00:00:00Z"`
+	To     time.Time `form:"to" example:"1970-01-01T00:00:00Z"`
+	Cursor string    `form:"cursor"`
+	Limit  int       `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
 }
 
 type CreateOrderProductRequest struct {
@@ -15,8 +29,10 @@ type CreateOrderProductRequest struct {
 }
 
 type CreateOrderRequest struct {
-	CustomerID uint64                      `uri:"customerId" binding:"required,min=1" example:"1"`
-	Products   []CreateOrderProductRequest `json:"products" binding:"required"`
+	CustomerID string `json:"customerId" binding:"required_without=GuestID" example:"00000000-0000-0000-0000-000000000000"`
+	// instead of customerId, for guests ordering without signing up
+	GuestID  string                      `json:"guestId" binding:"omitempty,uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Products []CreateOrderProductRequest `json:"products" binding:"required"`
 }
 
 type RemoveProductRequest struct {
@@ -26,7 +42,22 @@ type RemoveProductRequest struct {
 
 type AddProductRequest struct {
 	ProductID  string `json:"productId" binding:"required" example:"00000000-0000-0000-0000-000000000000"`
-	CustomerID uint64 `json:"customerId" example:"00000000000"`
-	Quantity   uint16 `json:"quantity" binding:"required" example:"1"`
-	Notes      string `json:"notes" example:"notes"`
+	CustomerID string `json:"customerId" example:"00000000-0000-0000-0000-000000000000"`
+	// instead of customerId, for guests ordering without signing up
+	GuestID  string `json:"guestId" binding:"omitempty,uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Quantity uint16 `json:"quantity" binding:"required" example:"1"`
+	Notes    string `json:"notes" example:"notes"`
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason" binding:"required,max=500" example:"customer gave up"`
+}
+
+type RefundOrderRequest struct {
+	// amount to give back, zero refunds everything not refunded yet
+	Amount domain.Money `json:"amount" swaggertype:"number" example:"10.5"`
+}
+
+type UncollectedReportRequest struct {
+	Since time.Time `form:"since" example:"1970-01-01T00:00:00Z"`
 }
@@ -0,0 +1,12 @@
+package request
+
+type PayOrderRequest struct {
+	Method string `json:"method" binding:"omitempty,oneof=card pix" example:"pix"`
+}
+
+type PaymentWebhookRequest struct {
+	EventID   string `json:"eventId" binding:"required" example:"evt_000001"`
+	Reference string `json:"reference" binding:"required" example:"fake_ch_000001"`
+	OrderID   string `json:"orderId" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Status    string `json:"status" binding:"required,oneof=approved declined expired" example:"approved"`
+}
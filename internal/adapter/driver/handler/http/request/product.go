@@ -1,10 +1,12 @@
 package request
 
+import "github.com/vitovidale/fastfood-app/internal/core/domain"
+
 type CreateProductRequest struct {
-	Name        string  `json:"name" binding:"required" example:"Potato Chips"`
-	Price       float64 `json:"price" binding:"required,min=0" example:"10000"`
-	Description string  `json:"description" example:"Potato chips with cheese flavor"`
-	CategoryID  string  `json:"categoryId"`
+	Name        string       `json:"name" binding:"required" example:"Potato Chips"`
+	Price       domain.Money `json:"price" swaggertype:"number" example:"10.5"`
+	Description string       `json:"description" example:"Potato chips with cheese flavor"`
+	CategoryID  string       `json:"categoryId"`
 }
 
 type GetProductRequest struct {
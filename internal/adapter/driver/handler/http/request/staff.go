@@ -0,0 +1,14 @@
+package request
+
+type AuthStaffRequest struct {
+	Username string `json:"username" binding:"required" example:"jane"`
+	Password string `json:"password" binding:"required" example:"12345678"`
+}
+
+type CreateStaffRequest struct {
+	Username string `json:"username" binding:"required,max=100" example:"jane"`
+	Name     string `json:"name" binding:"required,max=200" example:"Jane Doe"`
+	Password string `json:"password" binding:"required,min=8" example:"12345678"`
+	// one of cashier, kitchen, manager or admin
+	Role string `json:"role" binding:"required" example:"cashier"`
+}
@@ -0,0 +1,14 @@
+package request
+
+// WebSocketCommand is a command sent by the staff over the WebSocket, moving
+// an order to the next status. Ref is sent back with its outcome.
+type WebSocketCommand struct {
+	Type    string `json:"type" binding:"required,oneof=prepare complete" example:"prepare"`
+	Ref     string `json:"ref" example:"1"`
+	OrderID string `json:"orderId" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Version uint64 `json:"version" example:"1"`
+}
+
+type WebSocketRequest struct {
+	LastEventID uint64 `form:"lastEventId" example:"1"`
+}
@@ -0,0 +1,31 @@
+package response
+
+import (
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+type AuthResponse struct {
+	AccessToken  string `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refreshToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	TokenType    string `json:"tokenType" example:"Bearer"`
+	// when the access token expires
+	ExpiresAt time.Time `json:"expiresAt" example:"1970-01-01T00:00:00Z"`
+	// only when authenticating with a password
+	Customer *CustomerResponse `json:"customer,omitempty"`
+}
+
+func NewAuthResponse(pair *domain.TokenPair, customer *domain.Customer) AuthResponse {
+	rsp := AuthResponse{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresAt:    pair.ExpiresAt,
+	}
+	if customer != nil {
+		c := NewCustomerResponse(customer)
+		rsp.Customer = &c
+	}
+	return rsp
+}
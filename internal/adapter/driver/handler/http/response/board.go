@@ -0,0 +1,17 @@
+package response
+
+import (
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+type PickupBoardResponse struct {
+	Preparing []uint16 `json:"preparing" example:"12,13"`
+	Ready     []uint16 `json:"ready" example:"10,11"`
+}
+
+func NewPickupBoardResponse(board *domain.PickupBoard) PickupBoardResponse {
+	return PickupBoardResponse{
+		Preparing: board.Preparing,
+		Ready:     board.Ready,
+	}
+}
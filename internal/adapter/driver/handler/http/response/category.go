@@ -9,6 +9,7 @@ import (
 type CategoryResponse struct {
 	ID        domain.ID  `json:"id"`
 	Name      string     `json:"name" example:"Snacks"`
+	Station   string     `json:"station" example:"grill"`
 	CreatedAt time.Time  `json:"createdAt" example:"1970-01-01T00:00:00Z"`
 	UpdatedAt *time.Time `json:"updatedAt" example:"1970-01-01T00:00:00Z"`
 }
@@ -17,6 +18,7 @@ func NewCategoryResponse(category *domain.Category) CategoryResponse {
 	return CategoryResponse{
 		ID:        category.ID,
 		Name:      category.Name,
+		Station:   category.Station,
 		CreatedAt: category.CreatedAt,
 		UpdatedAt: category.UpdatedAt,
 	}
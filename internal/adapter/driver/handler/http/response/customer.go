@@ -6,22 +6,49 @@ import (
 	"github.com/vitovidale/fastfood-app/internal/core/domain"
 )
 
+// CustomerResponse carries the CPF masked, see domain.CPF.Masked.
 type CustomerResponse struct {
-	ID        uint64 `json:"id"`
-	FirstName string `json:"firstName" example:"John"`
-	LastName  string `json:"lastName" example:"Doe"`
-	Email     string `json:"email" example:"john.doe@example.com"`
-	CreatedAt string `json:"createdAt" example:"1970-01-01T00:00:00Z"`
-	UpdatedAt string `json:"updatedAt" example:"1970-01-01T00:00:00Z"`
+	ID        domain.ID `json:"id"`
+	CPF       string    `json:"cpf" example:"***.456.789-**"`
+	FirstName string    `json:"firstName" example:"John"`
+	LastName  string    `json:"lastName" example:"Doe"`
+	Email     string    `json:"email" example:"john.doe@example.com"`
+	// whether the customer proved to own the email
+	EmailVerified bool   `json:"emailVerified" example:"true"`
+	CreatedAt     string `json:"createdAt" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt     string `json:"updatedAt" example:"1970-01-01T00:00:00Z"`
+	Version       uint64 `json:"version" example:"1"`
+}
+
+func (r CustomerResponse) GetVersion() uint64 {
+	return r.Version
 }
 
 func NewCustomerResponse(customer *domain.Customer) CustomerResponse {
 	return CustomerResponse{
-		ID:        customer.ID,
+		ID:            customer.ID,
+		CPF:           customer.CPF.Masked(),
+		FirstName:     customer.FirstName,
+		LastName:      customer.LastName,
+		Email:         customer.Email,
+		EmailVerified: customer.IsEmailVerified(),
+		CreatedAt:     customer.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     customer.UpdatedAt.Format(time.RFC3339),
+		Version:       customer.Version,
+	}
+}
+
+// CustomerIdentityResponse is what the kiosk learns about the customer
+// identifying with a CPF, enough to greet them. The customer signs in to be
+// told apart any further.
+type CustomerIdentityResponse struct {
+	CPF       string `json:"cpf" example:"***.456.789-**"`
+	FirstName string `json:"firstName" example:"John"`
+}
+
+func NewCustomerIdentityResponse(customer *domain.Customer) CustomerIdentityResponse {
+	return CustomerIdentityResponse{
+		CPF:       customer.CPF.Masked(),
 		FirstName: customer.FirstName,
-		LastName:  customer.LastName,
-		Email:     customer.Email,
-		CreatedAt: customer.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: customer.UpdatedAt.Format(time.RFC3339),
 	}
 }
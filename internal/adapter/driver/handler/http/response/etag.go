@@ -0,0 +1,29 @@
+package response
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Versioned is implemented by the responses of entities with a version,
+// which HandleSuccess sends as their ETag.
+type Versioned interface {
+	GetVersion() uint64
+}
+
+// ETag returns the entity tag of an entity version.
+func ETag(version uint64) string {
+	return strconv.Quote(strconv.FormatUint(version, 10))
+}
+
+// ParseETag returns the entity version of an entity tag, as sent back by
+// clients in If-Match headers. Weak tags are accepted.
+func ParseETag(tag string) (uint64, error) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(unquoted, 10, 64)
+}
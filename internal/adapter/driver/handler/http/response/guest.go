@@ -0,0 +1,43 @@
+package response
+
+import (
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+type GuestResponse struct {
+	ID         domain.ID  `json:"id"`
+	Name       string     `json:"name,omitempty" example:"John"`
+	CustomerID *domain.ID `json:"customerId,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt" example:"1970-01-01T00:00:00Z"`
+	AttachedAt *time.Time `json:"attachedAt,omitempty" example:"1970-01-01T00:00:00Z"`
+}
+
+func NewGuestResponse(g *domain.GuestSession) GuestResponse {
+	return GuestResponse{
+		ID:         g.ID,
+		Name:       g.Name,
+		CustomerID: g.CustomerID,
+		CreatedAt:  g.CreatedAt,
+		AttachedAt: g.AttachedAt,
+	}
+}
+
+type GuestAuthResponse struct {
+	AccessToken  string        `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string        `json:"refreshToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	TokenType    string        `json:"tokenType" example:"Bearer"`
+	ExpiresAt    time.Time     `json:"expiresAt" example:"1970-01-01T00:00:00Z"`
+	Guest        GuestResponse `json:"guest"`
+}
+
+func NewGuestAuthResponse(pair *domain.TokenPair, g *domain.GuestSession) GuestAuthResponse {
+	return GuestAuthResponse{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresAt:    pair.ExpiresAt,
+		Guest:        NewGuestResponse(g),
+	}
+}
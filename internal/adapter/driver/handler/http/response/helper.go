@@ -6,7 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/vitovidale/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
 )
 
 type DefaultResponse struct {
@@ -44,6 +44,10 @@ func parseError(err error) []string {
 }
 
 func HandleSuccess(ctx *gin.Context, data any) {
+	if v, ok := data.(Versioned); ok {
+		ctx.Header("ETag", ETag(v.GetVersion()))
+	}
+
 	rsp := newResponse(data)
 	ctx.JSON(http.StatusOK, rsp)
 }
@@ -52,6 +56,35 @@ var requestErrorStatusMap = map[error]int{
 	domain.ErrorInternal:        http.StatusInternalServerError,
 	domain.ErrorDataNotFound:    http.StatusNotFound,
 	domain.ErrorConflictingData: http.StatusConflict,
+
+	domain.ErrorPreconditionFailed: http.StatusPreconditionFailed,
+	domain.ErrorUnauthorized:       http.StatusUnauthorized,
+	domain.ErrorForbidden:          http.StatusForbidden,
+
+	domain.ErrorCustomerWrongPassword: http.StatusUnauthorized,
+
+	domain.ErrorCustomerNotFound:      http.StatusNotFound,
+	domain.ErrorCustomerAlreadyExists: http.StatusConflict,
+	domain.ErrorCPFInvalid:            http.StatusBadRequest,
+
+	domain.ErrorCustomerTokenInvalid:         http.StatusBadRequest,
+	domain.ErrorCustomerEmailAlreadyVerified: http.StatusConflict,
+
+	domain.ErrorGuestNotFound:        http.StatusNotFound,
+	domain.ErrorGuestAlreadyAttached: http.StatusConflict,
+
+	domain.ErrorRoleUnknown:            http.StatusBadRequest,
+	domain.ErrorStaffUserAlreadyExists: http.StatusConflict,
+
+	domain.ErrorInvalidCursor:                 http.StatusBadRequest,
+	domain.ErrorOrderNotEditable:              http.StatusConflict,
+	domain.ErrorOrderEmpty:                    http.StatusUnprocessableEntity,
+	domain.ErrorOrderTrackingNumbersExhausted: http.StatusServiceUnavailable,
+
+	domain.ErrorMoneyInvalidAmount:      http.StatusBadRequest,
+	domain.ErrorPaymentInvalidSignature: http.StatusUnauthorized,
+	domain.ErrorPaymentNotRefundable:    http.StatusConflict,
+	domain.ErrorRefundInvalidAmount:     http.StatusUnprocessableEntity,
 }
 
 func HandleBadRequest(ctx *gin.Context, err error) {
@@ -66,6 +99,16 @@ func HandleError(ctx *gin.Context, err error) {
 		statusCode = http.StatusInternalServerError
 	}
 
+	var transitionErr *domain.ErrInvalidTransition
+	if errors.As(err, &transitionErr) {
+		statusCode = http.StatusConflict
+	}
+
+	// the entity changed after it was read, see domain.ErrVersionConflict
+	if errors.Is(err, domain.ErrorConflictingData) {
+		statusCode = http.StatusConflict
+	}
+
 	errMsg := parseError(err)
 	errRsp := newErrorResponse(errMsg)
 	ctx.JSON(statusCode, errRsp)
@@ -0,0 +1,56 @@
+package response
+
+import (
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+type KitchenTicketResponse struct {
+	OrderID        domain.ID             `json:"orderId" example:"00000000-0000-0000-0000-000000000000"`
+	TrackingNumber *uint16               `json:"trackingNumber" example:"1"`
+	Status         string                `json:"status" example:"confirmed"`
+	PaidAt         time.Time             `json:"paidAt" example:"1970-01-01T00:00:00Z"`
+	StartedAt      *time.Time            `json:"startedAt" example:"1970-01-01T00:00:00Z"`
+	ElapsedSeconds int64                 `json:"elapsedSeconds" example:"300"`
+	Items          []KitchenItemResponse `json:"items"`
+}
+
+type KitchenItemResponse struct {
+	ProductID domain.ID `json:"productId" example:"00000000-0000-0000-0000-000000000000"`
+	Name      string    `json:"name" example:"Cheeseburger"`
+	Quantity  uint16    `json:"quantity" example:"1"`
+	Notes     string    `json:"notes" example:"no onions"`
+	Station   string    `json:"station" example:"grill"`
+}
+
+func NewKitchenTicketResponse(ticket *domain.KitchenTicket, now time.Time) KitchenTicketResponse {
+	rsp := KitchenTicketResponse{
+		OrderID:        ticket.OrderID,
+		TrackingNumber: ticket.TrackingNumber,
+		Status:         ticket.Status,
+		PaidAt:         ticket.PaidAt,
+		StartedAt:      ticket.StartedAt,
+		ElapsedSeconds: int64(ticket.Elapsed(now).Seconds()),
+		Items:          []KitchenItemResponse{},
+	}
+
+	for _, item := range ticket.Items {
+		rsp.Items = append(rsp.Items, KitchenItemResponse{
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			Notes:     item.Notes,
+			Station:   item.Station,
+		})
+	}
+	return rsp
+}
+
+func NewKitchenQueueResponse(tickets []*domain.KitchenTicket, now time.Time) []KitchenTicketResponse {
+	list := []KitchenTicketResponse{}
+	for _, ticket := range tickets {
+		list = append(list, NewKitchenTicketResponse(ticket, now))
+	}
+	return list
+}
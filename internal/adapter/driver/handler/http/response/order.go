@@ -3,19 +3,32 @@ package response
 import (
 	"time"
 
-	"github.com/vitovidale/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
 )
 
 type OrderResponse struct {
 	ID             domain.ID              `json:"id" example:"1"`
-	CustomerID     uint64                 `json:"customerId" example:"1"`
-	Total          float64                `json:"total" example:"100"`
+	CustomerID     *domain.ID             `json:"customerId"`
+	GuestID        *domain.ID             `json:"guestId,omitempty"`
+	GuestName      string                 `json:"guestName,omitempty" example:"John"`
+	Total          domain.Money           `json:"total" swaggertype:"number" example:"100"`
+	Currency       string                 `json:"currency" example:"BRL"`
 	Status         string                 `json:"status" example:"pending"`
 	TrackingNumber *uint16                `json:"trackingNumber" example:"1"`
 	CreatedAt      time.Time              `json:"createdAt" example:"1970-01-01T00:00:00Z"`
 	StartedAt      *time.Time             `json:"startedAt" example:"1970-01-01T00:00:00Z"`
 	ReadyAt        *time.Time             `json:"readyAt" example:"1970-01-01T00:00:00Z"`
+	DeliveredAt    *time.Time             `json:"deliveredAt,omitempty" example:"1970-01-01T00:00:00Z"`
+	CancelledAt    *time.Time             `json:"cancelledAt,omitempty" example:"1970-01-01T00:00:00Z"`
+	CancelReason   string                 `json:"cancelReason,omitempty" example:"customer gave up"`
+	CancelledBy    string                 `json:"cancelledBy,omitempty" example:"kiosk"`
+	RefundPending  bool                   `json:"refundPending" example:"false"`
 	Products       []OrderProductResponse `json:"products"`
+	Version        uint64                 `json:"version" example:"1"`
+}
+
+func (r OrderResponse) GetVersion() uint64 {
+	return r.Version
 }
 
 type OrderProductResponse struct {
@@ -23,18 +36,116 @@ type OrderProductResponse struct {
 	ProductID string          `json:"productId" example:"00000000-0000-0000-0000-000000000000"`
 	Product   ProductResponse `json:"product"`
 	Quantity  uint16          `json:"quantity" example:"1"`
+	UnitPrice domain.Money    `json:"unitPrice" swaggertype:"number" example:"10.5"`
+	Total     domain.Money    `json:"total" swaggertype:"number" example:"21"`
 	Notes     string          `json:"notes" example:"notes"`
 }
 
+func NewOrderProductResponse(line *domain.OrderProduct) OrderProductResponse {
+	return OrderProductResponse{
+		ID:        line.ID.String(),
+		ProductID: line.ProductID.String(),
+		Product:   NewProductResponse(&line.Product),
+		Quantity:  line.Quantity,
+		UnitPrice: line.UnitPrice,
+		Total:     line.Total,
+		Notes:     line.Notes,
+	}
+}
+
 func NewOrderResponse(order *domain.Order) OrderResponse {
 	return OrderResponse{
 		ID:             order.ID,
 		CustomerID:     order.CustomerID,
+		GuestID:        order.GuestID,
+		GuestName:      order.GuestName,
 		Total:          order.Total,
+		Currency:       order.Total.Currency,
 		Status:         order.Status,
 		TrackingNumber: order.TrackingNumber,
 		CreatedAt:      order.CreatedAt,
 		StartedAt:      order.StartedAt,
 		ReadyAt:        order.ReadyAt,
+		DeliveredAt:    order.DeliveredAt,
+		CancelledAt:    order.CancelledAt,
+		CancelReason:   order.CancelReason,
+		CancelledBy:    order.CancelledBy,
+		RefundPending:  order.RefundPending,
+		Version:        order.Version,
+	}
+}
+
+type OrderPageResponse struct {
+	Orders []OrderResponse `json:"orders"`
+	// absent on the last page
+	NextCursor string `json:"nextCursor,omitempty" example:"MTcxNTM0NDIwMDAwMDAwMDAwMDow"`
+}
+
+func NewOrderPageResponse(page *domain.OrderPage) OrderPageResponse {
+	rsp := OrderPageResponse{Orders: []OrderResponse{}}
+	for _, detail := range page.Orders {
+		order := NewOrderResponse(&detail.Order)
+		order.Products = []OrderProductResponse{}
+		for _, line := range detail.Lines {
+			order.Products = append(order.Products, NewOrderProductResponse(line))
+		}
+		rsp.Orders = append(rsp.Orders, order)
+	}
+	if page.Next != nil {
+		rsp.NextCursor = page.Next.String()
+	}
+	return rsp
+}
+
+type OrderTotalMismatchResponse struct {
+	OrderID  domain.ID    `json:"orderId" example:"00000000-0000-0000-0000-000000000000"`
+	Stored   domain.Money `json:"stored" swaggertype:"number" example:"21"`
+	Computed domain.Money `json:"computed" swaggertype:"number" example:"31.5"`
+}
+
+func NewOrderTotalMismatchListResponse(mismatches []*domain.OrderTotalMismatch) []OrderTotalMismatchResponse {
+	list := []OrderTotalMismatchResponse{}
+	for _, m := range mismatches {
+		list = append(list, OrderTotalMismatchResponse{
+			OrderID:  m.OrderID,
+			Stored:   m.Stored,
+			Computed: m.Computed,
+		})
+	}
+	return list
+}
+
+type UncollectedOrderResponse struct {
+	OrderID        domain.ID `json:"orderId" example:"00000000-0000-0000-0000-000000000000"`
+	TrackingNumber *uint16   `json:"trackingNumber" example:"1"`
+	ReadyAt        time.Time `json:"readyAt" example:"1970-01-01T00:00:00Z"`
+	WaitingSeconds int64     `json:"waitingSeconds" example:"300"`
+}
+
+type UncollectedReportResponse struct {
+	Waiting            []UncollectedOrderResponse `json:"waiting"`
+	Since              time.Time                  `json:"since" example:"1970-01-01T00:00:00Z"`
+	Delivered          int64                      `json:"delivered" example:"42"`
+	AverageWaitSeconds int64                      `json:"averageWaitSeconds" example:"90"`
+	MaxWaitSeconds     int64                      `json:"maxWaitSeconds" example:"600"`
+}
+
+func NewUncollectedReportResponse(report *domain.UncollectedReport, now time.Time) UncollectedReportResponse {
+	rsp := UncollectedReportResponse{
+		Waiting:            []UncollectedOrderResponse{},
+		Since:              report.Since,
+		Delivered:          report.Delivered,
+		AverageWaitSeconds: int64(report.AverageWait.Seconds()),
+		MaxWaitSeconds:     int64(report.MaxWait.Seconds()),
+	}
+
+	for _, o := range report.Waiting {
+		rsp.Waiting = append(rsp.Waiting, UncollectedOrderResponse{
+			OrderID:        o.OrderID,
+			TrackingNumber: o.TrackingNumber,
+			ReadyAt:        o.ReadyAt,
+			WaitingSeconds: int64(o.Waiting(now).Seconds()),
+		})
 	}
+	return rsp
 }
@@ -0,0 +1,31 @@
+package response
+
+import (
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+type OrderEventResponse struct {
+	ID         domain.ID `json:"id" example:"00000000-0000-0000-0000-000000000000"`
+	Type       string    `json:"type" example:"status_changed"`
+	Actor      string    `json:"actor" example:"staff:kitchen"`
+	OldValue   string    `json:"oldValue" example:"confirmed"`
+	NewValue   string    `json:"newValue" example:"started"`
+	OccurredAt time.Time `json:"occurredAt" example:"1970-01-01T00:00:00Z"`
+}
+
+func NewOrderEventListResponse(events []*domain.OrderEvent) []OrderEventResponse {
+	list := []OrderEventResponse{}
+	for _, event := range events {
+		list = append(list, OrderEventResponse{
+			ID:         event.ID,
+			Type:       event.Type,
+			Actor:      event.Actor,
+			OldValue:   event.OldValue,
+			NewValue:   event.NewValue,
+			OccurredAt: event.OccurredAt,
+		})
+	}
+	return list
+}
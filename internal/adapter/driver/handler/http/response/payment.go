@@ -0,0 +1,114 @@
+package response
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// pixQRCodeSize is the width and height, in pixels, of the PIX QR code image.
+const pixQRCodeSize = 256
+
+type PaymentResponse struct {
+	ID            domain.ID    `json:"id" example:"00000000-0000-0000-0000-000000000000"`
+	OrderID       domain.ID    `json:"orderId" example:"00000000-0000-0000-0000-000000000000"`
+	Amount        domain.Money `json:"amount" swaggertype:"number" example:"100"`
+	Currency      string       `json:"currency" example:"BRL"`
+	Method        string       `json:"method" example:"card"`
+	ProviderRef   string       `json:"providerRef" example:"fake_ch_000001"`
+	Status        string       `json:"status" example:"declined"`
+	Attempts      uint16       `json:"attempts" example:"1"`
+	FailureReason string       `json:"failureReason,omitempty" example:"insufficient funds"`
+	PixCode       string       `json:"pixCode,omitempty" example:"00020101021226580014br.gov.bcb.pix..."`
+	CreatedAt     time.Time    `json:"createdAt" example:"1970-01-01T00:00:00Z"`
+	ExpiresAt     *time.Time   `json:"expiresAt,omitempty" example:"1970-01-01T00:00:00Z"`
+	SettledAt     *time.Time   `json:"settledAt" example:"1970-01-01T00:00:00Z"`
+}
+
+type PayOrderResponse struct {
+	Order   any             `json:"order"`
+	Payment PaymentResponse `json:"payment"`
+	// PNG image of the PIX QR code, base64 encoded
+	PixQRCode string `json:"pixQrCode,omitempty" example:"iVBORw0KGgoAAAANSUhEUgAA..."`
+}
+
+func NewPaymentResponse(payment *domain.Payment) PaymentResponse {
+	return PaymentResponse{
+		ID:            payment.ID,
+		OrderID:       payment.OrderID,
+		Amount:        payment.Amount,
+		Currency:      payment.Amount.Currency,
+		Method:        string(payment.Method),
+		ProviderRef:   payment.ProviderRef,
+		Status:        payment.Status,
+		Attempts:      payment.Attempts,
+		FailureReason: payment.FailureReason,
+		PixCode:       payment.PixCode,
+		CreatedAt:     payment.CreatedAt,
+		ExpiresAt:     payment.ExpiresAt,
+		SettledAt:     payment.SettledAt,
+	}
+}
+
+// NewPayOrderResponse returns the paid order with its payment, rendering the
+// QR code image of PIX payments.
+func NewPayOrderResponse(order any, payment *domain.Payment) (PayOrderResponse, error) {
+	rsp := PayOrderResponse{
+		Order:   order,
+		Payment: NewPaymentResponse(payment),
+	}
+
+	if payment.PixCode != "" {
+		png, err := qrcode.Encode(payment.PixCode, qrcode.Medium, pixQRCodeSize)
+		if err != nil {
+			return rsp, err
+		}
+		rsp.PixQRCode = base64.StdEncoding.EncodeToString(png)
+	}
+
+	return rsp, nil
+}
+
+func NewPaymentListResponse(payments []*domain.Payment) []PaymentResponse {
+	list := []PaymentResponse{}
+	for _, payment := range payments {
+		list = append(list, NewPaymentResponse(payment))
+	}
+	return list
+}
+
+type RefundResponse struct {
+	ID          domain.ID    `json:"id" example:"00000000-0000-0000-0000-000000000000"`
+	OrderID     domain.ID    `json:"orderId" example:"00000000-0000-0000-0000-000000000000"`
+	PaymentID   domain.ID    `json:"paymentId" example:"00000000-0000-0000-0000-000000000000"`
+	Amount      domain.Money `json:"amount" swaggertype:"number" example:"10.5"`
+	Currency    string       `json:"currency" example:"BRL"`
+	ProviderRef string       `json:"providerRef" example:"fake_re_000001"`
+	Status      string       `json:"status" example:"completed"`
+	CreatedBy   string       `json:"createdBy" example:"manager"`
+	CreatedAt   time.Time    `json:"createdAt" example:"1970-01-01T00:00:00Z"`
+}
+
+func NewRefundResponse(refund *domain.Refund) RefundResponse {
+	return RefundResponse{
+		ID:          refund.ID,
+		OrderID:     refund.OrderID,
+		PaymentID:   refund.PaymentID,
+		Amount:      refund.Amount,
+		Currency:    refund.Amount.Currency,
+		ProviderRef: refund.ProviderRef,
+		Status:      refund.Status,
+		CreatedBy:   refund.CreatedBy,
+		CreatedAt:   refund.CreatedAt,
+	}
+}
+
+func NewRefundListResponse(refunds []*domain.Refund) []RefundResponse {
+	list := []RefundResponse{}
+	for _, refund := range refunds {
+		list = append(list, NewRefundResponse(refund))
+	}
+	return list
+}
@@ -3,29 +3,41 @@ package response
 import (
 	"time"
 
-	"github.com/vitovidale/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
 )
 
 type ProductResponse struct {
 	ID          string           `json:"id" example:"1"`
 	Name        string           `json:"name" example:"Potato Chips"`
-	Price       float64          `json:"price" example:"10000"`
+	Price       domain.Money     `json:"price" swaggertype:"number" example:"10.5"`
+	Currency    string           `json:"currency" example:"BRL"`
 	Description string           `json:"description" example:"Potato chips with cheese flavor"`
 	Category    CategoryResponse `json:"category"`
 	CreatedAt   time.Time        `json:"createdAt" example:"1970-01-01T00:00:00Z"`
 	UpdatedAt   *time.Time       `json:"updatedAt" example:"1970-01-01T00:00:00Z"`
+	Version     uint64           `json:"version" example:"1"`
+}
+
+func (r ProductResponse) GetVersion() uint64 {
+	return r.Version
 }
 
 func NewProductResponse(product *domain.Product) ProductResponse {
-	return ProductResponse{
+	rsp := ProductResponse{
 		ID:          product.ID.String(),
 		Name:        product.Name,
 		Price:       product.Price,
+		Currency:    product.Price.Currency,
 		Description: product.Description,
-		Category:    NewCategoryResponse(product.Category),
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
+		Version:     product.Version,
+	}
+	// the category is not loaded along with order lines
+	if product.Category != nil {
+		rsp.Category = NewCategoryResponse(product.Category)
 	}
+	return rsp
 }
 
 func NewProductListResponse(products []*domain.Product) []ProductResponse {
@@ -0,0 +1,57 @@
+package response
+
+import (
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+type StaffUserResponse struct {
+	ID        domain.ID   `json:"id"`
+	Username  string      `json:"username" example:"jane"`
+	Name      string      `json:"name" example:"Jane Doe"`
+	Role      domain.Role `json:"role" example:"cashier"`
+	CreatedAt time.Time   `json:"createdAt" example:"1970-01-01T00:00:00Z"`
+	Version   uint64      `json:"version" example:"1"`
+}
+
+func (r StaffUserResponse) GetVersion() uint64 {
+	return r.Version
+}
+
+func NewStaffUserResponse(u *domain.StaffUser) StaffUserResponse {
+	return StaffUserResponse{
+		ID:        u.ID,
+		Username:  u.Username,
+		Name:      u.Name,
+		Role:      u.Role,
+		CreatedAt: u.CreatedAt,
+		Version:   u.Version,
+	}
+}
+
+func NewStaffUserListResponse(users []*domain.StaffUser) []StaffUserResponse {
+	list := make([]StaffUserResponse, 0, len(users))
+	for _, u := range users {
+		list = append(list, NewStaffUserResponse(u))
+	}
+	return list
+}
+
+type StaffAuthResponse struct {
+	AccessToken  string            `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string            `json:"refreshToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	TokenType    string            `json:"tokenType" example:"Bearer"`
+	ExpiresAt    time.Time         `json:"expiresAt" example:"1970-01-01T00:00:00Z"`
+	Staff        StaffUserResponse `json:"staff"`
+}
+
+func NewStaffAuthResponse(pair *domain.TokenPair, u *domain.StaffUser) StaffAuthResponse {
+	return StaffAuthResponse{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresAt:    pair.ExpiresAt,
+		Staff:        NewStaffUserResponse(u),
+	}
+}
@@ -0,0 +1,23 @@
+package response
+
+import (
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+type OrderStatusEventResponse struct {
+	OrderID        domain.ID `json:"orderId" example:"00000000-0000-0000-0000-000000000000"`
+	Status         string    `json:"status" example:"confirmed"`
+	TrackingNumber *uint16   `json:"trackingNumber" example:"1"`
+	OccurredAt     time.Time `json:"occurredAt" example:"1970-01-01T00:00:00Z"`
+}
+
+func NewOrderStatusEventResponse(event *domain.OrderStatusEvent) OrderStatusEventResponse {
+	return OrderStatusEventResponse{
+		OrderID:        event.OrderID,
+		Status:         event.Status,
+		TrackingNumber: event.TrackingNumber,
+		OccurredAt:     event.OccurredAt,
+	}
+}
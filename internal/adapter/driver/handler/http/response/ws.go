@@ -0,0 +1,39 @@
+package response
+
+import (
+	"strconv"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// WebSocketMessage is a message sent over the WebSocket: an order status
+// event, a reset telling the client to reload the orders, or the outcome of a
+// command, acknowledged or failed.
+type WebSocketMessage struct {
+	Type     string                    `json:"type" example:"status"`
+	EventID  string                    `json:"eventId,omitempty" example:"1"`
+	Order    *OrderStatusEventResponse `json:"order,omitempty"`
+	Ref      string                    `json:"ref,omitempty" example:"1"`
+	Messages []string                  `json:"messages,omitempty" example:"Error message 1, Error message 2"`
+}
+
+func NewWebSocketStatusMessage(event *domain.OrderStatusEvent) WebSocketMessage {
+	if event.Reset {
+		return WebSocketMessage{Type: "reset", EventID: strconv.FormatUint(event.ID, 10)}
+	}
+
+	order := NewOrderStatusEventResponse(event)
+	return WebSocketMessage{
+		Type:    "status",
+		EventID: strconv.FormatUint(event.ID, 10),
+		Order:   &order,
+	}
+}
+
+func NewWebSocketAckMessage(ref string) WebSocketMessage {
+	return WebSocketMessage{Type: "ack", Ref: ref}
+}
+
+func NewWebSocketErrorMessage(ref string, err error) WebSocketMessage {
+	return WebSocketMessage{Type: "error", Ref: ref, Messages: parseError(err)}
+}
@@ -11,6 +11,8 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/vitovidale/fastfood-app/docs"
 	"github.com/vitovidale/fastfood-app/internal/adapter/driven/config"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/port"
 )
 
 // Router is a struct that wraps all the routes for the app.
@@ -21,10 +23,17 @@ type Router struct {
 // NewRouter creates a new HTTP Router.
 func NewRouter(
 	config *config.HTTP,
+	auth port.AuthService,
 	productHandler ProductHandler,
 	categoryHandler CategoryHandler,
 	customerHandler CustomerHandler,
+	staffHandler StaffHandler,
+	guestHandler GuestHandler,
 	orderHandler OrderHandler,
+	paymentHandler PaymentHandler,
+	kitchenHandler KitchenHandler,
+	webSocketHandler WebSocketHandler,
+	boardHandler BoardHandler,
 	healthHandler HealthHandler,
 ) (*Router, error) {
 	if config.Env == "production" {
@@ -32,6 +41,7 @@ func NewRouter(
 	}
 
 	router := gin.Default()
+	router.ContextWithFallback = true
 
 	// cors setup
 	corsConfig := cors.DefaultConfig()
@@ -46,51 +56,104 @@ func NewRouter(
 	// originsList := strings.Split(allowedOrigins, ",")
 	// corsConfig.AllowOrigins = originsList
 
-	router.Use(sloggin.New(slog.Default()), gin.Recovery(), cors.New(corsConfig))
+	router.Use(sloggin.New(slog.Default()), gin.Recovery(), cors.New(corsConfig), versionMiddleware())
+
+	// customers and guests only reach their own data, see domain.CheckCustomer
+	// and domain.CheckOrder, and staff users what the policy of their role
+	// allows, see domain.Role
+	authenticated := authMiddleware(auth)
+	can := permissionMiddleware
+
 	v1 := router.Group("/v1")
 	{
 		products := v1.Group("/products")
 		{
 			products.GET("/category/:id", productHandler.GetByCategory)
 			products.GET("/:id", productHandler.GetByID)
-			products.PUT("/:id", productHandler.Update)
-			products.DELETE("/:id", productHandler.Delete)
+			products.PUT("/:id", authenticated, can(domain.PermissionManageCatalog), productHandler.Update)
+			products.DELETE("/:id", authenticated, can(domain.PermissionManageCatalog), productHandler.Delete)
 			products.GET("", productHandler.GetAll)
-			products.POST("", productHandler.Create)
+			products.POST("", authenticated, can(domain.PermissionManageCatalog), productHandler.Create)
 		}
 
 		categories := v1.Group("/categories")
 		{
 			categories.GET("/:id", categoryHandler.GetByID)
-			categories.PUT("/:id", categoryHandler.Update)
-			categories.DELETE("/:id", categoryHandler.Delete)
+			categories.PUT("/:id", authenticated, can(domain.PermissionManageCatalog), categoryHandler.Update)
+			categories.DELETE("/:id", authenticated, can(domain.PermissionManageCatalog), categoryHandler.Delete)
 			categories.GET("", categoryHandler.GetAll)
-			categories.POST("", categoryHandler.Create)
+			categories.POST("", authenticated, can(domain.PermissionManageCatalog), categoryHandler.Create)
 		}
 
 		customers := v1.Group("/customers")
 		{
-			customers.GET("/:id", customerHandler.GetByID)
-			customers.PUT("/:id", customerHandler.Update)
-			customers.DELETE("/:id", customerHandler.Delete)
+			customers.GET("/:id/orders", authenticated, orderHandler.GetCustomerOrders)
+			customers.GET("/cpf/:cpf", authenticated, customerHandler.GetByCPF)
+			customers.GET("/:id", authenticated, customerHandler.GetByID)
+			customers.PUT("/:id", authenticated, customerHandler.Update)
+			customers.DELETE("/:id", authenticated, customerHandler.Delete)
+			customers.POST("/auth/refresh", customerHandler.Refresh)
+			customers.POST("/auth/revoke", customerHandler.Revoke)
 			customers.POST("/auth", customerHandler.Auth)
+			customers.POST("/password-reset/confirm", customerHandler.ResetPassword)
+			customers.POST("/password-reset", customerHandler.RequestPasswordReset)
+			customers.POST("/email-verification/confirm", customerHandler.VerifyEmail)
+			customers.POST("/:id/email-verification", authenticated, customerHandler.RequestEmailVerification)
 			customers.POST("", customerHandler.Create)
 		}
 
-		orders := v1.Group("/orders")
+		guests := v1.Group("/guests")
+		{
+			guests.POST("/:id/attach", authenticated, guestHandler.Attach)
+			guests.POST("", guestHandler.Create)
+		}
+
+		staff := v1.Group("/staff")
+		{
+			staff.POST("/auth", staffHandler.Auth)
+			staff.DELETE("/:id", authenticated, can(domain.PermissionManageStaff), staffHandler.Delete)
+			staff.GET("", authenticated, can(domain.PermissionManageStaff), staffHandler.List)
+			staff.POST("", authenticated, can(domain.PermissionManageStaff), staffHandler.Create)
+		}
+
+		orders := v1.Group("/orders", authenticated)
 		{
+			orders.GET("/stream", can(domain.PermissionViewOrders), orderHandler.Stream)
+			orders.GET("/:id/stream", orderHandler.StreamByID)
 			orders.GET("/:id/status", orderHandler.GetStatus)
+			orders.GET("/:id/payments", orderHandler.GetPayments)
+			orders.GET("/:id/refunds", orderHandler.GetRefunds)
+			orders.GET("/:id/history", orderHandler.GetHistory)
+			orders.GET("/totals/mismatches", can(domain.PermissionViewReports), orderHandler.GetTotalMismatches)
+			orders.GET("/reports/uncollected", can(domain.PermissionViewReports), orderHandler.UncollectedReport)
+			orders.PATCH("/:id/totals", can(domain.PermissionManageOrders), orderHandler.RecalculateTotals)
+			orders.POST("/:id/refunds", can(domain.PermissionManageOrders), orderHandler.Refund)
 			orders.PATCH("/:id/pay", orderHandler.Pay)
-			orders.PATCH("/:id/prepare", orderHandler.Prepare)
-			orders.PATCH("/:id/complete", orderHandler.Complete)
+			orders.PATCH("/:id/prepare", can(domain.PermissionPrepareOrders), orderHandler.Prepare)
+			orders.PATCH("/:id/complete", can(domain.PermissionPrepareOrders), orderHandler.Complete)
+			orders.PATCH("/:id/deliver", can(domain.PermissionDeliverOrders), orderHandler.Deliver)
+			orders.PATCH("/:id/cancel", orderHandler.Cancel)
 			orders.POST("/products", orderHandler.AddProduct)
 			orders.DELETE("/:orderId/products/:orderProductId", orderHandler.RemoveProduct)
 			orders.GET("/customer/:customerId", orderHandler.GetByCustomerID)
 			orders.GET("/:id", orderHandler.GetByID)
-			orders.GET("", orderHandler.List)
+			orders.GET("", can(domain.PermissionViewOrders), orderHandler.List)
 			orders.POST("", orderHandler.Create)
 		}
 
+		kitchen := v1.Group("/kitchen", authenticated, can(domain.PermissionViewOrders))
+		{
+			kitchen.GET("/queue", kitchenHandler.Queue)
+		}
+
+		v1.GET("/ws", authenticated, can(domain.PermissionViewOrders), webSocketHandler.Serve)
+		v1.GET("/board", boardHandler.Get)
+
+		payments := v1.Group("/payments")
+		{
+			payments.POST("/webhook", paymentHandler.Webhook)
+		}
+
 		health := v1.Group("/health")
 		{
 			health.GET("/readiness", healthHandler.Readiness)
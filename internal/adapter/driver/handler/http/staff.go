@@ -0,0 +1,136 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/request"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/response"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/port"
+)
+
+type StaffHandler struct {
+	service port.StaffService
+	auth    port.AuthService
+}
+
+func NewStaffHandler(service port.StaffService, auth port.AuthService) *StaffHandler {
+	return &StaffHandler{service: service, auth: auth}
+}
+
+// Auth godoc
+//
+//	@Summary		Authenticate a staff user
+//	@Description	Authenticates a staff user with username and password, returning tokens carrying the role of the user. Tokens are refreshed and revoked as the customer ones
+//	@Tags			Staff
+//	@Accept			json
+//	@Produce		json
+//	@Param			AuthStaffRequest	body		request.AuthStaffRequest	true	"Authenticate staff user request"
+//	@Success		200					{object}	response.StaffAuthResponse	"Staff user authenticated"
+//	@Failure		400					{object}	response.ErrorResponse		"Bad Request error"
+//	@Failure		401					{object}	response.ErrorResponse		"Wrong username or password"
+//	@Router			/staff/auth [post]
+func (h *StaffHandler) Auth(ctx *gin.Context) {
+	var req request.AuthStaffRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	u, err := h.service.Authenticate(ctx, req.Username, req.Password)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	pair, err := h.auth.Issue(ctx, domain.NewStaffPrincipal(u))
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewStaffAuthResponse(pair, u))
+}
+
+// Create godoc
+//
+//	@Summary		Create a staff user
+//	@Description	Creates a staff user with one of the roles cashier, kitchen, manager or admin
+//	@Tags			Staff
+//	@Accept			json
+//	@Produce		json
+//	@Param			CreateStaffRequest	body		request.CreateStaffRequest	true	"Create staff user request"
+//	@Success		200					{object}	response.StaffUserResponse	"Staff user created"
+//	@Failure		400					{object}	response.ErrorResponse		"Validation errors or unknown role"
+//	@Failure		401					{object}	response.ErrorResponse		"Missing or invalid token"
+//	@Failure		403					{object}	response.ErrorResponse		"Not allowed to manage staff users"
+//	@Failure		409					{object}	response.ErrorResponse		"Username already taken"
+//	@Security		BearerAuth
+//	@Router			/staff [post]
+func (h *StaffHandler) Create(ctx *gin.Context) {
+	var req request.CreateStaffRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	role, err := domain.ParseRole(req.Role)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	u, err := h.service.Create(ctx, req.Username, req.Name, req.Password, role)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewStaffUserResponse(u))
+}
+
+// List godoc
+//
+//	@Summary	List the staff users
+//	@Tags		Staff
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	[]response.StaffUserResponse	"Staff users"
+//	@Failure	401	{object}	response.ErrorResponse			"Missing or invalid token"
+//	@Failure	403	{object}	response.ErrorResponse			"Not allowed to manage staff users"
+//	@Security	BearerAuth
+//	@Router		/staff [get]
+func (h *StaffHandler) List(ctx *gin.Context) {
+	users, err := h.service.List(ctx)
+	if err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+
+	response.HandleSuccess(ctx, response.NewStaffUserListResponse(users))
+}
+
+// Delete godoc
+//
+//	@Summary		Delete a staff user
+//	@Description	Deletes a staff user, whose tokens stop being refreshed
+//	@Tags			Staff
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Staff user ID"
+//	@Param			If-Match	header		string					false	"ETag of the staff user version to delete"
+//	@Success		200			{object}	bool					"Staff user deleted"
+//	@Failure		401			{object}	response.ErrorResponse	"Missing or invalid token"
+//	@Failure		403			{object}	response.ErrorResponse	"Not allowed to manage staff users"
+//	@Failure		404			{object}	response.ErrorResponse	"Not found error"
+//	@Failure		412			{object}	response.ErrorResponse	"If-Match does not match the current version"
+//	@Security		BearerAuth
+//	@Router			/staff/{id} [delete]
+func (h *StaffHandler) Delete(ctx *gin.Context) {
+	id, _ := domain.ParseID(ctx.Params.ByName("id"))
+
+	if err := h.service.Delete(ctx, id); err != nil {
+		response.HandleError(ctx, err)
+		return
+	}
+	response.HandleSuccess(ctx, true)
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/response"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+const (
+	// sseHeartbeatInterval is how often an idle stream sends a comment, so
+	// proxies and clients do not close it.
+	sseHeartbeatInterval = 15 * time.Second
+
+	// sseRetry is how long, in milliseconds, clients wait to reconnect.
+	sseRetry = 3000
+)
+
+// lastEventID returns the ID of the last event a reconnecting client received,
+// taken from the Last-Event-ID header, or zero.
+func lastEventID(ctx *gin.Context) uint64 {
+	id, err := strconv.ParseUint(ctx.GetHeader("Last-Event-ID"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// startStream sends the headers of a Server-Sent Events stream, and how long
+// clients should wait before reconnecting.
+func startStream(ctx *gin.Context) {
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	fmt.Fprintf(ctx.Writer, "retry: %d\n\n", sseRetry)
+	ctx.Writer.Flush()
+}
+
+// renderStatusEvent sends an order status event, or a reset event telling
+// the client to reload the orders it follows. Events without an ID, such as
+// the current status of an order, do not change where clients resume from.
+func renderStatusEvent(ctx *gin.Context, event *domain.OrderStatusEvent) {
+	e := sse.Event{
+		Event: "status",
+		Data:  response.NewOrderStatusEventResponse(event),
+	}
+	if event.Reset {
+		e.Event, e.Data = "reset", ""
+	}
+	if event.ID > 0 {
+		e.Id = strconv.FormatUint(event.ID, 10)
+	}
+	ctx.Render(-1, e)
+}
+
+// streamStatus sends the events accepted by filter, and every reset event,
+// until the client goes away or the events channel is closed, sending
+// heartbeats while idle.
+func streamStatus(ctx *gin.Context, events <-chan *domain.OrderStatusEvent, filter func(*domain.OrderStatusEvent) bool) {
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// dropped for falling behind, the client resumes from the
+				// last event it received
+				return false
+			}
+			if event.Reset || filter(event) {
+				renderStatusEvent(ctx, event)
+			}
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		return true
+	})
+}
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gorilla/websocket"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/request"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driver/handler/http/response"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/service"
+)
+
+const (
+	// wsWriteWait is how long writing a message to a connection may take.
+	wsWriteWait = 10 * time.Second
+
+	// wsPongWait is how long a connection may stay silent, pings included,
+	// before it is considered gone.
+	wsPongWait = 60 * time.Second
+
+	// wsPingInterval is how often connections are pinged, less than wsPongWait.
+	wsPingInterval = wsPongWait * 9 / 10
+
+	// wsMaxMessageSize is the largest command accepted, in bytes.
+	wsMaxMessageSize = 4096
+
+	// wsReplyBuffer is how many command outcomes wait to be written.
+	wsReplyBuffer = 16
+)
+
+// WebSocketHandler serves the in-store boards over a WebSocket. Every
+// connection receives the status changes of all orders, and the kitchen sends
+// commands over it to move orders along.
+//
+// Each connection subscribes to the order event bus, which fans events out to
+// a buffer per connection, dropping connections that fall behind.
+type WebSocketHandler struct {
+	service  *service.OrderService
+	upgrader websocket.Upgrader
+}
+
+func NewWebSocketHandler(service *service.OrderService) *WebSocketHandler {
+	return &WebSocketHandler{
+		service: service,
+		upgrader: websocket.Upgrader{
+			// the access token, not the origin, authenticates the handshake
+			CheckOrigin: func(*nethttp.Request) bool { return true },
+		},
+	}
+}
+
+// Serve godoc
+//
+//	@Summary		Order updates and kitchen commands over WebSocket
+//	@Description	Upgrades the request to a WebSocket, authenticated with the access token of a staff user allowed to view orders, as a bearer token or in the access_token query parameter
+//	@Description	The server sends a message of type status for every order status change, starting after lastEventId when reconnecting
+//	@Description	Clients reconnecting after events no longer retained get a message of type reset instead, and should reload the orders
+//	@Description	The client sends commands of type prepare or complete, with the orderId, an optional ref and an optional version to expect, and gets back a message of type ack or error with the same ref
+//	@Description	Connections falling behind are closed with code 1013, and should reconnect from the last event received
+//	@Tags			Kitchen
+//	@Param			Authorization	header		string						false	"Bearer access token"
+//	@Param			access_token	query		string						false	"Access token"
+//	@Param			lastEventId		query		int							false	"ID of the last event received"
+//	@Success		101				{object}	response.WebSocketMessage	"Switching protocols"
+//	@Failure		400				{object}	response.ErrorResponse		"Bad Request error"
+//	@Failure		401				{object}	response.ErrorResponse		"Unauthorized error"
+//	@Security		BearerAuth
+//	@Router			/ws [get]
+func (h *WebSocketHandler) Serve(ctx *gin.Context) {
+	var req request.WebSocketRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.HandleBadRequest(ctx, err)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// the upgrader already replied with the error
+		return
+	}
+
+	connCtx, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+
+	events := h.service.SubscribeStatus(connCtx, req.LastEventID)
+	replies := make(chan response.WebSocketMessage, wsReplyBuffer)
+
+	go h.write(connCtx, conn, events, replies)
+	h.read(connCtx, conn, replies)
+}
+
+// read runs the commands received on the connection, in order, until the
+// connection is closed or goes silent.
+func (h *WebSocketHandler) read(ctx context.Context, conn *websocket.Conn, replies chan<- response.WebSocketMessage) {
+	conn.SetReadLimit(wsMaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var cmd request.WebSocketCommand
+		if err = json.Unmarshal(data, &cmd); err == nil {
+			err = h.run(ctx, &cmd)
+		}
+
+		reply := response.NewWebSocketAckMessage(cmd.Ref)
+		if err != nil {
+			reply = response.NewWebSocketErrorMessage(cmd.Ref, err)
+		}
+
+		select {
+		case replies <- reply:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// run moves an order as the command asks, through the same transitions as
+// the HTTP endpoints.
+func (h *WebSocketHandler) run(ctx context.Context, cmd *request.WebSocketCommand) error {
+	if err := binding.Validator.ValidateStruct(cmd); err != nil {
+		return err
+	}
+
+	if cmd.Version > 0 {
+		ctx = domain.WithExpectedVersion(ctx, cmd.Version)
+	}
+
+	id := domain.ParseIDOrNil(cmd.OrderID)
+	switch cmd.Type {
+	case "prepare":
+		return h.service.Prepare(ctx, id)
+	case "complete":
+		return h.service.Complete(ctx, id)
+	}
+	return nil
+}
+
+// write sends the order status events and the command outcomes to the
+// connection, pinging it while idle, and closes it when done.
+func (h *WebSocketHandler) write(ctx context.Context, conn *websocket.Conn, events <-chan *domain.OrderStatusEvent, replies <-chan response.WebSocketMessage) {
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+	defer conn.Close()
+
+	for {
+		var msg response.WebSocketMessage
+
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				if ctx.Err() == nil {
+					// dropped for falling behind, the client reconnects from
+					// the last event it received
+					deadline := time.Now().Add(wsWriteWait)
+					_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too slow"), deadline)
+				}
+				return
+			}
+			msg = response.NewWebSocketStatusMessage(event)
+		case msg = <-replies:
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+			continue
+		}
+
+		_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+		if err := conn.WriteJSON(msg); err != nil {
+			return
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package logger_test
 
 import (
+	"log/slog"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/vitovidale/fastfood-app/internal/adapter/driven/config"
 	"github.com/vitovidale/fastfood-app/internal/adapter/logger"
 )
@@ -21,3 +24,39 @@ func TestSetLogger(t *testing.T) {
 		t.Error("Expected logger to be initialized, but it was nil")
 	}
 }
+
+func TestMaskCPF(t *testing.T) {
+	testCases := []struct {
+		title string
+		attr  slog.Attr
+		want  slog.Attr
+	}{
+		{
+			title: "Path",
+			attr:  slog.String("path", "/v1/customers/cpf/529.982.247-25"),
+			want:  slog.String("path", "/v1/customers/cpf/***.982.247-**"),
+		},
+		{
+			title: "Invalid CPF in the path",
+			attr:  slog.String("path", "/v1/customers/cpf/52998224724"),
+			want:  slog.String("path", "/v1/customers/cpf/***"),
+		},
+		{
+			title: "Params",
+			attr:  slog.Any("params", map[string]string{"cpf": "52998224725"}),
+			want:  slog.Any("params", map[string]string{"cpf": "***.982.247-**"}),
+		},
+		{
+			title: "Other paths",
+			attr:  slog.String("path", "/v1/customers/auth"),
+			want:  slog.String("path", "/v1/customers/auth"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			got := logger.MaskCPF([]string{"request"}, tc.attr)
+			require.Equal(t, tc.want.String(), got.String())
+		})
+	}
+}
@@ -3,17 +3,56 @@ package logger
 import (
 	"log/slog"
 	"os"
+	"strings"
 
 	slogmulti "github.com/samber/slog-multi"
 	"github.com/vitovidale/fastfood-app/internal/adapter/driven/config"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var logger *slog.Logger
 
+// cpfPathSegment precedes the CPF in the paths of the requests looking a
+// customer up by CPF.
+const cpfPathSegment = "/cpf/"
+
+// MaskCPF masks the CPF of the requests looking a customer up by CPF, in
+// their path and parameters, so request logs carry it masked like everywhere
+// else, see domain.CPF.LogValue.
+func MaskCPF(groups []string, a slog.Attr) slog.Attr {
+	switch {
+	case a.Key == "path" && a.Value.Kind() == slog.KindString:
+		path := a.Value.String()
+		if i := strings.Index(path, cpfPathSegment); i >= 0 {
+			i += len(cpfPathSegment)
+			return slog.String(a.Key, path[:i]+maskCPF(path[i:]))
+		}
+	case a.Key == "params":
+		if params, ok := a.Value.Any().(map[string]string); ok && params["cpf"] != "" {
+			masked := make(map[string]string, len(params))
+			for k, v := range params {
+				masked[k] = v
+			}
+			masked["cpf"] = maskCPF(params["cpf"])
+			return slog.Any(a.Key, masked)
+		}
+	}
+	return a
+}
+
+func maskCPF(s string) string {
+	if cpf, err := domain.ParseCPF(s); err == nil {
+		return cpf.Masked()
+	}
+	return "***"
+}
+
 func Set(config *config.App) {
+	options := &slog.HandlerOptions{ReplaceAttr: MaskCPF}
+
 	logger = slog.New(
-		slog.NewTextHandler(os.Stderr, nil),
+		slog.NewTextHandler(os.Stderr, options),
 	)
 
 	if config.Env == "production" {
@@ -27,8 +66,8 @@ func Set(config *config.App) {
 
 		logger = slog.New(
 			slogmulti.Fanout(
-				slog.NewJSONHandler(logRotate, nil),
-				slog.NewTextHandler(os.Stderr, nil),
+				slog.NewJSONHandler(logRotate, options),
+				slog.NewTextHandler(os.Stderr, options),
 			),
 		)
 	}
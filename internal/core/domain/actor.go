@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+)
+
+type actorContextKey struct{}
+
+// WithActor returns a copy of ctx carrying who is performing the operation.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns who is performing the operation, or an empty
+// string when the caller is unknown.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorContextKey{}).(string)
+	return actor
+}
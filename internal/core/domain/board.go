@@ -0,0 +1,8 @@
+package domain
+
+// PickupBoard is what the customer-facing board shows: the tracking numbers of
+// the orders being prepared, and of the orders ready to be picked up.
+type PickupBoard struct {
+	Preparing []uint16
+	Ready     []uint16
+}
@@ -7,6 +7,7 @@ import (
 type Category struct {
 	ID        ID         `gorm:"size:36"`
 	Name      string     `gorm:"size:60;not null"`
+	Station   string     `gorm:"size:30"` // kitchen station preparing its products, e.g. grill or drinks
 	CreatedAt time.Time  `gorm:"autoCreateTime;not null"`
 	UpdatedAt *time.Time `gorm:"autoUpdateTime"`
 	DeletedAt *time.Time
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"time"
+)
+
+type PaymentStatus uint8
+
+// PaymentStatus is an enum that represents the status of a charge at the payment provider.
+//
+// The status can be one of the following:
+//
+// - Pending: the charge was created and is waiting for the customer to pay
+//
+// - Approved: the charge was paid
+//
+// - Declined: the charge was refused by the payment provider
+//
+// - Failed: the charge could not be created at the payment provider
+//
+// - Expired: the charge was not paid in time, such as an unpaid PIX QR code
+const (
+	PaymentStatusPending  PaymentStatus = 0
+	PaymentStatusApproved PaymentStatus = 1
+	PaymentStatusDeclined PaymentStatus = 2
+	PaymentStatusFailed   PaymentStatus = 3
+	PaymentStatusExpired  PaymentStatus = 4
+)
+
+func (s PaymentStatus) String() string {
+	switch s {
+	case PaymentStatusPending:
+		return "pending"
+	case PaymentStatusApproved:
+		return "approved"
+	case PaymentStatusDeclined:
+		return "declined"
+	case PaymentStatusFailed:
+		return "failed"
+	case PaymentStatusExpired:
+		return "expired"
+	}
+	return "unknown"
+}
+
+// ParsePaymentStatus returns the PaymentStatus represented by its string form.
+func ParsePaymentStatus(s string) (PaymentStatus, error) {
+	for status := PaymentStatusPending; status <= PaymentStatusExpired; status++ {
+		if status.String() == s {
+			return status, nil
+		}
+	}
+	return 0, ErrorPaymentUnknownStatus
+}
+
+// Charge is a request for payment of an order, as seen by the payment provider.
+//
+// PIX charges carry the BR Code the customer pays with, and expire when not
+// paid in time.
+type Charge struct {
+	Reference     string
+	OrderID       ID
+	Amount        Money
+	Method        PaymentMethod
+	Status        PaymentStatus
+	FailureReason string
+	PixCode       string
+	CreatedAt     time.Time
+	ExpiresAt     *time.Time
+}
+
+// IsExpired reports whether a charge still waiting to be paid has expired.
+func (c *Charge) IsExpired(now time.Time) bool {
+	return c.Status == PaymentStatusPending && c.ExpiresAt != nil && now.After(*c.ExpiresAt)
+}
+
+// PaymentEvent is a notification sent by the payment provider when the status
+// of a charge changes. Events are kept so that redeliveries are ignored.
+type PaymentEvent struct {
+	ID         string    `gorm:"size:100;primaryKey"`
+	Reference  string    `gorm:"size:100;not null;index"`
+	OrderID    ID        `gorm:"size:36;not null"`
+	Status     string    `gorm:"size:20;not null"`
+	ReceivedAt time.Time `gorm:"autoCreateTime;not null"`
+}
+
+func (PaymentEvent) TableName() string {
+	return "payment_events"
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// CPF is the Brazilian individual taxpayer registry number identifying a
+// customer, held as its 11 digits. Its String form is the formatted number,
+// logs and responses show it masked, see Masked.
+type CPF string
+
+// ParseCPF returns the CPF written as 11 digits, formatted or not, such as
+// "123.456.789-09" or "12345678909", as long as its check digits are right.
+func ParseCPF(s string) (CPF, error) {
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '-', ' ':
+			return -1
+		}
+		return r
+	}, s)
+
+	if len(digits) != 11 {
+		return "", ErrorCPFInvalid
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", ErrorCPFInvalid
+		}
+	}
+
+	// numbers with every digit the same pass the check digits, but are not
+	// given to anyone
+	if strings.Count(digits, digits[:1]) == len(digits) {
+		return "", ErrorCPFInvalid
+	}
+
+	if cpfCheckDigit(digits[:9]) != digits[9] || cpfCheckDigit(digits[:10]) != digits[10] {
+		return "", ErrorCPFInvalid
+	}
+	return CPF(digits), nil
+}
+
+// cpfCheckDigit returns the check digit following the digits: the weighted sum
+// of the digits, weights counting down to 2, modulo 11.
+func cpfCheckDigit(digits string) byte {
+	sum := 0
+	weight := len(digits) + 1
+	for i := 0; i < len(digits); i++ {
+		sum += int(digits[i]-'0') * weight
+		weight--
+	}
+
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - rest)
+}
+
+// String returns the formatted CPF, such as "123.456.789-09".
+func (c CPF) String() string {
+	if len(c) != 11 {
+		return string(c)
+	}
+	return string(c[:3]) + "." + string(c[3:6]) + "." + string(c[6:9]) + "-" + string(c[9:])
+}
+
+// Masked returns the formatted CPF showing only its middle digits, such as
+// "***.456.789-**", enough to tell customers apart without disclosing it.
+func (c CPF) Masked() string {
+	if len(c) != 11 {
+		return ""
+	}
+	return "***." + string(c[3:6]) + "." + string(c[6:9]) + "-**"
+}
+
+// LogValue keeps the CPF masked in logs.
+func (c CPF) LogValue() slog.Value {
+	return slog.StringValue(c.Masked())
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCPF(t *testing.T) {
+	testCases := []struct {
+		input string
+		cpf   CPF
+		err   error
+	}{
+		{input: "12345678909", cpf: "12345678909"},
+		{input: "123.456.789-09", cpf: "12345678909"},
+		{input: "529.982.247-25", cpf: "52998224725"},
+		{input: "123.456.789-10", err: ErrorCPFInvalid},
+		{input: "111.111.111-11", err: ErrorCPFInvalid},
+		{input: "1234567890", err: ErrorCPFInvalid},
+		{input: "1234567890a", err: ErrorCPFInvalid},
+		{input: "", err: ErrorCPFInvalid},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			cpf, err := ParseCPF(tc.input)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.cpf, cpf)
+		})
+	}
+}
+
+func TestCPF_Format(t *testing.T) {
+	cpf := CPF("12345678909")
+	require.Equal(t, "123.456.789-09", cpf.String())
+	require.Equal(t, "***.456.789-**", cpf.Masked())
+	require.Equal(t, "***.456.789-**", cpf.LogValue().String())
+}
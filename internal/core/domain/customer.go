@@ -6,16 +6,23 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-// TODO change the ID from CPF to UUID
+// Customer is someone ordering at the store. VerifiedEmail is the email the
+// customer proved to own, so changing the email leaves it unverified, see
+// IsEmailVerified. SignedOutAt is when the customer was last signed out of
+// every session, such as when the password was reset, see AcceptsToken.
 type Customer struct {
-	ID        uint64     `gorm:"type:bigint"`
-	FirstName string     `gorm:"size:100;not null"`
-	LastName  string     `gorm:"size:100;not null"`
-	Email     string     `gorm:"size:255;unique;not null"`
-	Password  string     `gorm:"size:64;not null"`
-	CreatedAt time.Time  `gorm:"autoCreateTime;not null"`
-	UpdatedAt *time.Time `gorm:"autoUpdateTime"`
-	DeletedAt *time.Time
+	ID            ID         `gorm:"size:36"`
+	CPF           CPF        `gorm:"size:11;unique;not null"`
+	FirstName     string     `gorm:"size:100;not null"`
+	LastName      string     `gorm:"size:100;not null"`
+	Email         string     `gorm:"size:255;unique;not null"`
+	VerifiedEmail string     `gorm:"size:255"`
+	Password      string     `gorm:"size:64;not null"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime;not null"`
+	UpdatedAt     *time.Time `gorm:"autoUpdateTime"`
+	DeletedAt     *time.Time
+	SignedOutAt   *time.Time
+	Version       uint64 `gorm:"not null;default:1"`
 }
 
 func (p *Customer) IsActive() bool {
@@ -42,3 +49,17 @@ func (p *Customer) Activate() error {
 func (p *Customer) Authenticate(password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(p.Password), []byte(password))
 }
+
+// AcceptsToken reports whether a token issued to the customer is still
+// accepted, that is it was issued after the customer was last signed out.
+// Tokens carry their issue time in whole seconds, so those issued in the same
+// second as the sign out are not accepted either.
+func (p *Customer) AcceptsToken(t *Token) bool {
+	return p.SignedOutAt == nil || t.IssuedAt.After(p.SignedOutAt.Truncate(time.Second))
+}
+
+// IsEmailVerified reports whether the customer proved to own the current
+// email.
+func (p *Customer) IsEmailVerified() bool {
+	return p.VerifiedEmail != "" && p.VerifiedEmail == p.Email
+}
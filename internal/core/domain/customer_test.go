@@ -53,3 +53,30 @@ func TestCustomer_Inactivate(t *testing.T) {
 		require.EqualError(t, err, "customer already inactive")
 	})
 }
+
+func TestCustomer_AcceptsToken(t *testing.T) {
+	c := &Customer{ID: NewID()}
+	token := NewToken(TokenTypeRefresh, NewCustomerPrincipal(c.ID), time.Hour)
+	// as read back from a signed token
+	token.IssuedAt = token.IssuedAt.Truncate(time.Second)
+	require.True(t, c.AcceptsToken(token))
+
+	signedOutAt := token.IssuedAt.Add(time.Millisecond)
+	c.SignedOutAt = &signedOutAt
+	require.False(t, c.AcceptsToken(token))
+
+	// issued in a later second than the sign out
+	token.IssuedAt = signedOutAt.Truncate(time.Second).Add(time.Second)
+	require.True(t, c.AcceptsToken(token))
+}
+
+func TestCustomer_IsEmailVerified(t *testing.T) {
+	c := &Customer{Email: "john.doe@example.com"}
+	require.False(t, c.IsEmailVerified())
+
+	c.VerifiedEmail = c.Email
+	require.True(t, c.IsEmailVerified())
+
+	c.Email = "john@example.com"
+	require.False(t, c.IsEmailVerified())
+}
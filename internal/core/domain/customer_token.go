@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+type CustomerTokenPurpose string
+
+const (
+	CustomerTokenPasswordReset     CustomerTokenPurpose = "password_reset"
+	CustomerTokenEmailVerification CustomerTokenPurpose = "email_verification"
+)
+
+// CustomerToken is a single use secret mailed to a customer, proving they own
+// Email when handed back, to reset their password or verify their email. Only
+// the hash of the secret is kept, see HashCustomerTokenSecret.
+type CustomerToken struct {
+	ID         ID                   `gorm:"size:36"`
+	CustomerID ID                   `gorm:"size:36;not null;index"`
+	Purpose    CustomerTokenPurpose `gorm:"size:20;not null"`
+	Hash       string               `gorm:"size:64;unique;not null"`
+	Email      string               `gorm:"size:255;not null"`
+	ExpiresAt  time.Time            `gorm:"not null"`
+	UsedAt     *time.Time
+	CreatedAt  time.Time `gorm:"autoCreateTime;not null"`
+}
+
+// NewCustomerToken returns a token for the customer to prove they own their
+// current email, along with the secret to mail them.
+func NewCustomerToken(c *Customer, purpose CustomerTokenPurpose, ttl time.Duration) (*CustomerToken, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(b)
+
+	return &CustomerToken{
+		ID:         NewID(),
+		CustomerID: c.ID,
+		Purpose:    purpose,
+		Hash:       HashCustomerTokenSecret(secret),
+		Email:      c.Email,
+		ExpiresAt:  time.Now().Add(ttl),
+	}, secret, nil
+}
+
+// HashCustomerTokenSecret returns the hash a customer token is found by.
+func HashCustomerTokenSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Check returns ErrorCustomerTokenInvalid unless the token was issued for
+// purpose, to the current email of c, and is neither used nor expired.
+func (t *CustomerToken) Check(purpose CustomerTokenPurpose, c *Customer) error {
+	if t.Purpose != purpose || t.UsedAt != nil || !time.Now().Before(t.ExpiresAt) || t.CustomerID != c.ID || t.Email != c.Email {
+		return ErrorCustomerTokenInvalid
+	}
+	return nil
+}
+
+// Use marks the token used, so it cannot be handed back again.
+func (t *CustomerToken) Use() {
+	now := time.Now()
+	t.UsedAt = &now
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCustomerToken(t *testing.T) {
+	c := &Customer{ID: NewID(), Email: "john.doe@example.com"}
+
+	token, secret, err := NewCustomerToken(c, CustomerTokenPasswordReset, time.Hour)
+	require.NoError(t, err)
+	require.NotEmpty(t, secret)
+	require.Equal(t, HashCustomerTokenSecret(secret), token.Hash)
+	require.NotEqual(t, secret, token.Hash)
+	require.Equal(t, c.Email, token.Email)
+
+	_, other, err := NewCustomerToken(c, CustomerTokenPasswordReset, time.Hour)
+	require.NoError(t, err)
+	require.NotEqual(t, secret, other)
+}
+
+func TestCustomerToken_Check(t *testing.T) {
+	c := &Customer{ID: NewID(), Email: "john.doe@example.com"}
+
+	used, _, _ := NewCustomerToken(c, CustomerTokenPasswordReset, time.Hour)
+	used.Use()
+	expired, _, _ := NewCustomerToken(c, CustomerTokenPasswordReset, -time.Minute)
+	token, _, _ := NewCustomerToken(c, CustomerTokenPasswordReset, time.Hour)
+
+	testCases := []struct {
+		title    string
+		token    *CustomerToken
+		purpose  CustomerTokenPurpose
+		customer *Customer
+		err      error
+	}{
+		{title: "Valid token", token: token, purpose: CustomerTokenPasswordReset, customer: c},
+		{title: "Another purpose", token: token, purpose: CustomerTokenEmailVerification, customer: c, err: ErrorCustomerTokenInvalid},
+		{title: "Used token", token: used, purpose: CustomerTokenPasswordReset, customer: c, err: ErrorCustomerTokenInvalid},
+		{title: "Expired token", token: expired, purpose: CustomerTokenPasswordReset, customer: c, err: ErrorCustomerTokenInvalid},
+		{title: "Email changed", token: token, purpose: CustomerTokenPasswordReset, customer: &Customer{ID: c.ID, Email: "john@example.com"}, err: ErrorCustomerTokenInvalid},
+		{title: "Another customer", token: token, purpose: CustomerTokenPasswordReset, customer: &Customer{ID: NewID(), Email: c.Email}, err: ErrorCustomerTokenInvalid},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			require.ErrorIs(t, tc.token.Check(tc.purpose, tc.customer), tc.err)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 )
 
 var (
@@ -10,6 +11,9 @@ var (
 	ErrorDataNotFound    = errors.New("record not found")
 	ErrorConflictingData = errors.New("conflicting data")
 
+	// the entity is no longer at the version the caller expected
+	ErrorPreconditionFailed = errors.New("precondition failed")
+
 	// category errors
 	ErrorCategoryAlreadyActive   = errors.New("category already active")
 	ErrorCategoryAlreadyInactive = errors.New("category already inactive")
@@ -28,14 +32,79 @@ var (
 	ErrorCustomerAlreadyActive   = errors.New("customer already inactive")
 	ErrorCustomerNotFound        = errors.New("customer not found")
 	ErrorCustomerWrongPassword   = errors.New("wrong password")
+	ErrorCPFInvalid              = errors.New("invalid CPF")
+
+	// the password reset or email verification token is unknown, expired or
+	// used already
+	ErrorCustomerTokenInvalid         = errors.New("invalid or expired token")
+	ErrorCustomerEmailAlreadyVerified = errors.New("email already verified")
 
 	// order errors
 	ErrorOrderAlreadyStarted    = errors.New("order already started")
 	ErrorOrderAlreadyDone       = errors.New("order already done")
 	ErrorOrderAlreadyProcessing = errors.New("order already processing")
 	ErrorOrderAlreadyCancelled  = errors.New("order already cancelled")
+	ErrorOrderNotEditable       = errors.New("order no longer takes product changes")
+	ErrorOrderEmpty             = errors.New("order has no products")
+	ErrorOrderUnknownStatus     = errors.New("unknown order status")
+
+	// the cursor of a page of orders was not handed out by the app
+	ErrorInvalidCursor = errors.New("invalid cursor")
+
+	// every tracking number is held by an active order
+	ErrorOrderTrackingNumbersExhausted = errors.New("no tracking number available")
+
+	// payment errors
+	ErrorPaymentNotRefundable    = errors.New("payment not refundable")
+	ErrorPaymentAlreadySettled   = errors.New("payment already settled")
+	ErrorPaymentUnknownStatus    = errors.New("unknown payment status")
+	ErrorPaymentUnknownMethod    = errors.New("unknown payment method")
+	ErrorPaymentInvalidSignature = errors.New("invalid payment signature")
+
+	// money errors
+	ErrorMoneyInvalidAmount = errors.New("invalid money amount")
+
+	// refund errors
+	ErrorRefundInvalidAmount = errors.New("invalid refund amount")
+
+	// auth errors
+	ErrorUnauthorized = errors.New("unauthorized")
+	ErrorForbidden    = errors.New("forbidden")
+	ErrorRoleUnknown  = errors.New("unknown role")
+
+	// guest errors
+	ErrorGuestNotFound        = errors.New("guest not found")
+	ErrorGuestAlreadyAttached = errors.New("guest already attached to another customer")
+
+	// staff errors
+	ErrorStaffUserAlreadyExists = errors.New("staff user already exists")
 
 	// healthcheck errors
 	ErrorAppNotReady   = errors.New("app not ready")
 	ErrorAppNotStarted = errors.New("app not started")
 )
+
+// ErrInvalidTransition is returned when an order is asked to move between two
+// statuses that are not connected in the order lifecycle.
+type ErrInvalidTransition struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e *ErrInvalidTransition) Error() string {
+	return fmt.Sprintf("invalid order transition from %s to %s", e.From, e.To)
+}
+
+// ErrVersionConflict is returned when an entity changed between being read and
+// written, so the write was not applied. It is an ErrorConflictingData.
+type ErrVersionConflict struct {
+	Version uint64
+}
+
+func (e *ErrVersionConflict) Error() string {
+	return fmt.Sprintf("conflicting data: version %d is no longer current", e.Version)
+}
+
+func (e *ErrVersionConflict) Unwrap() error {
+	return ErrorConflictingData
+}
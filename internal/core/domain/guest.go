@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+)
+
+// GuestSession is someone ordering at the kiosk without signing up. The
+// session may later be attached to the customer the guest identifies as,
+// along with its orders.
+type GuestSession struct {
+	ID ID `gorm:"size:36"`
+	// optional, to call the orders of the guest out by
+	Name       string `gorm:"size:100"`
+	CustomerID *ID    `gorm:"size:36"`
+	Customer   *Customer
+	CreatedAt  time.Time `gorm:"autoCreateTime;not null"`
+	AttachedAt *time.Time
+}
+
+func NewGuestSession(name string) *GuestSession {
+	return &GuestSession{
+		ID:        NewID(),
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Attach attaches the guest to a customer. Attaching again to the same
+// customer changes nothing, a guest is never attached to another customer.
+func (g *GuestSession) Attach(customerId ID) error {
+	if g.CustomerID != nil {
+		if *g.CustomerID != customerId {
+			return ErrorGuestAlreadyAttached
+		}
+		return nil
+	}
+
+	attachedAt := time.Now()
+	g.CustomerID = &customerId
+	g.AttachedAt = &attachedAt
+	return nil
+}
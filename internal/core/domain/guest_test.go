@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestSession_Attach(t *testing.T) {
+	customerId, otherId := NewID(), NewID()
+
+	g := NewGuestSession("John")
+	require.NoError(t, g.Attach(customerId))
+	require.Equal(t, customerId, *g.CustomerID)
+	require.NotNil(t, g.AttachedAt)
+
+	require.NoError(t, g.Attach(customerId))
+	require.ErrorIs(t, g.Attach(otherId), ErrorGuestAlreadyAttached)
+	require.Equal(t, customerId, *g.CustomerID)
+}
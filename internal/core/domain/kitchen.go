@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+)
+
+// KitchenTicket is an order in the kitchen queue: a paid order waiting to be
+// prepared, or being prepared, with the items the kitchen has to make.
+type KitchenTicket struct {
+	OrderID        ID
+	TrackingNumber *uint16
+	Status         string
+	PaidAt         time.Time
+	StartedAt      *time.Time
+	Items          []KitchenItem
+}
+
+// KitchenItem is a product of a kitchen ticket, along with the station of its
+// category that prepares it.
+type KitchenItem struct {
+	ProductID ID
+	Name      string
+	Quantity  uint16
+	Notes     string
+	Station   string
+}
+
+// Elapsed returns how long the customer has been waiting since paying.
+func (t *KitchenTicket) Elapsed(now time.Time) time.Duration {
+	return now.Sub(t.PaidAt)
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKitchenTicket_Elapsed(t *testing.T) {
+	paidAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ticket := KitchenTicket{PaidAt: paidAt}
+
+	require.Equal(t, 5*time.Minute, ticket.Elapsed(paidAt.Add(5*time.Minute)))
+}
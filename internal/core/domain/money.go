@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the ISO 4217 code of the currency everything is sold in.
+const DefaultCurrency = "BRL"
+
+// Money is an amount of money in integer cents, so that adding and
+// multiplying prices never drifts, with its ISO 4217 currency code.
+//
+// Entities store it in two columns, embedding it with a prefix such as
+// `gorm:"embedded;embeddedPrefix:price_"`. In JSON it is a plain decimal
+// number, as prices and totals were before, e.g. 10.50.
+//
+// Amounts in different currencies are never converted: the app sells in a
+// single currency and an empty currency is taken as DefaultCurrency.
+type Money struct {
+	Cents    int64  `gorm:"not null;default:0"`
+	Currency string `gorm:"size:3;not null;default:'BRL'"`
+}
+
+// NewMoney returns cents in the default currency.
+func NewMoney(cents int64) Money {
+	return Money{Cents: cents, Currency: DefaultCurrency}
+}
+
+// ParseMoney parses a decimal amount, such as "10", "10.5" or "-0.99", in the
+// default currency. Fractions of a cent are rejected instead of rounded.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	units, fraction, _ := strings.Cut(s, ".")
+	if units == "" || len(fraction) > 2 || strings.ContainsAny(units+fraction, "+-") {
+		return Money{}, ErrorMoneyInvalidAmount
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	cents, err := strconv.ParseInt(units+fraction, 10, 64)
+	if err != nil {
+		return Money{}, ErrorMoneyInvalidAmount
+	}
+
+	if negative {
+		cents = -cents
+	}
+	return NewMoney(cents), nil
+}
+
+func (m Money) currency() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{Cents: m.Cents + other.Cents, Currency: m.currency()}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{Cents: m.Cents - other.Cents, Currency: m.currency()}
+}
+
+// Mul returns the amount times quantity, e.g. the total of an order item.
+func (m Money) Mul(quantity int64) Money {
+	return Money{Cents: m.Cents * quantity, Currency: m.currency()}
+}
+
+func (m Money) IsZero() bool {
+	return m.Cents == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Cents > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Cents < 0
+}
+
+// String returns the amount with two decimal places, e.g. "10.50".
+func (m Money) String() string {
+	cents := m.Cents
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return sign + strconv.FormatInt(cents/100, 10) + "." + leftPad(strconv.FormatInt(cents%100, 10), 2)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts the amount as a JSON number, as prices and totals
+// were sent before, or as a string.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	money, err := ParseMoney(string(bytes.Trim(data, `"`)))
+	if err != nil {
+		return err
+	}
+
+	*m = money
+	return nil
+}
+
+func leftPad(s string, length int) string {
+	if len(s) >= length {
+		return s
+	}
+	return strings.Repeat("0", length-len(s)) + s
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoney_ParseMoney(t *testing.T) {
+	testCases := []struct {
+		input string
+		cents int64
+		err   error
+	}{
+		{input: "10", cents: 1000},
+		{input: "10.5", cents: 1050},
+		{input: "10.05", cents: 1005},
+		{input: "0.1", cents: 10},
+		{input: "-0.99", cents: -99},
+		{input: "10.555", err: ErrorMoneyInvalidAmount},
+		{input: ".5", err: ErrorMoneyInvalidAmount},
+		{input: "1e3", err: ErrorMoneyInvalidAmount},
+		{input: "--1", err: ErrorMoneyInvalidAmount},
+		{input: "", err: ErrorMoneyInvalidAmount},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			m, err := ParseMoney(tc.input)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, NewMoney(tc.cents), m)
+		})
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	// 0.1 + 0.2 drifts as float64
+	require.Equal(t, NewMoney(30), NewMoney(10).Add(NewMoney(20)))
+	require.Equal(t, NewMoney(-10), NewMoney(10).Sub(NewMoney(20)))
+	require.Equal(t, NewMoney(2997), NewMoney(999).Mul(3))
+	require.Equal(t, DefaultCurrency, Money{}.Add(NewMoney(1)).Currency)
+}
+
+func TestMoney_String(t *testing.T) {
+	require.Equal(t, "10.50", NewMoney(1050).String())
+	require.Equal(t, "0.05", NewMoney(5).String())
+	require.Equal(t, "-1.99", NewMoney(-199).String())
+}
+
+func TestMoney_JSON(t *testing.T) {
+	var v struct {
+		Price Money `json:"price"`
+		Total Money `json:"total"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"price": 10.5, "total": "25.50"}`), &v))
+	require.Equal(t, NewMoney(1050), v.Price)
+	require.Equal(t, NewMoney(2550), v.Total)
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"price": 10.5, "total": 25.5}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"price": 10.555}`), &v)
+	require.ErrorIs(t, err, ErrorMoneyInvalidAmount)
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Notification is a message sent to a customer, such as an email.
+type Notification struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// NewPasswordResetNotification returns the email handing a customer the secret
+// of a password reset token.
+func NewPasswordResetNotification(c *Customer, t *CustomerToken, secret string) *Notification {
+	return &Notification{
+		To:      t.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the code below to choose a new password. It expires at %s and can only be used once.\n\n%s\n\nIf you did not ask to reset your password, you can ignore this email.\n",
+			c.FirstName, t.ExpiresAt.Format(time.RFC3339), secret,
+		),
+	}
+}
+
+// NewEmailVerificationNotification returns the email handing a customer the
+// secret of an email verification token.
+func NewEmailVerificationNotification(c *Customer, t *CustomerToken, secret string) *Notification {
+	return &Notification{
+		To:      t.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the code below to verify your email. It expires at %s.\n\n%s\n",
+			c.FirstName, t.ExpiresAt.Format(time.RFC3339), secret,
+		),
+	}
+}
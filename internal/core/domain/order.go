@@ -18,9 +18,15 @@ type OrderStatus uint8
 //
 // - Started: the order is being prepared by the kitchen
 //
-// - Done: the order is done and sent to customer
+// - Done: the order is ready, waiting at the counter for the customer
+//
+// - Delivered: the customer collected the order
 //
 // - Cancelled: the order is cancelled
+//
+// - Refunded: everything paid for the order was given back to the customer
+//
+// - PartiallyRefunded: part of what was paid for the order was given back
 const (
 	OrderStatusPending    OrderStatus = 0
 	OrderStatusProcessing OrderStatus = 1
@@ -28,6 +34,11 @@ const (
 	OrderStatusStarted    OrderStatus = 3
 	OrderStatusDone       OrderStatus = 4
 	OrderStatusCancelled  OrderStatus = 5
+
+	OrderStatusRefunded          OrderStatus = 6
+	OrderStatusPartiallyRefunded OrderStatus = 7
+
+	OrderStatusDelivered OrderStatus = 8
 )
 
 func (s OrderStatus) String() string {
@@ -44,22 +55,96 @@ func (s OrderStatus) String() string {
 		return "done"
 	case OrderStatusCancelled:
 		return "cancelled"
+	case OrderStatusRefunded:
+		return "refunded"
+	case OrderStatusPartiallyRefunded:
+		return "partially_refunded"
+	case OrderStatusDelivered:
+		return "delivered"
 	}
 	return "unknown"
 }
 
+// ParseOrderStatus returns the OrderStatus represented by its string form.
+func ParseOrderStatus(s string) (OrderStatus, error) {
+	for status := OrderStatusPending; status <= OrderStatusDelivered; status++ {
+		if status.String() == s {
+			return status, nil
+		}
+	}
+	return 0, ErrorOrderUnknownStatus
+}
+
+// orderTransitions is the order lifecycle: for each status, the statuses an
+// order is allowed to move to. Paid orders that will not be fulfilled, because
+// they were cancelled or the kitchen cannot prepare them, can be refunded.
+// Processing orders cannot be cancelled while their charge may still be
+// approved, they are cancelled once it is settled. Delivered and Refunded are
+// terminal.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:           {OrderStatusProcessing, OrderStatusCancelled},
+	OrderStatusProcessing:        {OrderStatusConfirmed, OrderStatusPending},
+	OrderStatusConfirmed:         {OrderStatusStarted, OrderStatusCancelled, OrderStatusRefunded, OrderStatusPartiallyRefunded},
+	OrderStatusStarted:           {OrderStatusDone, OrderStatusRefunded, OrderStatusPartiallyRefunded},
+	OrderStatusDone:              {OrderStatusDelivered},
+	OrderStatusCancelled:         {OrderStatusRefunded, OrderStatusPartiallyRefunded},
+	OrderStatusPartiallyRefunded: {OrderStatusRefunded, OrderStatusPartiallyRefunded},
+}
+
+// RefundStatus returns the status of an order once refunded is given back
+// out of what was paid.
+func RefundStatus(refunded Money, paid Money) OrderStatus {
+	if !paid.Sub(refunded).IsPositive() {
+		return OrderStatusRefunded
+	}
+	return OrderStatusPartiallyRefunded
+}
+
+// IsPaid reports whether an order in this status has already been paid for.
+func (s OrderStatus) IsPaid() bool {
+	switch s {
+	case OrderStatusConfirmed, OrderStatusStarted, OrderStatusDone, OrderStatusDelivered:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether the lifecycle allows moving from s to next.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Order is what a customer, or a guest ordering without signing up, asks for.
+// Guest orders have no customer until the guest identifies as one, and may
+// have a name to call them out by.
 type Order struct {
-	ID             ID     `gorm:"size:36"`
-	CustomerID     uint64 `gorm:"type:bigint"`
-	Customer       Customer
+	ID             ID  `gorm:"size:36"`
+	CustomerID     *ID `gorm:"size:36;index:idx_orders_customer_created,priority:1"`
+	Customer       *Customer
+	GuestID        *ID       `gorm:"size:36;index"`
+	GuestName      string    `gorm:"size:100"`
 	Status         string    `gorm:"size:20"`
 	Products       []Product `gorm:"many2many:order_products;"`
-	Total          float64   `gorm:"not null;precision:14;scale:2;"`
+	Total          Money     `gorm:"embedded;embeddedPrefix:total_"`
 	TrackingNumber *uint16   ``
-	CreatedAt      time.Time `gorm:"autoCreateTime;not null"`
+	PaymentRef     string    `gorm:"size:100"`
+	CreatedAt      time.Time `gorm:"autoCreateTime;not null;index:idx_orders_customer_created,priority:2"`
+	PaidAt         *time.Time
 	StartedAt      *time.Time
 	ReadyAt        *time.Time
+	DeliveredAt    *time.Time
+	CancelledAt    *time.Time
+	CancelReason   string `gorm:"size:500"`
+	CancelledBy    string `gorm:"size:100"`
+	RefundPending  bool   `gorm:"not null;default:false"`
 	DeletedAt      *time.Time
+	// changes on every write, to detect concurrent changes
+	Version uint64 `gorm:"not null;default:1"`
 }
 
 type OrderProduct struct {
@@ -69,63 +154,174 @@ type OrderProduct struct {
 	Order     Order   `gorm:"foreignkey:OrderID"`
 	Product   Product `gorm:"foreignkey:ProductID"`
 	Quantity  uint16  `gorm:"not null"`
-	Total     float64 `gorm:"not null;default:0;precision:14;scale:2;"`
+	UnitPrice Money   `gorm:"embedded;embeddedPrefix:unit_price_"`
+	Total     Money   `gorm:"embedded;embeddedPrefix:total_"`
 	Notes     string  `gorm:"size:500"`
 	CreatedAt time.Time
 }
 
-func NewOrderWithCustomer(customerId uint64) *Order {
+// OrderTotalMismatch is an order whose stored total disagrees with the sum of
+// its lines, quantity times the unit price captured when each was added.
+type OrderTotalMismatch struct {
+	OrderID  ID
+	Stored   Money
+	Computed Money
+}
+
+func NewOrderWithCustomer(customerId ID) *Order {
+	return &Order{
+		ID:         NewID(),
+		CustomerID: &customerId,
+		Total:      NewMoney(0),
+		Status:     OrderStatusPending.String(),
+		CreatedAt:  time.Now(),
+	}
+}
+
+// NewOrderWithGuest returns an order of a guest, which belongs to the customer
+// the guest identified as, if any.
+func NewOrderWithGuest(g *GuestSession) *Order {
 	return &Order{
 		ID:         NewID(),
-		CustomerID: customerId,
-		Total:      0,
+		CustomerID: g.CustomerID,
+		GuestID:    &g.ID,
+		GuestName:  g.Name,
+		Total:      NewMoney(0),
 		Status:     OrderStatusPending.String(),
 		CreatedAt:  time.Now(),
 	}
 }
 
 func (o *Order) IsActive() bool {
-	return o.DeletedAt.IsZero()
+	return o.DeletedAt == nil
+}
+
+// CurrentStatus returns the order status as an OrderStatus.
+func (o *Order) CurrentStatus() (OrderStatus, error) {
+	return ParseOrderStatus(o.Status)
+}
+
+// CheckTransition returns an *ErrInvalidTransition when the order lifecycle
+// does not allow the order to move to the next status.
+func (o *Order) CheckTransition(next OrderStatus) error {
+	current, err := o.CurrentStatus()
+	if err != nil {
+		return err
+	}
+
+	if !current.CanTransitionTo(next) {
+		return &ErrInvalidTransition{From: current, To: next}
+	}
+	return nil
 }
 
-func (o *Order) Pay() error {
-	// o.Total =
-	o.Status = OrderStatusProcessing.String()
+// TransitionTo moves the order to the next status, as long as the order
+// lifecycle allows it, otherwise an *ErrInvalidTransition is returned.
+func (o *Order) TransitionTo(next OrderStatus) error {
+	if err := o.CheckTransition(next); err != nil {
+		return err
+	}
+
+	o.Status = next.String()
 	return nil
 }
 
-func (o *Order) Start() error {
-	if o.StartedAt != nil {
-		return ErrorOrderAlreadyStarted
+// CheckEditable returns ErrorOrderNotEditable unless the order is pending, so
+// the lines of an order being paid for, or paid, never change.
+func (o *Order) CheckEditable() error {
+	if o.Status != OrderStatusPending.String() {
+		return ErrorOrderNotEditable
 	}
+	return nil
+}
+
+// Pay moves the order to processing while the charge is settled by the
+// payment provider.
+func (o *Order) Pay(paymentRef string) error {
+	if err := o.TransitionTo(OrderStatusProcessing); err != nil {
+		return err
+	}
+	o.PaymentRef = paymentRef
+	return nil
+}
+
+// Confirm moves the order to confirmed once its charge is approved, queueing
+// it in the kitchen by payment time.
+func (o *Order) Confirm() error {
+	if err := o.TransitionTo(OrderStatusConfirmed); err != nil {
+		return err
+	}
+	paidAt := time.Now()
+	o.PaidAt = &paidAt
+	return nil
+}
 
+// FailPayment moves the order back to pending, when its charge was declined or
+// expired, so the customer can try again.
+func (o *Order) FailPayment() error {
+	return o.TransitionTo(OrderStatusPending)
+}
+
+func (o *Order) Start() error {
+	if err := o.TransitionTo(OrderStatusStarted); err != nil {
+		return err
+	}
 	startedAt := time.Now()
 	o.StartedAt = &startedAt
-	o.Status = OrderStatusStarted.String()
-
 	return nil
 }
 
-func (o *Order) Cancel() error {
-	if o.DeletedAt != nil {
-		return ErrorOrderAlreadyCancelled
+// Cancel cancels the order, recording why and by whom. Orders that were
+// already paid for are flagged for refund.
+func (o *Order) Cancel(reason string, cancelledBy string) error {
+	current, err := o.CurrentStatus()
+	if err != nil {
+		return err
 	}
 
-	deletedAt := time.Now()
-	o.DeletedAt = &deletedAt
-	o.Status = OrderStatusCancelled.String()
+	if err := o.TransitionTo(OrderStatusCancelled); err != nil {
+		return err
+	}
 
+	cancelledAt := time.Now()
+	o.CancelledAt = &cancelledAt
+	o.CancelReason = reason
+	o.CancelledBy = cancelledBy
+	o.RefundPending = current.IsPaid()
 	return nil
 }
 
-func (o *Order) Complete() error {
-	if o.ReadyAt != nil {
-		return ErrorOrderAlreadyDone
+// Refund moves the order to refunded, or partially refunded, once refunded is
+// given back out of what was paid. Fully refunded orders are no longer
+// pending a refund.
+func (o *Order) Refund(refunded Money, paid Money) error {
+	next := RefundStatus(refunded, paid)
+	if err := o.TransitionTo(next); err != nil {
+		return err
+	}
+
+	if next == OrderStatusRefunded {
+		o.RefundPending = false
 	}
+	return nil
+}
 
+func (o *Order) Complete() error {
+	if err := o.TransitionTo(OrderStatusDone); err != nil {
+		return err
+	}
 	readyAt := time.Now()
 	o.ReadyAt = &readyAt
-	o.Status = OrderStatusDone.String()
+	return nil
+}
 
+// Deliver moves a done order to delivered once the customer collects it from
+// the counter.
+func (o *Order) Deliver() error {
+	if err := o.TransitionTo(OrderStatusDelivered); err != nil {
+		return err
+	}
+	deliveredAt := time.Now()
+	o.DeliveredAt = &deliveredAt
 	return nil
 }
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+)
+
+// Types of the changes recorded in the order history.
+const (
+	OrderEventCreated          = "created"
+	OrderEventStatusChanged    = "status_changed"
+	OrderEventProductAdded     = "product_added"
+	OrderEventProductRemoved   = "product_removed"
+	OrderEventTotalChanged     = "total_changed"
+	OrderEventCustomerAttached = "customer_attached"
+)
+
+// OrderEvent is a change made to an order, recorded in its history along with
+// who made it and the values before and after. Events are never changed once
+// recorded.
+type OrderEvent struct {
+	ID         ID        `gorm:"size:36"`
+	OrderID    ID        `gorm:"size:36;not null;index"`
+	Type       string    `gorm:"size:30;not null"`
+	Actor      string    `gorm:"size:100"`
+	OldValue   string    `gorm:"type:text"`
+	NewValue   string    `gorm:"type:text"`
+	OccurredAt time.Time `gorm:"not null"`
+}
+
+func NewOrderEvent(orderID ID, eventType string, actor string, oldValue string, newValue string) *OrderEvent {
+	return &OrderEvent{
+		ID:         NewID(),
+		OrderID:    orderID,
+		Type:       eventType,
+		Actor:      actor,
+		OldValue:   oldValue,
+		NewValue:   newValue,
+		OccurredAt: time.Now(),
+	}
+}
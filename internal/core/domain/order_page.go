@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OrderFilter narrows down the orders of a customer. Zero fields match every
+// order, and From is inclusive while To is not.
+type OrderFilter struct {
+	Status string
+	From   *time.Time
+	To     *time.Time
+	// orders past the end of the previous page, none for the first page
+	After *OrderCursor
+	Limit int
+}
+
+// OrderCursor is where a page of orders, newest first, ends.
+type OrderCursor struct {
+	CreatedAt time.Time
+	ID        ID
+}
+
+// String encodes the cursor to be handed to clients, which should not rely on
+// its contents.
+func (c OrderCursor) String() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseOrderCursor decodes a cursor returned by OrderCursor.String.
+func ParseOrderCursor(s string) (*OrderCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrorInvalidCursor
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrorInvalidCursor
+	}
+
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, ErrorInvalidCursor
+	}
+
+	c := &OrderCursor{CreatedAt: time.Unix(0, n)}
+	if c.ID, err = ParseID(id); err != nil {
+		return nil, ErrorInvalidCursor
+	}
+	return c, nil
+}
+
+// OrderDetail is an order along with its lines.
+type OrderDetail struct {
+	Order
+	Lines []*OrderProduct
+}
+
+// OrderPage is a page of orders, newest first, and where the next page starts
+// when there is one.
+type OrderPage struct {
+	Orders []*OrderDetail
+	Next   *OrderCursor
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderCursor(t *testing.T) {
+	cursor := OrderCursor{
+		CreatedAt: time.Date(2024, 5, 10, 12, 30, 0, 123456000, time.UTC),
+		ID:        NewID(),
+	}
+
+	t.Run("Parse an encoded cursor", func(t *testing.T) {
+		parsed, err := ParseOrderCursor(cursor.String())
+		require.NoError(t, err)
+		require.True(t, cursor.CreatedAt.Equal(parsed.CreatedAt))
+		require.Equal(t, cursor.ID, parsed.ID)
+	})
+
+	t.Run("Reject a malformed cursor", func(t *testing.T) {
+		for _, s := range []string{"not base64!", "bm8tc2VwYXJhdG9y", "eDoxMjM"} {
+			_, err := ParseOrderCursor(s)
+			require.ErrorIs(t, err, ErrorInvalidCursor, s)
+		}
+	})
+}
@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_ParseOrderStatus(t *testing.T) {
+	t.Run("known status", func(t *testing.T) {
+		status, err := ParseOrderStatus("confirmed")
+		require.NoError(t, err)
+		require.Equal(t, OrderStatusConfirmed, status)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		_, err := ParseOrderStatus("invalid")
+		require.ErrorIs(t, err, ErrorOrderUnknownStatus)
+	})
+}
+
+func TestOrder_Lifecycle(t *testing.T) {
+	o := NewOrderWithCustomer(NewID())
+
+	require.NoError(t, o.Pay("ch_1"))
+	require.Equal(t, OrderStatusProcessing.String(), o.Status)
+	require.Equal(t, "ch_1", o.PaymentRef)
+
+	require.NoError(t, o.Confirm())
+	require.Equal(t, OrderStatusConfirmed.String(), o.Status)
+	require.NotNil(t, o.PaidAt)
+
+	require.NoError(t, o.Start())
+	require.Equal(t, OrderStatusStarted.String(), o.Status)
+	require.NotNil(t, o.StartedAt)
+
+	require.NoError(t, o.Complete())
+	require.Equal(t, OrderStatusDone.String(), o.Status)
+	require.NotNil(t, o.ReadyAt)
+
+	require.NoError(t, o.Deliver())
+	require.Equal(t, OrderStatusDelivered.String(), o.Status)
+	require.NotNil(t, o.DeliveredAt)
+}
+
+func TestOrder_FailPayment(t *testing.T) {
+	o := NewOrderWithCustomer(NewID())
+	require.NoError(t, o.Pay("ch_1"))
+
+	require.NoError(t, o.FailPayment())
+	require.Equal(t, OrderStatusPending.String(), o.Status)
+
+	require.NoError(t, o.Pay("ch_2"))
+	require.Equal(t, "ch_2", o.PaymentRef)
+}
+
+func TestOrder_TransitionTo(t *testing.T) {
+	t.Run("skipping a status", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+
+		err := o.Start()
+		require.EqualError(t, err, "invalid order transition from pending to started")
+		require.Equal(t, OrderStatusPending.String(), o.Status)
+		require.Nil(t, o.StartedAt)
+	})
+
+	t.Run("completing a cancelled order", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+		require.NoError(t, o.Cancel("abandoned", "kiosk"))
+
+		err := o.Complete()
+		var transitionErr *ErrInvalidTransition
+		require.ErrorAs(t, err, &transitionErr)
+		require.Equal(t, OrderStatusCancelled, transitionErr.From)
+		require.Equal(t, OrderStatusDone, transitionErr.To)
+	})
+
+	t.Run("cancelling a started order", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+		o.Status = OrderStatusStarted.String()
+
+		err := o.Cancel("abandoned", "kiosk")
+		require.EqualError(t, err, "invalid order transition from started to cancelled")
+		require.Nil(t, o.CancelledAt)
+	})
+
+	t.Run("unknown current status", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+		o.Status = "invalid"
+
+		err := o.Pay("ch_1")
+		require.ErrorIs(t, err, ErrorOrderUnknownStatus)
+	})
+}
+
+func TestOrder_Cancel(t *testing.T) {
+	t.Run("pending order", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+
+		err := o.Cancel("abandoned", "kiosk")
+		require.NoError(t, err)
+		require.Equal(t, OrderStatusCancelled.String(), o.Status)
+		require.Equal(t, "abandoned", o.CancelReason)
+		require.Equal(t, "kiosk", o.CancelledBy)
+		require.NotNil(t, o.CancelledAt)
+		require.False(t, o.RefundPending)
+	})
+
+	t.Run("paid order", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+		o.Status = OrderStatusConfirmed.String()
+
+		err := o.Cancel("out of stock", "cashier")
+		require.NoError(t, err)
+		require.True(t, o.RefundPending)
+	})
+
+	t.Run("order with a charge being settled", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+		o.Status = OrderStatusProcessing.String()
+		o.PaymentRef = "ch_1"
+
+		err := o.Cancel("abandoned", "kiosk")
+		require.EqualError(t, err, "invalid order transition from processing to cancelled")
+		require.Equal(t, OrderStatusProcessing.String(), o.Status)
+		require.Nil(t, o.CancelledAt)
+	})
+
+	t.Run("cancelled order", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+		o.Status = OrderStatusCancelled.String()
+
+		err := o.Cancel("abandoned", "kiosk")
+		require.EqualError(t, err, "invalid order transition from cancelled to cancelled")
+	})
+}
+
+func TestOrder_Refund(t *testing.T) {
+	t.Run("partial refund of a cancelled order", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+		o.Status = OrderStatusConfirmed.String()
+		require.NoError(t, o.Cancel("out of stock", "cashier"))
+
+		require.NoError(t, o.Refund(NewMoney(1000), NewMoney(2550)))
+		require.Equal(t, OrderStatusPartiallyRefunded.String(), o.Status)
+		require.True(t, o.RefundPending)
+
+		require.NoError(t, o.Refund(NewMoney(2550), NewMoney(2550)))
+		require.Equal(t, OrderStatusRefunded.String(), o.Status)
+		require.False(t, o.RefundPending)
+	})
+
+	t.Run("order the kitchen cannot fulfil", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+		o.Status = OrderStatusStarted.String()
+
+		require.NoError(t, o.Refund(NewMoney(2550), NewMoney(2550)))
+		require.Equal(t, OrderStatusRefunded.String(), o.Status)
+	})
+
+	t.Run("unpaid order", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+
+		err := o.Refund(NewMoney(2550), NewMoney(2550))
+		require.EqualError(t, err, "invalid order transition from pending to refunded")
+	})
+
+	t.Run("refunded order", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+		o.Status = OrderStatusRefunded.String()
+
+		err := o.Refund(NewMoney(2550), NewMoney(2550))
+		require.EqualError(t, err, "invalid order transition from refunded to refunded")
+	})
+}
+
+func TestOrder_Deliver(t *testing.T) {
+	t.Run("order still being prepared", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+		o.Status = OrderStatusStarted.String()
+
+		err := o.Deliver()
+		require.EqualError(t, err, "invalid order transition from started to delivered")
+		require.Nil(t, o.DeliveredAt)
+	})
+
+	t.Run("delivered order", func(t *testing.T) {
+		o := NewOrderWithCustomer(NewID())
+		o.Status = OrderStatusDelivered.String()
+
+		err := o.Deliver()
+		require.EqualError(t, err, "invalid order transition from delivered to delivered")
+	})
+}
+
+func TestOrder_CheckEditable(t *testing.T) {
+	o := NewOrderWithCustomer(NewID())
+	require.NoError(t, o.CheckEditable())
+
+	require.NoError(t, o.Pay(""))
+	require.ErrorIs(t, o.CheckEditable(), ErrorOrderNotEditable)
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// Types of the order events published to other systems.
+const (
+	OutboxOrderCreated   = "OrderCreated"
+	OutboxOrderPaid      = "OrderPaid"
+	OutboxOrderStarted   = "OrderStarted"
+	OutboxOrderReady     = "OrderReady"
+	OutboxOrderCancelled = "OrderCancelled"
+)
+
+// outboxRetryDelay and outboxMaxRetryDelay bound how long a message waits
+// before being published again, doubling after every failed attempt.
+const (
+	outboxRetryDelay    = time.Second
+	outboxMaxRetryDelay = 5 * time.Minute
+)
+
+// outboxMaxErrorLength is how many characters of the last error are kept, the
+// size of the LastError column.
+const outboxMaxErrorLength = 500
+
+// OutboxMessage is an event waiting to be published to other systems, written
+// along with the change it tells about, so it is published if, and only if,
+// the change was made. Messages of an order are published in the order they
+// were written.
+type OutboxMessage struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement"`
+	AggregateID   ID        `gorm:"size:36;not null;index"`
+	Type          string    `gorm:"size:50;not null"`
+	Payload       string    `gorm:"type:jsonb;not null"`
+	CreatedAt     time.Time `gorm:"not null"`
+	Attempts      uint32    `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null"`
+	LastError     string    `gorm:"size:500"`
+	PublishedAt   *time.Time
+}
+
+func NewOutboxMessage(aggregateID ID, messageType string, payload string) *OutboxMessage {
+	now := time.Now()
+	return &OutboxMessage{
+		AggregateID:   aggregateID,
+		Type:          messageType,
+		Payload:       payload,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+}
+
+// OutboxOrderStatusType returns the type of the event published when an order
+// moves to status, if any.
+func OutboxOrderStatusType(status string) (string, bool) {
+	switch status {
+	case OrderStatusConfirmed.String():
+		return OutboxOrderPaid, true
+	case OrderStatusStarted.String():
+		return OutboxOrderStarted, true
+	case OrderStatusDone.String():
+		return OutboxOrderReady, true
+	case OrderStatusCancelled.String():
+		return OutboxOrderCancelled, true
+	}
+	return "", false
+}
+
+// IsDue reports whether the message should be published by now.
+func (m *OutboxMessage) IsDue(now time.Time) bool {
+	return m.PublishedAt == nil && !m.NextAttemptAt.After(now)
+}
+
+// Publish records that the message was published.
+func (m *OutboxMessage) Publish(now time.Time) {
+	m.Attempts++
+	m.PublishedAt = &now
+	m.LastError = ""
+}
+
+// Fail records a failed attempt to publish the message, which is tried again
+// later, waiting longer after every failure.
+func (m *OutboxMessage) Fail(reason string, now time.Time) {
+	delay := outboxMaxRetryDelay
+	// beyond 16 doublings the delay is way past the maximum, and may overflow
+	if m.Attempts < 16 {
+		delay = min(outboxRetryDelay<<m.Attempts, outboxMaxRetryDelay)
+	}
+
+	m.Attempts++
+	m.NextAttemptAt = now.Add(delay)
+	// cut between characters, never in the middle of one
+	if utf8.RuneCountInString(reason) > outboxMaxErrorLength {
+		reason = string([]rune(reason)[:outboxMaxErrorLength])
+	}
+	m.LastError = reason
+}
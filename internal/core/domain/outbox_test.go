@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxMessage_Fail(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := NewOutboxMessage(NewID(), OutboxOrderPaid, `{}`)
+	require.True(t, m.IsDue(m.CreatedAt))
+
+	m.Fail("unavailable", now)
+	require.Equal(t, now.Add(time.Second), m.NextAttemptAt)
+	require.False(t, m.IsDue(now))
+
+	m.Fail("unavailable", now)
+	require.Equal(t, now.Add(2*time.Second), m.NextAttemptAt)
+	require.Equal(t, uint32(2), m.Attempts)
+	require.Equal(t, "unavailable", m.LastError)
+
+	// the wait stops growing
+	m.Attempts = 40
+	m.Fail("unavailable", now)
+	require.Equal(t, now.Add(5*time.Minute), m.NextAttemptAt)
+
+	m.Publish(now)
+	require.False(t, m.IsDue(now.Add(time.Hour)))
+	require.Empty(t, m.LastError)
+
+	// such as the message of a provider in Portuguese
+	m.Fail(strings.Repeat("não", 200), now)
+	require.True(t, utf8.ValidString(m.LastError))
+	require.Equal(t, outboxMaxErrorLength, utf8.RuneCountInString(m.LastError))
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+)
+
+// PaymentMethod is how the customer pays for an order.
+type PaymentMethod string
+
+const (
+	PaymentMethodCard PaymentMethod = "card"
+	PaymentMethodPix  PaymentMethod = "pix"
+)
+
+// ParsePaymentMethod returns the PaymentMethod represented by its string form.
+func ParsePaymentMethod(s string) (PaymentMethod, error) {
+	switch m := PaymentMethod(s); m {
+	case PaymentMethodCard, PaymentMethodPix:
+		return m, nil
+	}
+	return "", ErrorPaymentUnknownMethod
+}
+
+// Payment is an attempt to pay for an order: a single charge at the payment
+// provider, with the number of times creating it was tried.
+type Payment struct {
+	ID            ID            `gorm:"size:36"`
+	OrderID       ID            `gorm:"size:36;not null;index"`
+	Amount        Money         `gorm:"embedded;embeddedPrefix:amount_"`
+	Method        PaymentMethod `gorm:"size:20;not null"`
+	ProviderRef   string        `gorm:"size:100;index"`
+	Status        string        `gorm:"size:20;not null"`
+	Attempts      uint16        `gorm:"not null;default:0"`
+	FailureReason string        `gorm:"size:500"`
+	PixCode       string        `gorm:"size:512"`
+	CreatedAt     time.Time     `gorm:"autoCreateTime;not null"`
+	UpdatedAt     *time.Time    `gorm:"autoUpdateTime"`
+	ExpiresAt     *time.Time
+	SettledAt     *time.Time
+}
+
+func NewPayment(orderID ID, amount Money, method PaymentMethod) *Payment {
+	return &Payment{
+		ID:        NewID(),
+		OrderID:   orderID,
+		Amount:    amount,
+		Method:    method,
+		Status:    PaymentStatusPending.String(),
+		CreatedAt: time.Now(),
+	}
+}
+
+// Charged records the charge created at the payment provider.
+func (p *Payment) Charged(charge *Charge) {
+	p.ProviderRef = charge.Reference
+	p.PixCode = charge.PixCode
+	p.ExpiresAt = charge.ExpiresAt
+	p.FailureReason = ""
+}
+
+// Settle records the final status of the charge, once the payment provider
+// approved, declined or failed to create it.
+func (p *Payment) Settle(status PaymentStatus, reason string) error {
+	if p.Status != PaymentStatusPending.String() {
+		return ErrorPaymentAlreadySettled
+	}
+
+	settledAt := time.Now()
+	p.Status = status.String()
+	p.FailureReason = reason
+	p.SettledAt = &settledAt
+	return nil
+}
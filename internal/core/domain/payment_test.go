@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayment_NewPayment(t *testing.T) {
+	orderID := NewID()
+	p := NewPayment(orderID, NewMoney(2550), PaymentMethodCard)
+
+	require.NotEmpty(t, p.ID)
+	require.Equal(t, orderID, p.OrderID)
+	require.Equal(t, NewMoney(2550), p.Amount)
+	require.Equal(t, PaymentMethodCard, p.Method)
+	require.Equal(t, PaymentStatusPending.String(), p.Status)
+	require.Zero(t, p.Attempts)
+}
+
+func TestPayment_Settle(t *testing.T) {
+	t.Run("pending payment", func(t *testing.T) {
+		p := NewPayment(NewID(), NewMoney(2550), PaymentMethodCard)
+		p.Charged(&Charge{Reference: "ch_1"})
+		require.Equal(t, "ch_1", p.ProviderRef)
+
+		err := p.Settle(PaymentStatusDeclined, "insufficient funds")
+		require.NoError(t, err)
+		require.Equal(t, PaymentStatusDeclined.String(), p.Status)
+		require.Equal(t, "insufficient funds", p.FailureReason)
+		require.NotNil(t, p.SettledAt)
+	})
+
+	t.Run("settled payment", func(t *testing.T) {
+		p := NewPayment(NewID(), NewMoney(2550), PaymentMethodCard)
+		require.NoError(t, p.Settle(PaymentStatusApproved, ""))
+
+		err := p.Settle(PaymentStatusDeclined, "")
+		require.ErrorIs(t, err, ErrorPaymentAlreadySettled)
+		require.Equal(t, PaymentStatusApproved.String(), p.Status)
+	})
+}
+
+func TestPayment_ParsePaymentMethod(t *testing.T) {
+	method, err := ParsePaymentMethod("pix")
+	require.NoError(t, err)
+	require.Equal(t, PaymentMethodPix, method)
+
+	_, err = ParsePaymentMethod("cash")
+	require.ErrorIs(t, err, ErrorPaymentUnknownMethod)
+}
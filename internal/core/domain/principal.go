@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"context"
+)
+
+type principalContextKey struct{}
+
+// Principal is who a request was authenticated as: a customer, a guest, or a
+// staff user.
+type Principal struct {
+	CustomerID ID
+	GuestID    ID
+	StaffID    ID
+	// staff users only
+	Username string
+	Role     Role
+}
+
+// NewCustomerPrincipal returns the principal of a customer.
+func NewCustomerPrincipal(customerId ID) *Principal {
+	return &Principal{CustomerID: customerId, Role: RoleCustomer}
+}
+
+// NewGuestPrincipal returns the principal of a guest.
+func NewGuestPrincipal(guestId ID) *Principal {
+	return &Principal{GuestID: guestId, Role: RoleGuest}
+}
+
+// NewStaffPrincipal returns the principal of a staff user.
+func NewStaffPrincipal(u *StaffUser) *Principal {
+	return &Principal{StaffID: u.ID, Username: u.Username, Role: u.Role}
+}
+
+// NewSystemPrincipal returns the principal of the app itself, for the work it
+// does on its own, such as the background jobs.
+func NewSystemPrincipal() *Principal {
+	return &Principal{Role: RoleSystem}
+}
+
+// String names the principal as an actor of the changes it makes.
+func (p *Principal) String() string {
+	if p.Role == RoleSystem {
+		return string(RoleSystem)
+	}
+	if p.Role.IsStaff() {
+		return "staff:" + p.Username
+	}
+	if p.Role == RoleGuest {
+		return "guest:" + p.GuestID.String()
+	}
+	return "customer:" + p.CustomerID.String()
+}
+
+// WithPrincipal returns a copy of ctx carrying who the request was
+// authenticated as.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+// WithSystemPrincipal returns a copy of ctx carrying the system as both the
+// principal and the actor of the changes made.
+func WithSystemPrincipal(ctx context.Context) context.Context {
+	p := NewSystemPrincipal()
+	return WithActor(WithPrincipal(ctx, p), p.String())
+}
+
+// PrincipalFromContext returns who the request was authenticated as, or nil
+// when it was not authenticated.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalContextKey{}).(*Principal)
+	return p
+}
+
+// CheckCustomer returns ErrorForbidden when ctx carries a customer other than
+// customerId, and ErrorUnauthorized when it carries no principal. Staff users
+// and the system may act on behalf of any customer, as far as their
+// permissions allow, see CheckPermission.
+func CheckCustomer(ctx context.Context, customerId ID) error {
+	p := PrincipalFromContext(ctx)
+	if p == nil {
+		return ErrorUnauthorized
+	}
+	if p.Role.actsForAnyone() || (p.Role == RoleCustomer && p.CustomerID == customerId) {
+		return nil
+	}
+	return ErrorForbidden
+}
+
+// CheckGuest returns ErrorForbidden when ctx carries a customer, or a guest
+// other than guestId, and ErrorUnauthorized when it carries no principal.
+func CheckGuest(ctx context.Context, guestId ID) error {
+	p := PrincipalFromContext(ctx)
+	if p == nil {
+		return ErrorUnauthorized
+	}
+	if p.Role.actsForAnyone() || (p.Role == RoleGuest && p.GuestID == guestId) {
+		return nil
+	}
+	return ErrorForbidden
+}
+
+// CheckOrder returns ErrorForbidden when ctx carries someone who may not act
+// on the order: customers reach their own orders and guests the orders they
+// placed, even once attached to a customer. Without a principal it returns
+// ErrorUnauthorized.
+func CheckOrder(ctx context.Context, o *Order) error {
+	p := PrincipalFromContext(ctx)
+	if p == nil {
+		return ErrorUnauthorized
+	}
+	if p.Role.actsForAnyone() {
+		return nil
+	}
+
+	if p.Role == RoleGuest {
+		if o.GuestID != nil && *o.GuestID == p.GuestID {
+			return nil
+		}
+		return ErrorForbidden
+	}
+
+	if o.CustomerID != nil && *o.CustomerID == p.CustomerID {
+		return nil
+	}
+	return ErrorForbidden
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSystemPrincipal(t *testing.T) {
+	ctx := WithSystemPrincipal(context.Background())
+
+	require.Equal(t, RoleSystem, PrincipalFromContext(ctx).Role)
+	require.Equal(t, "system", ActorFromContext(ctx))
+}
+
+func TestCheckCustomer(t *testing.T) {
+	ctx := context.Background()
+	customerId := NewID()
+
+	testCases := []struct {
+		title     string
+		principal *Principal
+		err       error
+	}{
+		{title: "Not authenticated", principal: nil, err: ErrorUnauthorized},
+		{title: "System", principal: NewSystemPrincipal()},
+		{title: "Staff", principal: &Principal{Role: RoleCashier}},
+		{title: "Same customer", principal: NewCustomerPrincipal(customerId)},
+		{title: "Other customer", principal: NewCustomerPrincipal(NewID()), err: ErrorForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctx := ctx
+			if tc.principal != nil {
+				ctx = WithPrincipal(ctx, tc.principal)
+			}
+			require.ErrorIs(t, CheckCustomer(ctx, customerId), tc.err)
+		})
+	}
+}
+
+func TestCheckGuest(t *testing.T) {
+	guestId := NewID()
+
+	testCases := []struct {
+		title     string
+		principal *Principal
+		err       error
+	}{
+		{title: "Not authenticated", principal: nil, err: ErrorUnauthorized},
+		{title: "System", principal: NewSystemPrincipal()},
+		{title: "Same guest", principal: NewGuestPrincipal(guestId)},
+		{title: "Other guest", principal: NewGuestPrincipal(NewID()), err: ErrorForbidden},
+		{title: "Customer", principal: NewCustomerPrincipal(NewID()), err: ErrorForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.principal != nil {
+				ctx = WithPrincipal(ctx, tc.principal)
+			}
+			require.ErrorIs(t, CheckGuest(ctx, guestId), tc.err)
+		})
+	}
+}
+
+func TestCheckOrder(t *testing.T) {
+	customerId := NewID()
+	o := NewOrderWithCustomer(customerId)
+
+	testCases := []struct {
+		title     string
+		principal *Principal
+		err       error
+	}{
+		{title: "Not authenticated", principal: nil, err: ErrorUnauthorized},
+		{title: "System", principal: NewSystemPrincipal()},
+		{title: "Staff", principal: &Principal{Role: RoleKitchen}},
+		{title: "Customer of the order", principal: NewCustomerPrincipal(customerId)},
+		{title: "Other customer", principal: NewCustomerPrincipal(NewID()), err: ErrorForbidden},
+		{title: "Guest", principal: NewGuestPrincipal(NewID()), err: ErrorForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.principal != nil {
+				ctx = WithPrincipal(ctx, tc.principal)
+			}
+			require.ErrorIs(t, CheckOrder(ctx, o), tc.err)
+		})
+	}
+}
@@ -5,24 +5,25 @@ import (
 )
 
 type Product struct {
-	ID          ID      `gorm:"size:36"`
-	Name        string  `gorm:"size:60;not null"`
-	Description string  `gorm:"size:100"`
-	Price       float64 `gorm:"not null"`
-	CategoryID  ID      `gorm:"size:36;not null"`
+	ID          ID     `gorm:"size:36"`
+	Name        string `gorm:"size:60;not null"`
+	Description string `gorm:"size:100"`
+	Price       Money  `gorm:"embedded;embeddedPrefix:price_"`
+	CategoryID  ID     `gorm:"size:36;not null"`
 	Category    *Category
 	CreatedAt   time.Time  `gorm:"autoCreateTime;not null"`
 	UpdatedAt   *time.Time `gorm:"autoUpdateTime"`
 	DeletedAt   *time.Time
+	Version     uint64 `gorm:"not null;default:1"`
 }
 
-func NewProductWithID(id ID, name string, description string, price float64, categoryID ID) *Product {
+func NewProductWithID(id ID, name string, description string, price Money, categoryID ID) *Product {
 	product := NewProduct(name, description, price, categoryID)
 	product.ID = id
 	return product
 }
 
-func NewProduct(name string, description string, price float64, categoryID ID) *Product {
+func NewProduct(name string, description string, price Money, categoryID ID) *Product {
 	return &Product{
 		ID:          NewID(),
 		Name:        name,
@@ -36,7 +37,7 @@ func (p *Product) IsActive() bool {
 	return p.DeletedAt.IsZero()
 }
 
-func (p *Product) GetPrice() float64 {
+func (p *Product) GetPrice() Money {
 	return p.Price
 }
 
@@ -10,22 +10,22 @@ import (
 
 func TestProduct_NewProductWithID(t *testing.T) {
 	id := NewID()
-	p := NewProductWithID(id, "product", "description", 10.5, NewID())
+	p := NewProductWithID(id, "product", "description", NewMoney(1050), NewID())
 	require.Equal(t, id, p.ID)
 	require.Equal(t, "product", p.Name)
 	require.Equal(t, "description", p.Description)
-	require.Equal(t, 10.5, p.Price)
+	require.Equal(t, NewMoney(1050), p.Price)
 	require.False(t, p.CreatedAt.IsZero())
 	require.False(t, p.UpdatedAt.IsZero())
 	require.True(t, p.DeletedAt.IsZero())
 }
 
 func TestProduct_NewProduct(t *testing.T) {
-	p := NewProduct("product", "description", 10.5, NewID())
+	p := NewProduct("product", "description", NewMoney(1050), NewID())
 	require.NotEmpty(t, p.ID)
 	require.Equal(t, "product", p.Name)
 	require.Equal(t, "description", p.Description)
-	require.Equal(t, 10.5, p.Price)
+	require.Equal(t, NewMoney(1050), p.Price)
 	require.False(t, p.CreatedAt.IsZero())
 	require.False(t, p.UpdatedAt.IsZero())
 	require.True(t, p.DeletedAt.IsZero())
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+)
+
+// Statuses of a refund: pending until the payment provider gives the money
+// back, and failed when it refused to.
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusCompleted = "completed"
+	RefundStatusFailed    = "failed"
+)
+
+// Refund is money given back to the customer out of an order payment. The
+// refunds of an order are its refund ledger. Refunds are recorded as pending
+// before the money is given back, see IdempotencyKey.
+type Refund struct {
+	ID          ID        `gorm:"size:36"`
+	OrderID     ID        `gorm:"size:36;not null;index"`
+	PaymentID   ID        `gorm:"size:36;not null"`
+	Amount      Money     `gorm:"embedded;embeddedPrefix:amount_"`
+	ProviderRef string    `gorm:"size:100"`
+	Status      string    `gorm:"size:20;not null;default:completed;index"`
+	CreatedBy   string    `gorm:"size:100"`
+	CreatedAt   time.Time `gorm:"autoCreateTime;not null"`
+}
+
+func NewRefund(orderID ID, paymentID ID, amount Money, createdBy string) *Refund {
+	return &Refund{
+		ID:        NewID(),
+		OrderID:   orderID,
+		PaymentID: paymentID,
+		Amount:    amount,
+		Status:    RefundStatusPending,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IdempotencyKey identifies the refund at the payment provider, so the money
+// is given back once however many times the refund is asked for.
+func (r *Refund) IdempotencyKey() string {
+	return r.ID.String()
+}
+
+// Complete records that the payment provider gave the money back.
+func (r *Refund) Complete(providerRef string) {
+	r.ProviderRef = providerRef
+	r.Status = RefundStatusCompleted
+}
+
+// Fail records that the payment provider refused to give the money back.
+func (r *Refund) Fail() {
+	r.Status = RefundStatusFailed
+}
+
+// RefundedTotal returns how much was, or is being, given back by the refunds.
+func RefundedTotal(refunds []*Refund) Money {
+	total := NewMoney(0)
+	for _, r := range refunds {
+		if r.Status != RefundStatusFailed {
+			total = total.Add(r.Amount)
+		}
+	}
+	return total
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"slices"
+)
+
+// Role is what a principal is allowed to do, customers and guests only acting
+// on their own data and staff users acting on behalf of the store.
+type Role string
+
+const (
+	RoleCustomer Role = "customer"
+	// someone ordering at the kiosk without signing up
+	RoleGuest   Role = "guest"
+	RoleCashier Role = "cashier"
+	RoleKitchen Role = "kitchen"
+	RoleManager Role = "manager"
+	RoleAdmin   Role = "admin"
+	// the app itself, such as the background jobs and the payment provider
+	// notifications, never given to a token
+	RoleSystem Role = "system"
+)
+
+// ParseRole returns the Role represented by its string form.
+func ParseRole(s string) (Role, error) {
+	role := Role(s)
+	if _, ok := rolePermissions[role]; !ok {
+		return "", ErrorRoleUnknown
+	}
+	return role, nil
+}
+
+// IsStaff reports whether the role is one of the store staff.
+func (r Role) IsStaff() bool {
+	_, ok := rolePermissions[r]
+	return ok && r != RoleCustomer && r != RoleGuest
+}
+
+// Can reports whether the role is allowed to perform operations needing the
+// permission. Admins and the system can do everything.
+func (r Role) Can(p Permission) bool {
+	return r == RoleAdmin || r == RoleSystem || slices.Contains(rolePermissions[r], p)
+}
+
+// actsForAnyone reports whether the role acts on behalf of any customer or
+// guest, as far as its permissions allow.
+func (r Role) actsForAnyone() bool {
+	return r.IsStaff() || r == RoleSystem
+}
+
+// Permission is a kind of operation only some roles are allowed to perform.
+type Permission string
+
+const (
+	// create, change and remove products and categories
+	PermissionManageCatalog Permission = "catalog:manage"
+	// see every order, as the kitchen and the counter do
+	PermissionViewOrders Permission = "orders:view"
+	// start and complete the preparation of orders
+	PermissionPrepareOrders Permission = "orders:prepare"
+	// hand orders over to customers
+	PermissionDeliverOrders Permission = "orders:deliver"
+	// refund orders and fix their totals
+	PermissionManageOrders Permission = "orders:manage"
+	// see the reports and consistency checks of the orders
+	PermissionViewReports Permission = "reports:view"
+	// create and remove staff users
+	PermissionManageStaff Permission = "staff:manage"
+)
+
+// rolePermissions is the policy: for each role, the permissions it has.
+var rolePermissions = map[Role][]Permission{
+	RoleCustomer: {},
+	RoleGuest:    {},
+	RoleCashier:  {PermissionViewOrders, PermissionDeliverOrders},
+	RoleKitchen:  {PermissionViewOrders, PermissionPrepareOrders},
+	RoleManager: {
+		PermissionManageCatalog, PermissionViewOrders, PermissionPrepareOrders,
+		PermissionDeliverOrders, PermissionManageOrders, PermissionViewReports,
+	},
+	RoleAdmin: {},
+}
+
+// CheckPermission returns ErrorForbidden when the principal in ctx does not
+// have the permission, and ErrorUnauthorized when ctx carries no principal.
+// The background jobs act as the system, see NewSystemPrincipal.
+func CheckPermission(ctx context.Context, p Permission) error {
+	principal := PrincipalFromContext(ctx)
+	if principal == nil {
+		return ErrorUnauthorized
+	}
+	if principal.Role.Can(p) {
+		return nil
+	}
+	return ErrorForbidden
+}
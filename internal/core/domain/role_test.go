@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole("kitchen")
+	require.NoError(t, err)
+	require.Equal(t, RoleKitchen, role)
+
+	_, err = ParseRole("kiosk")
+	require.ErrorIs(t, err, ErrorRoleUnknown)
+
+	// the system is never given to a staff user
+	_, err = ParseRole("system")
+	require.ErrorIs(t, err, ErrorRoleUnknown)
+}
+
+func TestRole_IsStaff(t *testing.T) {
+	require.False(t, RoleCustomer.IsStaff())
+	require.True(t, RoleCashier.IsStaff())
+	require.True(t, RoleAdmin.IsStaff())
+	require.False(t, Role("").IsStaff())
+	require.False(t, RoleSystem.IsStaff())
+}
+
+func TestCheckPermission(t *testing.T) {
+	testCases := []struct {
+		title      string
+		principal  *Principal
+		permission Permission
+		err        error
+	}{
+		{title: "Not authenticated", principal: nil, permission: PermissionPrepareOrders, err: ErrorUnauthorized},
+		{title: "System", principal: NewSystemPrincipal(), permission: PermissionManageStaff},
+		{title: "Customer", principal: NewCustomerPrincipal(NewID()), permission: PermissionPrepareOrders, err: ErrorForbidden},
+		{title: "Kitchen preparing", principal: &Principal{Role: RoleKitchen}, permission: PermissionPrepareOrders},
+		{title: "Kitchen delivering", principal: &Principal{Role: RoleKitchen}, permission: PermissionDeliverOrders, err: ErrorForbidden},
+		{title: "Cashier delivering", principal: &Principal{Role: RoleCashier}, permission: PermissionDeliverOrders},
+		{title: "Manager managing staff", principal: &Principal{Role: RoleManager}, permission: PermissionManageStaff, err: ErrorForbidden},
+		{title: "Admin managing staff", principal: &Principal{Role: RoleAdmin}, permission: PermissionManageStaff},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.principal != nil {
+				ctx = WithPrincipal(ctx, tc.principal)
+			}
+			require.ErrorIs(t, CheckPermission(ctx, tc.permission), tc.err)
+		})
+	}
+}
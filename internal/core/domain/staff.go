@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// StaffUser is an account of someone working at the store, such as a cashier
+// or a cook, allowed to perform the operations of its role.
+type StaffUser struct {
+	ID        ID         `gorm:"size:36"`
+	Username  string     `gorm:"size:100;unique;not null"`
+	Name      string     `gorm:"size:200;not null"`
+	Password  string     `gorm:"size:64;not null"`
+	Role      Role       `gorm:"size:20;not null"`
+	CreatedAt time.Time  `gorm:"autoCreateTime;not null"`
+	UpdatedAt *time.Time `gorm:"autoUpdateTime"`
+	DeletedAt *time.Time
+	Version   uint64 `gorm:"not null;default:1"`
+}
+
+// NewStaffUser returns a staff user with a hashed password, as long as role is
+// one of the staff roles.
+func NewStaffUser(username string, name string, password string, role Role) (*StaffUser, error) {
+	if !role.IsStaff() {
+		return nil, ErrorRoleUnknown
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StaffUser{
+		ID:       NewID(),
+		Username: username,
+		Name:     name,
+		Password: string(hash),
+		Role:     role,
+	}, nil
+}
+
+func (u *StaffUser) Authenticate(password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+)
+
+// OrderStatusEvent tells an order moved to a new status. Its ID is assigned
+// when published, increasing with every event, so clients can resume from
+// the last event they received.
+//
+// Reset events are about no order. They tell the events after the last one
+// received are no longer retained, so clients should reload the orders they
+// follow instead of waiting for the events they missed.
+type OrderStatusEvent struct {
+	ID             uint64
+	OrderID        ID
+	Status         string
+	TrackingNumber *uint16
+	OccurredAt     time.Time
+	Reset          bool
+}
+
+func NewOrderStatusEvent(o *Order) *OrderStatusEvent {
+	return &OrderStatusEvent{
+		OrderID:        o.ID,
+		Status:         o.Status,
+		TrackingNumber: o.TrackingNumber,
+		OccurredAt:     time.Now(),
+	}
+}
+
+func NewOrderStatusResetEvent(id uint64) *OrderStatusEvent {
+	return &OrderStatusEvent{
+		ID:         id,
+		OccurredAt: time.Now(),
+		Reset:      true,
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+)
+
+// NextStoreOpening returns the first time after now the store opens, opensAt
+// past midnight in the time zone of now.
+func NextStoreOpening(now time.Time, opensAt time.Duration) time.Time {
+	year, month, day := now.Date()
+	hour, minute := int(opensAt/time.Hour), int(opensAt%time.Hour/time.Minute)
+
+	next := time.Date(year, month, day, hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = time.Date(year, month, day+1, hour, minute, 0, 0, now.Location())
+	}
+	return next
+}
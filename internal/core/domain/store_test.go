@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_NextStoreOpening(t *testing.T) {
+	opensAt := 6*time.Hour + 30*time.Minute
+
+	testCases := []struct {
+		title string
+		now   time.Time
+		next  time.Time
+	}{
+		{
+			title: "Before opening",
+			now:   time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC),
+			next:  time.Date(2024, 1, 1, 6, 30, 0, 0, time.UTC),
+		},
+		{
+			title: "At opening",
+			now:   time.Date(2024, 1, 1, 6, 30, 0, 0, time.UTC),
+			next:  time.Date(2024, 1, 2, 6, 30, 0, 0, time.UTC),
+		},
+		{
+			title: "After opening, at the end of the month",
+			now:   time.Date(2024, 1, 31, 22, 0, 0, 0, time.UTC),
+			next:  time.Date(2024, 2, 1, 6, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			require.Equal(t, tc.next, NextStoreOpening(tc.now, opensAt))
+		})
+	}
+}
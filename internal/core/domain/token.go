@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+)
+
+type TokenType string
+
+const (
+	// short lived, sent along with every request
+	TokenTypeAccess TokenType = "access"
+	// long lived, only exchanged for a new pair of tokens
+	TokenTypeRefresh TokenType = "refresh"
+)
+
+// Token is a credential issued to a customer or a staff user once
+// authenticated, before being signed.
+type Token struct {
+	ID        ID
+	Type      TokenType
+	Principal Principal
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+func NewToken(tokenType TokenType, p *Principal, ttl time.Duration) *Token {
+	now := time.Now()
+	return &Token{
+		ID:        NewID(),
+		Type:      tokenType,
+		Principal: *p,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+// TokenPair is a signed access token along with the refresh token to get the
+// next pair once it expires.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// RevokedToken is a token no longer accepted although not expired yet. It can
+// be forgotten once expired.
+type RevokedToken struct {
+	ID        ID        `gorm:"size:36;primaryKey"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	RevokedAt time.Time `gorm:"not null"`
+}
+
+func NewRevokedToken(t *Token) *RevokedToken {
+	return &RevokedToken{
+		ID:        t.ID,
+		ExpiresAt: t.ExpiresAt,
+		RevokedAt: time.Now(),
+	}
+}
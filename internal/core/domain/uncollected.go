@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+)
+
+// UncollectedOrder is a done order still waiting at the counter for the
+// customer to collect it.
+type UncollectedOrder struct {
+	OrderID        ID
+	TrackingNumber *uint16
+	ReadyAt        time.Time
+}
+
+// Waiting returns how long the order has been waiting at the counter.
+func (o *UncollectedOrder) Waiting(now time.Time) time.Duration {
+	return now.Sub(o.ReadyAt)
+}
+
+// UncollectedReport tells how long orders sit at the counter: the orders
+// waiting right now, longest waiting first, and how long the orders delivered
+// since a given time waited before being collected.
+type UncollectedReport struct {
+	Waiting     []*UncollectedOrder
+	Since       time.Time
+	Delivered   int64
+	AverageWait time.Duration
+	MaxWait     time.Duration
+}
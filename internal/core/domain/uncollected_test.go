@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUncollectedOrder_Waiting(t *testing.T) {
+	readyAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	o := UncollectedOrder{ReadyAt: readyAt}
+
+	require.Equal(t, 3*time.Minute, o.Waiting(readyAt.Add(3*time.Minute)))
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+)
+
+type expectedVersionContextKey struct{}
+
+// WithExpectedVersion returns a copy of ctx carrying the version of an entity
+// the caller expects to change, as read by the caller beforehand.
+func WithExpectedVersion(ctx context.Context, version uint64) context.Context {
+	return context.WithValue(ctx, expectedVersionContextKey{}, version)
+}
+
+// CheckVersion returns ErrorPreconditionFailed when the caller expects the
+// entity to be at another version than current. Callers that do not state a
+// version always pass.
+func CheckVersion(ctx context.Context, current uint64) error {
+	expected, ok := ctx.Value(expectedVersionContextKey{}).(uint64)
+	if ok && expected != current {
+		return ErrorPreconditionFailed
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package port
+
+import (
+	"context"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// TokenSigner is an interface that wraps the signing of the tokens issued to
+// customers, guests and staff users, such as JWTs.
+type TokenSigner interface {
+	Sign(t *domain.Token) (string, error)
+
+	// the token signed by Sign, or domain.ErrorUnauthorized when it was not
+	// signed by Sign or is expired
+	Parse(signed string) (*domain.Token, error)
+}
+
+// RevokedTokenRepository is an interface that wraps the operations for the
+// tokens no longer accepted before they expire.
+type RevokedTokenRepository interface {
+	// revoke a token, reporting false when it was revoked already
+	Revoke(ctx context.Context, t *domain.RevokedToken) (bool, error)
+	IsRevoked(ctx context.Context, id domain.ID) (bool, error)
+}
+
+// AuthService is an interface that wraps the lifecycle of the tokens issued to
+// customers, guests and staff users.
+type AuthService interface {
+	// a new pair of tokens for an authenticated customer or staff user, or a
+	// guest
+	Issue(ctx context.Context, p *domain.Principal) (*domain.TokenPair, error)
+
+	// exchange a refresh token for a new pair, the refresh token can only be
+	// used once
+	Refresh(ctx context.Context, refreshToken string) (*domain.TokenPair, error)
+	Revoke(ctx context.Context, token string) error
+
+	// who holds a valid access token
+	Verify(ctx context.Context, accessToken string) (*domain.Principal, error)
+}
@@ -8,14 +8,17 @@ import (
 
 // CustomerRepositoryReader is an interface that wraps all the reading operations for a customer.
 type CustomerRepositoryReader interface {
-	FindByID(ctx context.Context, id uint64) (*domain.Customer, error)
-	FindByKeys(ctx context.Context, id uint64, email string) (*domain.Customer, error)
+	FindByID(ctx context.Context, id domain.ID) (*domain.Customer, error)
+	FindByCPF(ctx context.Context, cpf domain.CPF) (*domain.Customer, error)
+	// the customer with either the CPF or the email
+	FindByKeys(ctx context.Context, cpf domain.CPF, email string) (*domain.Customer, error)
 }
 
 // CustomerRepositoryWriter is an interface that wraps all the writing operations for a customer.
 type CustomerRepositoryWriter interface {
 	Create(ctx context.Context, c *domain.Customer) error
-	Patch(ctx context.Context, id uint64, data *domain.Customer) error
+	// update the customer if still at data.Version, see domain.ErrVersionConflict
+	Patch(ctx context.Context, id domain.ID, data *domain.Customer) error
 }
 
 // CustomerRepository is an interface that wraps all the reading and writing operations for a customer.
@@ -24,11 +27,34 @@ type CustomerRepository interface {
 	CustomerRepositoryWriter
 }
 
+// CustomerTokenRepository is an interface that wraps the operations for the
+// password reset and email verification tokens of the customers.
+type CustomerTokenRepository interface {
+	Create(ctx context.Context, t *domain.CustomerToken) error
+	FindByHash(ctx context.Context, hash string) (*domain.CustomerToken, error)
+	// write when the token was used, see domain.CustomerToken.Use, reporting
+	// false when it was used already
+	Use(ctx context.Context, t *domain.CustomerToken) (bool, error)
+}
+
 // CustomerService is an interface that wraps all the operations for a customer.
 type CustomerService interface {
-	GetByID(ctx context.Context, id uint64) (*domain.Customer, error)
+	GetByID(ctx context.Context, id domain.ID) (*domain.Customer, error)
+
+	// the customer identifying at the kiosk
+	GetByCPF(ctx context.Context, cpf domain.CPF) (*domain.Customer, error)
 	Create(ctx context.Context, c *domain.Customer) (*domain.Customer, error)
 	Authenticate(ctx context.Context, c *domain.Customer) (*domain.Customer, error)
 	Update(ctx context.Context, c *domain.Customer) (*domain.Customer, error)
-	Delete(ctx context.Context, id uint64) error
+	Delete(ctx context.Context, id domain.ID) error
+
+	// mail a password reset token to the customer with the email, if any
+	RequestPasswordReset(ctx context.Context, email string) error
+	// set the password of the customer a password reset token was mailed to
+	ResetPassword(ctx context.Context, secret string, password string) error
+
+	// mail an email verification token to the customer
+	RequestEmailVerification(ctx context.Context, id domain.ID) error
+	// verify the email an email verification token was mailed to
+	VerifyEmail(ctx context.Context, secret string) (*domain.Customer, error)
 }
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// OrderEventBus delivers order status events, from the services publishing
+// them to the clients following the orders.
+type OrderEventBus interface {
+	// publish never blocks on slow subscribers
+	Publish(ctx context.Context, event *domain.OrderStatusEvent)
+
+	// events published from now on, after replaying the recent events
+	// published after lastEventID, if any, or a reset event when some of
+	// them are no longer retained. The channel is closed when ctx is
+	// done, or when the subscriber falls too far behind, so it should
+	// subscribe again from the last event it received
+	Subscribe(ctx context.Context, lastEventID uint64) <-chan *domain.OrderStatusEvent
+}
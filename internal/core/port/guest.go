@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// GuestRepository is an interface that wraps the operations for the guest
+// sessions.
+type GuestRepository interface {
+	Create(ctx context.Context, g *domain.GuestSession) error
+	FindByID(ctx context.Context, id domain.ID) (*domain.GuestSession, error)
+	// write the customer the guest was attached to
+	Attach(ctx context.Context, g *domain.GuestSession) error
+}
+
+// GuestService is an interface that wraps the operations for the guests
+// ordering without signing up.
+type GuestService interface {
+	// start a guest session, name is optional
+	Create(ctx context.Context, name string) (*domain.GuestSession, error)
+
+	// attach the guest and its orders to the customer signed in as customer
+	Attach(ctx context.Context, id domain.ID, customer *domain.Principal) (*domain.GuestSession, error)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/vitovidale/TECH-CHALLENGE/internal/core/port (interfaces: CustomerRepository)
+// Source: github.com/vitovidale/fastfood-app/internal/core/port (interfaces: CustomerRepository,CustomerTokenRepository)
 //
 // Generated by this command:
 //
-//	mockgen . CustomerRepository
+//	mockgen -destination mock/customer.go -package mock_port . CustomerRepository,CustomerTokenRepository
 //
 
 // Package mock_port is a generated GoMock package.
@@ -55,22 +55,23 @@ func (mr *MockCustomerRepositoryMockRecorder) Create(ctx, c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomerRepository)(nil).Create), ctx, c)
 }
 
-// Delete mocks base method.
-func (m *MockCustomerRepository) Delete(ctx context.Context, id uint64) error {
+// FindByCPF mocks base method.
+func (m *MockCustomerRepository) FindByCPF(ctx context.Context, cpf domain.CPF) (*domain.Customer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "FindByCPF", ctx, cpf)
+	ret0, _ := ret[0].(*domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockCustomerRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+// FindByCPF indicates an expected call of FindByCPF.
+func (mr *MockCustomerRepositoryMockRecorder) FindByCPF(ctx, cpf any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomerRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCPF", reflect.TypeOf((*MockCustomerRepository)(nil).FindByCPF), ctx, cpf)
 }
 
 // FindByID mocks base method.
-func (m *MockCustomerRepository) FindByID(ctx context.Context, id uint64) (*domain.Customer, error) {
+func (m *MockCustomerRepository) FindByID(ctx context.Context, id domain.ID) (*domain.Customer, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindByID", ctx, id)
 	ret0, _ := ret[0].(*domain.Customer)
@@ -84,16 +85,99 @@ func (mr *MockCustomerRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCustomerRepository)(nil).FindByID), ctx, id)
 }
 
-// Update mocks base method.
-func (m *MockCustomerRepository) Update(ctx context.Context, c *domain.Customer) error {
+// FindByKeys mocks base method.
+func (m *MockCustomerRepository) FindByKeys(ctx context.Context, cpf domain.CPF, email string) (*domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByKeys", ctx, cpf, email)
+	ret0, _ := ret[0].(*domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByKeys indicates an expected call of FindByKeys.
+func (mr *MockCustomerRepositoryMockRecorder) FindByKeys(ctx, cpf, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByKeys", reflect.TypeOf((*MockCustomerRepository)(nil).FindByKeys), ctx, cpf, email)
+}
+
+// Patch mocks base method.
+func (m *MockCustomerRepository) Patch(ctx context.Context, id domain.ID, data *domain.Customer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, c)
+	ret := m.ctrl.Call(m, "Patch", ctx, id, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockCustomerRepositoryMockRecorder) Update(ctx, c any) *gomock.Call {
+// Patch indicates an expected call of Patch.
+func (mr *MockCustomerRepositoryMockRecorder) Patch(ctx, id, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockCustomerRepository)(nil).Patch), ctx, id, data)
+}
+
+// MockCustomerTokenRepository is a mock of CustomerTokenRepository interface.
+type MockCustomerTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerTokenRepositoryMockRecorder is the mock recorder for MockCustomerTokenRepository.
+type MockCustomerTokenRepositoryMockRecorder struct {
+	mock *MockCustomerTokenRepository
+}
+
+// NewMockCustomerTokenRepository creates a new mock instance.
+func NewMockCustomerTokenRepository(ctrl *gomock.Controller) *MockCustomerTokenRepository {
+	mock := &MockCustomerTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerTokenRepository) EXPECT() *MockCustomerTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomerTokenRepository) Create(ctx context.Context, t *domain.CustomerToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomerTokenRepositoryMockRecorder) Create(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomerTokenRepository)(nil).Create), ctx, t)
+}
+
+// FindByHash mocks base method.
+func (m *MockCustomerTokenRepository) FindByHash(ctx context.Context, hash string) (*domain.CustomerToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", ctx, hash)
+	ret0, _ := ret[0].(*domain.CustomerToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockCustomerTokenRepositoryMockRecorder) FindByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockCustomerTokenRepository)(nil).FindByHash), ctx, hash)
+}
+
+// Use mocks base method.
+func (m *MockCustomerTokenRepository) Use(ctx context.Context, t *domain.CustomerToken) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Use", ctx, t)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Use indicates an expected call of Use.
+func (mr *MockCustomerTokenRepositoryMockRecorder) Use(ctx, t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomerRepository)(nil).Update), ctx, c)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Use", reflect.TypeOf((*MockCustomerTokenRepository)(nil).Use), ctx, t)
 }
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vitovidale/fastfood-app/internal/core/port (interfaces: GuestRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination mock/guest.go -package mock_port . GuestRepository
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/vitovidale/fastfood-app/internal/core/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGuestRepository is a mock of GuestRepository interface.
+type MockGuestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuestRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockGuestRepositoryMockRecorder is the mock recorder for MockGuestRepository.
+type MockGuestRepositoryMockRecorder struct {
+	mock *MockGuestRepository
+}
+
+// NewMockGuestRepository creates a new mock instance.
+func NewMockGuestRepository(ctrl *gomock.Controller) *MockGuestRepository {
+	mock := &MockGuestRepository{ctrl: ctrl}
+	mock.recorder = &MockGuestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuestRepository) EXPECT() *MockGuestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockGuestRepository) Attach(ctx context.Context, g *domain.GuestSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockGuestRepositoryMockRecorder) Attach(ctx, g any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockGuestRepository)(nil).Attach), ctx, g)
+}
+
+// Create mocks base method.
+func (m *MockGuestRepository) Create(ctx context.Context, g *domain.GuestSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGuestRepositoryMockRecorder) Create(ctx, g any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGuestRepository)(nil).Create), ctx, g)
+}
+
+// FindByID mocks base method.
+func (m *MockGuestRepository) FindByID(ctx context.Context, id domain.ID) (*domain.GuestSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.GuestSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockGuestRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockGuestRepository)(nil).FindByID), ctx, id)
+}
@@ -0,0 +1,367 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vitovidale/fastfood-app/internal/core/port (interfaces: OrderRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination mock/order.go -package mock_port . OrderRepository
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vitovidale/fastfood-app/internal/core/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderRepository is a mock of OrderRepository interface.
+type MockOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderRepositoryMockRecorder is the mock recorder for MockOrderRepository.
+type MockOrderRepositoryMockRecorder struct {
+	mock *MockOrderRepository
+}
+
+// NewMockOrderRepository creates a new mock instance.
+func NewMockOrderRepository(ctrl *gomock.Controller) *MockOrderRepository {
+	mock := &MockOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddProduct mocks base method.
+func (m *MockOrderRepository) AddProduct(ctx context.Context, p *domain.OrderProduct) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProduct", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProduct indicates an expected call of AddProduct.
+func (mr *MockOrderRepositoryMockRecorder) AddProduct(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockOrderRepository)(nil).AddProduct), ctx, p)
+}
+
+// AttachGuest mocks base method.
+func (m *MockOrderRepository) AttachGuest(ctx context.Context, guestId, customerId domain.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachGuest", ctx, guestId, customerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachGuest indicates an expected call of AttachGuest.
+func (mr *MockOrderRepositoryMockRecorder) AttachGuest(ctx, guestId, customerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachGuest", reflect.TypeOf((*MockOrderRepository)(nil).AttachGuest), ctx, guestId, customerId)
+}
+
+// Delete mocks base method.
+func (m *MockOrderRepository) Delete(ctx context.Context, id domain.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockOrderRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderRepository)(nil).Delete), ctx, id)
+}
+
+// FindByCustomer mocks base method.
+func (m *MockOrderRepository) FindByCustomer(ctx context.Context, customerId domain.ID) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCustomer", ctx, customerId)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCustomer indicates an expected call of FindByCustomer.
+func (mr *MockOrderRepositoryMockRecorder) FindByCustomer(ctx, customerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCustomer", reflect.TypeOf((*MockOrderRepository)(nil).FindByCustomer), ctx, customerId)
+}
+
+// FindByGuest mocks base method.
+func (m *MockOrderRepository) FindByGuest(ctx context.Context, guestId domain.ID) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByGuest", ctx, guestId)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByGuest indicates an expected call of FindByGuest.
+func (mr *MockOrderRepositoryMockRecorder) FindByGuest(ctx, guestId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByGuest", reflect.TypeOf((*MockOrderRepository)(nil).FindByGuest), ctx, guestId)
+}
+
+// FindByID mocks base method.
+func (m *MockOrderRepository) FindByID(ctx context.Context, id domain.ID) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockOrderRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockOrderRepository)(nil).FindByID), ctx, id)
+}
+
+// FindByStatus mocks base method.
+func (m *MockOrderRepository) FindByStatus(ctx context.Context, status domain.OrderStatus) ([]*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByStatus", ctx, status)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByStatus indicates an expected call of FindByStatus.
+func (mr *MockOrderRepositoryMockRecorder) FindByStatus(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByStatus", reflect.TypeOf((*MockOrderRepository)(nil).FindByStatus), ctx, status)
+}
+
+// FindEvents mocks base method.
+func (m *MockOrderRepository) FindEvents(ctx context.Context, id domain.ID) ([]*domain.OrderEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEvents", ctx, id)
+	ret0, _ := ret[0].([]*domain.OrderEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEvents indicates an expected call of FindEvents.
+func (mr *MockOrderRepositoryMockRecorder) FindEvents(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEvents", reflect.TypeOf((*MockOrderRepository)(nil).FindEvents), ctx, id)
+}
+
+// FindKitchenQueue mocks base method.
+func (m *MockOrderRepository) FindKitchenQueue(ctx context.Context, station string) ([]*domain.KitchenTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindKitchenQueue", ctx, station)
+	ret0, _ := ret[0].([]*domain.KitchenTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindKitchenQueue indicates an expected call of FindKitchenQueue.
+func (mr *MockOrderRepositoryMockRecorder) FindKitchenQueue(ctx, station any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindKitchenQueue", reflect.TypeOf((*MockOrderRepository)(nil).FindKitchenQueue), ctx, station)
+}
+
+// FindNestedByID mocks base method.
+func (m *MockOrderRepository) FindNestedByID(ctx context.Context, id domain.ID) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNestedByID", ctx, id)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNestedByID indicates an expected call of FindNestedByID.
+func (mr *MockOrderRepositoryMockRecorder) FindNestedByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNestedByID", reflect.TypeOf((*MockOrderRepository)(nil).FindNestedByID), ctx, id)
+}
+
+// FindOrderProduct mocks base method.
+func (m *MockOrderRepository) FindOrderProduct(ctx context.Context, orderProductId domain.ID) (*domain.OrderProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrderProduct", ctx, orderProductId)
+	ret0, _ := ret[0].(*domain.OrderProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrderProduct indicates an expected call of FindOrderProduct.
+func (mr *MockOrderRepositoryMockRecorder) FindOrderProduct(ctx, orderProductId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrderProduct", reflect.TypeOf((*MockOrderRepository)(nil).FindOrderProduct), ctx, orderProductId)
+}
+
+// FindPageByCustomer mocks base method.
+func (m *MockOrderRepository) FindPageByCustomer(ctx context.Context, customerId domain.ID, filter domain.OrderFilter) (*domain.OrderPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPageByCustomer", ctx, customerId, filter)
+	ret0, _ := ret[0].(*domain.OrderPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPageByCustomer indicates an expected call of FindPageByCustomer.
+func (mr *MockOrderRepositoryMockRecorder) FindPageByCustomer(ctx, customerId, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPageByCustomer", reflect.TypeOf((*MockOrderRepository)(nil).FindPageByCustomer), ctx, customerId, filter)
+}
+
+// FindPickupBoard mocks base method.
+func (m *MockOrderRepository) FindPickupBoard(ctx context.Context, readySince time.Time) (*domain.PickupBoard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPickupBoard", ctx, readySince)
+	ret0, _ := ret[0].(*domain.PickupBoard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPickupBoard indicates an expected call of FindPickupBoard.
+func (mr *MockOrderRepositoryMockRecorder) FindPickupBoard(ctx, readySince any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPickupBoard", reflect.TypeOf((*MockOrderRepository)(nil).FindPickupBoard), ctx, readySince)
+}
+
+// FindTotalMismatches mocks base method.
+func (m *MockOrderRepository) FindTotalMismatches(ctx context.Context) ([]*domain.OrderTotalMismatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTotalMismatches", ctx)
+	ret0, _ := ret[0].([]*domain.OrderTotalMismatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTotalMismatches indicates an expected call of FindTotalMismatches.
+func (mr *MockOrderRepositoryMockRecorder) FindTotalMismatches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTotalMismatches", reflect.TypeOf((*MockOrderRepository)(nil).FindTotalMismatches), ctx)
+}
+
+// FindUncollectedReport mocks base method.
+func (m *MockOrderRepository) FindUncollectedReport(ctx context.Context, since time.Time) (*domain.UncollectedReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUncollectedReport", ctx, since)
+	ret0, _ := ret[0].(*domain.UncollectedReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUncollectedReport indicates an expected call of FindUncollectedReport.
+func (mr *MockOrderRepositoryMockRecorder) FindUncollectedReport(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUncollectedReport", reflect.TypeOf((*MockOrderRepository)(nil).FindUncollectedReport), ctx, since)
+}
+
+// GetTrackingNumber mocks base method.
+func (m *MockOrderRepository) GetTrackingNumber(ctx context.Context, num *uint16) (*uint16, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrackingNumber", ctx, num)
+	ret0, _ := ret[0].(*uint16)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrackingNumber indicates an expected call of GetTrackingNumber.
+func (mr *MockOrderRepositoryMockRecorder) GetTrackingNumber(ctx, num any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrackingNumber", reflect.TypeOf((*MockOrderRepository)(nil).GetTrackingNumber), ctx, num)
+}
+
+// List mocks base method.
+func (m *MockOrderRepository) List(ctx context.Context) ([]*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockOrderRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOrderRepository)(nil).List), ctx)
+}
+
+// Patch mocks base method.
+func (m *MockOrderRepository) Patch(ctx context.Context, id domain.ID, data *domain.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockOrderRepositoryMockRecorder) Patch(ctx, id, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockOrderRepository)(nil).Patch), ctx, id, data)
+}
+
+// RecalculateTotals mocks base method.
+func (m *MockOrderRepository) RecalculateTotals(ctx context.Context, id domain.ID) (domain.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecalculateTotals", ctx, id)
+	ret0, _ := ret[0].(domain.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecalculateTotals indicates an expected call of RecalculateTotals.
+func (mr *MockOrderRepositoryMockRecorder) RecalculateTotals(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecalculateTotals", reflect.TypeOf((*MockOrderRepository)(nil).RecalculateTotals), ctx, id)
+}
+
+// RemoveProduct mocks base method.
+func (m *MockOrderRepository) RemoveProduct(ctx context.Context, p *domain.OrderProduct) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveProduct", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveProduct indicates an expected call of RemoveProduct.
+func (mr *MockOrderRepositoryMockRecorder) RemoveProduct(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProduct", reflect.TypeOf((*MockOrderRepository)(nil).RemoveProduct), ctx, p)
+}
+
+// ResetTrackingNumbers mocks base method.
+func (m *MockOrderRepository) ResetTrackingNumbers(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetTrackingNumbers", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetTrackingNumbers indicates an expected call of ResetTrackingNumbers.
+func (mr *MockOrderRepositoryMockRecorder) ResetTrackingNumbers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetTrackingNumbers", reflect.TypeOf((*MockOrderRepository)(nil).ResetTrackingNumbers), ctx)
+}
+
+// Save mocks base method.
+func (m *MockOrderRepository) Save(ctx context.Context, o *domain.Order) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, o)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockOrderRepositoryMockRecorder) Save(ctx, o any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOrderRepository)(nil).Save), ctx, o)
+}
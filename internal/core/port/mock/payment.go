@@ -0,0 +1,305 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vitovidale/fastfood-app/internal/core/port (interfaces: PaymentGateway,PaymentEventRepository,PaymentRepository,RefundRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination mock/payment.go -package mock_port . PaymentGateway,PaymentEventRepository,PaymentRepository,RefundRepository
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vitovidale/fastfood-app/internal/core/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentGateway is a mock of PaymentGateway interface.
+type MockPaymentGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentGatewayMockRecorder is the mock recorder for MockPaymentGateway.
+type MockPaymentGatewayMockRecorder struct {
+	mock *MockPaymentGateway
+}
+
+// NewMockPaymentGateway creates a new mock instance.
+func NewMockPaymentGateway(ctrl *gomock.Controller) *MockPaymentGateway {
+	mock := &MockPaymentGateway{ctrl: ctrl}
+	mock.recorder = &MockPaymentGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentGateway) EXPECT() *MockPaymentGatewayMockRecorder {
+	return m.recorder
+}
+
+// CreateCharge mocks base method.
+func (m *MockPaymentGateway) CreateCharge(ctx context.Context, orderID domain.ID, amount domain.Money, method domain.PaymentMethod) (*domain.Charge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCharge", ctx, orderID, amount, method)
+	ret0, _ := ret[0].(*domain.Charge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCharge indicates an expected call of CreateCharge.
+func (mr *MockPaymentGatewayMockRecorder) CreateCharge(ctx, orderID, amount, method any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCharge", reflect.TypeOf((*MockPaymentGateway)(nil).CreateCharge), ctx, orderID, amount, method)
+}
+
+// GetCharge mocks base method.
+func (m *MockPaymentGateway) GetCharge(ctx context.Context, reference string) (*domain.Charge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCharge", ctx, reference)
+	ret0, _ := ret[0].(*domain.Charge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCharge indicates an expected call of GetCharge.
+func (mr *MockPaymentGatewayMockRecorder) GetCharge(ctx, reference any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCharge", reflect.TypeOf((*MockPaymentGateway)(nil).GetCharge), ctx, reference)
+}
+
+// Refund mocks base method.
+func (m *MockPaymentGateway) Refund(ctx context.Context, reference string, amount domain.Money, idempotencyKey string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, reference, amount, idempotencyKey)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPaymentGatewayMockRecorder) Refund(ctx, reference, amount, idempotencyKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPaymentGateway)(nil).Refund), ctx, reference, amount, idempotencyKey)
+}
+
+// MockPaymentEventRepository is a mock of PaymentEventRepository interface.
+type MockPaymentEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentEventRepositoryMockRecorder is the mock recorder for MockPaymentEventRepository.
+type MockPaymentEventRepositoryMockRecorder struct {
+	mock *MockPaymentEventRepository
+}
+
+// NewMockPaymentEventRepository creates a new mock instance.
+func NewMockPaymentEventRepository(ctrl *gomock.Controller) *MockPaymentEventRepository {
+	mock := &MockPaymentEventRepository{ctrl: ctrl}
+	mock.recorder = &MockPaymentEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentEventRepository) EXPECT() *MockPaymentEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPaymentEventRepository) Create(ctx context.Context, e *domain.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentEventRepositoryMockRecorder) Create(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentEventRepository)(nil).Create), ctx, e)
+}
+
+// Exists mocks base method.
+func (m *MockPaymentEventRepository) Exists(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockPaymentEventRepositoryMockRecorder) Exists(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockPaymentEventRepository)(nil).Exists), ctx, id)
+}
+
+// MockPaymentRepository is a mock of PaymentRepository interface.
+type MockPaymentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentRepositoryMockRecorder is the mock recorder for MockPaymentRepository.
+type MockPaymentRepositoryMockRecorder struct {
+	mock *MockPaymentRepository
+}
+
+// NewMockPaymentRepository creates a new mock instance.
+func NewMockPaymentRepository(ctrl *gomock.Controller) *MockPaymentRepository {
+	mock := &MockPaymentRepository{ctrl: ctrl}
+	mock.recorder = &MockPaymentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRepository) EXPECT() *MockPaymentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPaymentRepository) Create(ctx context.Context, p *domain.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentRepositoryMockRecorder) Create(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentRepository)(nil).Create), ctx, p)
+}
+
+// FindByOrder mocks base method.
+func (m *MockPaymentRepository) FindByOrder(ctx context.Context, orderID domain.ID) ([]*domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrder", ctx, orderID)
+	ret0, _ := ret[0].([]*domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrder indicates an expected call of FindByOrder.
+func (mr *MockPaymentRepositoryMockRecorder) FindByOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrder", reflect.TypeOf((*MockPaymentRepository)(nil).FindByOrder), ctx, orderID)
+}
+
+// FindByProviderRef mocks base method.
+func (m *MockPaymentRepository) FindByProviderRef(ctx context.Context, providerRef string) (*domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByProviderRef", ctx, providerRef)
+	ret0, _ := ret[0].(*domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByProviderRef indicates an expected call of FindByProviderRef.
+func (mr *MockPaymentRepositoryMockRecorder) FindByProviderRef(ctx, providerRef any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProviderRef", reflect.TypeOf((*MockPaymentRepository)(nil).FindByProviderRef), ctx, providerRef)
+}
+
+// Patch mocks base method.
+func (m *MockPaymentRepository) Patch(ctx context.Context, id domain.ID, data *domain.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockPaymentRepositoryMockRecorder) Patch(ctx, id, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockPaymentRepository)(nil).Patch), ctx, id, data)
+}
+
+// MockRefundRepository is a mock of RefundRepository interface.
+type MockRefundRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefundRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRefundRepositoryMockRecorder is the mock recorder for MockRefundRepository.
+type MockRefundRepositoryMockRecorder struct {
+	mock *MockRefundRepository
+}
+
+// NewMockRefundRepository creates a new mock instance.
+func NewMockRefundRepository(ctrl *gomock.Controller) *MockRefundRepository {
+	mock := &MockRefundRepository{ctrl: ctrl}
+	mock.recorder = &MockRefundRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefundRepository) EXPECT() *MockRefundRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRefundRepository) Create(ctx context.Context, r *domain.Refund) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefundRepositoryMockRecorder) Create(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefundRepository)(nil).Create), ctx, r)
+}
+
+// FindByOrder mocks base method.
+func (m *MockRefundRepository) FindByOrder(ctx context.Context, orderID domain.ID) ([]*domain.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrder", ctx, orderID)
+	ret0, _ := ret[0].([]*domain.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrder indicates an expected call of FindByOrder.
+func (mr *MockRefundRepositoryMockRecorder) FindByOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrder", reflect.TypeOf((*MockRefundRepository)(nil).FindByOrder), ctx, orderID)
+}
+
+// FindPending mocks base method.
+func (m *MockRefundRepository) FindPending(ctx context.Context, createdBefore time.Time) ([]*domain.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPending", ctx, createdBefore)
+	ret0, _ := ret[0].([]*domain.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPending indicates an expected call of FindPending.
+func (mr *MockRefundRepositoryMockRecorder) FindPending(ctx, createdBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPending", reflect.TypeOf((*MockRefundRepository)(nil).FindPending), ctx, createdBefore)
+}
+
+// Patch mocks base method.
+func (m *MockRefundRepository) Patch(ctx context.Context, id domain.ID, data *domain.Refund) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockRefundRepositoryMockRecorder) Patch(ctx, id, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockRefundRepository)(nil).Patch), ctx, id, data)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/vitovidale/TECH-CHALLENGE/internal/core/port (interfaces: ProductRepository)
+// Source: github.com/vitovidale/fastfood-app/internal/core/port (interfaces: ProductRepository)
 //
 // Generated by this command:
 //
-//	mockgen . ProductRepository
+//	mockgen -destination mock/product.go -package mock_port . ProductRepository
 //
 
 // Package mock_port is a generated GoMock package.
@@ -13,7 +13,6 @@ import (
 	context "context"
 	reflect "reflect"
 
-	uuid "github.com/google/uuid"
 	domain "github.com/vitovidale/fastfood-app/internal/core/domain"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -56,37 +55,38 @@ func (mr *MockProductRepositoryMockRecorder) Create(ctx, p any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductRepository)(nil).Create), ctx, p)
 }
 
-// Delete mocks base method.
-func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// FindAll mocks base method.
+func (m *MockProductRepository) FindAll(ctx context.Context) ([]*domain.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockProductRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+// FindAll indicates an expected call of FindAll.
+func (mr *MockProductRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProductRepository)(nil).FindAll), ctx)
 }
 
-// FindAllProducts mocks base method.
-func (m *MockProductRepository) FindAll(ctx context.Context) ([]*domain.Product, error) {
+// FindByCategory mocks base method.
+func (m *MockProductRepository) FindByCategory(ctx context.Context, id domain.ID) ([]*domain.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllProducts", ctx)
+	ret := m.ctrl.Call(m, "FindByCategory", ctx, id)
 	ret0, _ := ret[0].([]*domain.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllProducts indicates an expected call of FindAllProducts.
-func (mr *MockProductRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+// FindByCategory indicates an expected call of FindByCategory.
+func (mr *MockProductRepositoryMockRecorder) FindByCategory(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllProducts", reflect.TypeOf((*MockProductRepository)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCategory", reflect.TypeOf((*MockProductRepository)(nil).FindByCategory), ctx, id)
 }
 
 // FindByID mocks base method.
-func (m *MockProductRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+func (m *MockProductRepository) FindByID(ctx context.Context, id domain.ID) (*domain.Product, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindByID", ctx, id)
 	ret0, _ := ret[0].(*domain.Product)
@@ -100,16 +100,16 @@ func (mr *MockProductRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProductRepository)(nil).FindByID), ctx, id)
 }
 
-// Update mocks base method.
-func (m *MockProductRepository) Update(ctx context.Context, p *domain.Product) error {
+// Patch mocks base method.
+func (m *MockProductRepository) Patch(ctx context.Context, id domain.ID, p *domain.Product) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, p)
+	ret := m.ctrl.Call(m, "Patch", ctx, id, p)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockProductRepositoryMockRecorder) Update(ctx, p any) *gomock.Call {
+// Patch indicates an expected call of Patch.
+func (mr *MockProductRepositoryMockRecorder) Patch(ctx, id, p any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductRepository)(nil).Update), ctx, p)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockProductRepository)(nil).Patch), ctx, id, p)
 }
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vitovidale/fastfood-app/internal/core/port (interfaces: StaffRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination mock/staff.go -package mock_port . StaffRepository
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/vitovidale/fastfood-app/internal/core/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStaffRepository is a mock of StaffRepository interface.
+type MockStaffRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStaffRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStaffRepositoryMockRecorder is the mock recorder for MockStaffRepository.
+type MockStaffRepositoryMockRecorder struct {
+	mock *MockStaffRepository
+}
+
+// NewMockStaffRepository creates a new mock instance.
+func NewMockStaffRepository(ctrl *gomock.Controller) *MockStaffRepository {
+	mock := &MockStaffRepository{ctrl: ctrl}
+	mock.recorder = &MockStaffRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStaffRepository) EXPECT() *MockStaffRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStaffRepository) Create(ctx context.Context, u *domain.StaffUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStaffRepositoryMockRecorder) Create(ctx, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStaffRepository)(nil).Create), ctx, u)
+}
+
+// FindByID mocks base method.
+func (m *MockStaffRepository) FindByID(ctx context.Context, id domain.ID) (*domain.StaffUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.StaffUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockStaffRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockStaffRepository)(nil).FindByID), ctx, id)
+}
+
+// FindByUsername mocks base method.
+func (m *MockStaffRepository) FindByUsername(ctx context.Context, username string) (*domain.StaffUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username)
+	ret0, _ := ret[0].(*domain.StaffUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUsername indicates an expected call of FindByUsername.
+func (mr *MockStaffRepositoryMockRecorder) FindByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockStaffRepository)(nil).FindByUsername), ctx, username)
+}
+
+// List mocks base method.
+func (m *MockStaffRepository) List(ctx context.Context) ([]*domain.StaffUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.StaffUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockStaffRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockStaffRepository)(nil).List), ctx)
+}
+
+// Patch mocks base method.
+func (m *MockStaffRepository) Patch(ctx context.Context, id domain.ID, data *domain.StaffUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockStaffRepositoryMockRecorder) Patch(ctx, id, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockStaffRepository)(nil).Patch), ctx, id, data)
+}
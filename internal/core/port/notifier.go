@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// Notifier is an interface that wraps the delivery of notifications to
+// customers, such as emails.
+type Notifier interface {
+	Notify(ctx context.Context, n *domain.Notification) error
+}
@@ -2,29 +2,70 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/vitovidale/fastfood-app/internal/core/domain"
 )
 
 type OrderRepositoryReader interface {
 	FindByID(ctx context.Context, id domain.ID) (*domain.Order, error)
-	FindByCustomer(ctx context.Context, customerId uint64) (*domain.Order, error)
+	// the pending order of the customer or guest, the only one taking products
+	FindByCustomer(ctx context.Context, customerId domain.ID) (*domain.Order, error)
+	FindByGuest(ctx context.Context, guestId domain.ID) (*domain.Order, error)
+
+	// every order of the customer matching filter, newest first, with their
+	// lines
+	FindPageByCustomer(ctx context.Context, customerId domain.ID, filter domain.OrderFilter) (*domain.OrderPage, error)
 	List(ctx context.Context) ([]*domain.Order, error)
+	FindByStatus(ctx context.Context, status domain.OrderStatus) ([]*domain.Order, error)
 
-	// return a new tracking number or the existing one
-	GetTrackingNumber(ctx context.Context, num *uint16) *uint16
+	// return the existing tracking number, or a new one not held by any
+	// active order
+	GetTrackingNumber(ctx context.Context, num *uint16) (*uint16, error)
 
 	// return type detached from domain
 	FindNestedByID(ctx context.Context, id domain.ID) (any, error)
 	FindOrderProduct(ctx context.Context, orderProductId domain.ID) (*domain.OrderProduct, error)
+
+	// paid orders waiting for or being prepared, by payment time, with the
+	// items of the station only, or all items when station is empty
+	FindKitchenQueue(ctx context.Context, station string) ([]*domain.KitchenTicket, error)
+
+	// tracking numbers of the paid orders being prepared, and of the done
+	// orders ready since readySince
+	FindPickupBoard(ctx context.Context, readySince time.Time) (*domain.PickupBoard, error)
+
+	// done orders waiting to be collected, and how long the orders delivered
+	// since the given time waited
+	FindUncollectedReport(ctx context.Context, since time.Time) (*domain.UncollectedReport, error)
+
+	// the order history, oldest change first
+	FindEvents(ctx context.Context, id domain.ID) ([]*domain.OrderEvent, error)
+
+	// orders whose stored total disagrees with the sum of their lines
+	FindTotalMismatches(ctx context.Context) ([]*domain.OrderTotalMismatch, error)
 }
 
 type OrderRepositoryWriter interface {
+	// add or remove an order line, recalculating the order total
 	AddProduct(ctx context.Context, p *domain.OrderProduct) error
 	RemoveProduct(ctx context.Context, p *domain.OrderProduct) error
+
+	// set the order total to the sum of its lines and return it
+	RecalculateTotals(ctx context.Context, id domain.ID) (domain.Money, error)
+
+	// writes to existing orders only apply if the order is still at the version
+	// it was read at, see domain.ErrVersionConflict
 	Save(ctx context.Context, o *domain.Order) (*domain.Order, error)
 	Delete(ctx context.Context, id domain.ID) error
 	Patch(ctx context.Context, id domain.ID, data *domain.Order) error
+
+	// give the orders of a guest without a customer to the customer, recording
+	// the change in their history
+	AttachGuest(ctx context.Context, guestId domain.ID, customerId domain.ID) error
+
+	// start the tracking numbers over from 1
+	ResetTrackingNumbers(ctx context.Context) error
 }
 
 type OrderRepository interface {
@@ -33,19 +74,51 @@ type OrderRepository interface {
 }
 
 type OrderService interface {
-	GetByCustomer(ctx context.Context, customerId uint64) (*domain.Order, error)
+	GetByCustomer(ctx context.Context, customerId domain.ID) (*domain.Order, error)
+
+	// the past and active orders of a customer, a page at a time
+	GetCustomerOrders(ctx context.Context, customerId domain.ID, filter domain.OrderFilter) (*domain.OrderPage, error)
 	GetNestedByID(ctx context.Context, id domain.ID) (any, error)
 	GetByID(ctx context.Context, id domain.ID) (*domain.Order, error)
+	GetPayments(ctx context.Context, id domain.ID) ([]*domain.Payment, error)
 
 	// add a product and returns the new order ID or existing order ID
 	AddProduct(ctx context.Context, o *domain.Order, p *domain.OrderProduct) error
 	RemoveProduct(ctx context.Context, id domain.ID) error
 
 	// create a new order for a customer with a list of products
-	Create(ctx context.Context, customerId uint64, products []domain.OrderProduct) (*domain.ID, error)
+	Create(ctx context.Context, customerId domain.ID, products []domain.OrderProduct) (*domain.ID, error)
+
+	// create a new order for a guest, see Create
+	CreateForGuest(ctx context.Context, guestId domain.ID, products []domain.OrderProduct) (*domain.ID, error)
 
 	// order status movements
-	Pay(ctx context.Context, id domain.ID) error
+	Pay(ctx context.Context, id domain.ID, method domain.PaymentMethod) (*domain.Payment, error)
 	Prepare(ctx context.Context, id domain.ID) error
 	Complete(ctx context.Context, id domain.ID) error
+	Cancel(ctx context.Context, id domain.ID, reason string) error
+	Deliver(ctx context.Context, id domain.ID) error
+
+	// refund part or all of a paid order, zero refunds everything left
+	Refund(ctx context.Context, id domain.ID, amount domain.Money) (*domain.Refund, error)
+	GetRefunds(ctx context.Context, id domain.ID) ([]*domain.Refund, error)
+
+	// every change made to the order, who made it and when
+	GetHistory(ctx context.Context, id domain.ID) ([]*domain.OrderEvent, error)
+
+	// the kitchen queue, see OrderRepositoryReader.FindKitchenQueue
+	KitchenQueue(ctx context.Context, station string) ([]*domain.KitchenTicket, error)
+
+	// the pickup board, done orders drop off after readyFor or once delivered
+	PickupBoard(ctx context.Context, readyFor time.Duration) (*domain.PickupBoard, error)
+
+	// how long orders sit at the counter, see UncollectedReport
+	UncollectedReport(ctx context.Context, since time.Time) (*domain.UncollectedReport, error)
+
+	// consistency checks of the order totals against their lines
+	GetTotalMismatches(ctx context.Context) ([]*domain.OrderTotalMismatch, error)
+	RecalculateTotals(ctx context.Context, id domain.ID) (*domain.Order, error)
+
+	// start the tracking numbers over, at the store opening
+	ResetTrackingNumbers(ctx context.Context) error
 }
@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// OutboxRepository is an interface that wraps the operations for the messages
+// waiting to be published to other systems.
+type OutboxRepository interface {
+	// take the relay lock until the end of the transaction of ctx, reporting
+	// false when another relay holds it
+	Lock(ctx context.Context) (bool, error)
+
+	// unpublished messages due by now, oldest first, without those written
+	// after a message of the same aggregate that is not due yet
+	FindPending(ctx context.Context, limit int) ([]*domain.OutboxMessage, error)
+
+	// write the outcome of an attempt to publish a message
+	Update(ctx context.Context, m *domain.OutboxMessage) error
+}
+
+// EventPublisher is an interface that wraps the delivery of events to other
+// systems, such as a message broker.
+type EventPublisher interface {
+	Publish(ctx context.Context, m *domain.OutboxMessage) error
+}
@@ -0,0 +1,54 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// PaymentGateway is an interface that wraps the operations of a payment service provider.
+type PaymentGateway interface {
+	// create a charge for an order, the charge is settled asynchronously
+	CreateCharge(ctx context.Context, orderID domain.ID, amount domain.Money, method domain.PaymentMethod) (*domain.Charge, error)
+	GetCharge(ctx context.Context, reference string) (*domain.Charge, error)
+
+	// refund part or all of an approved charge, returning the refund reference,
+	// once per idempotency key
+	Refund(ctx context.Context, reference string, amount domain.Money, idempotencyKey string) (string, error)
+}
+
+// PaymentEventRepository is an interface that wraps the operations for the
+// events received from the payment provider.
+type PaymentEventRepository interface {
+	Exists(ctx context.Context, id string) (bool, error)
+	Create(ctx context.Context, e *domain.PaymentEvent) error
+}
+
+// PaymentRepositoryReader is an interface that wraps all the reading operations for a payment.
+type PaymentRepositoryReader interface {
+	FindByOrder(ctx context.Context, orderID domain.ID) ([]*domain.Payment, error)
+	FindByProviderRef(ctx context.Context, providerRef string) (*domain.Payment, error)
+}
+
+// PaymentRepositoryWriter is an interface that wraps all the writing operations for a payment.
+type PaymentRepositoryWriter interface {
+	Create(ctx context.Context, p *domain.Payment) error
+	Patch(ctx context.Context, id domain.ID, data *domain.Payment) error
+}
+
+// PaymentRepository is an interface that wraps all the reading and writing operations for a payment.
+type PaymentRepository interface {
+	PaymentRepositoryReader
+	PaymentRepositoryWriter
+}
+
+// RefundRepository is an interface that wraps the operations for the refund ledger of the orders.
+type RefundRepository interface {
+	Create(ctx context.Context, r *domain.Refund) error
+	Patch(ctx context.Context, id domain.ID, data *domain.Refund) error
+	FindByOrder(ctx context.Context, orderID domain.ID) ([]*domain.Refund, error)
+
+	// refunds not given back yet, created before the given time, oldest first
+	FindPending(ctx context.Context, createdBefore time.Time) ([]*domain.Refund, error)
+}
@@ -16,6 +16,7 @@ type ProductRepositoryReader interface {
 // ProductRepositoryWriter is an interface that wraps all the writing operations for a product.
 type ProductRepositoryWriter interface {
 	Create(ctx context.Context, p *domain.Product) error
+	// update the product if still at p.Version, see domain.ErrVersionConflict
 	Patch(ctx context.Context, id domain.ID, p *domain.Product) error
 }
 
@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+)
+
+// StaffRepository is an interface that wraps the operations for the staff
+// users, deleted users are never found.
+type StaffRepository interface {
+	Create(ctx context.Context, u *domain.StaffUser) error
+	FindByID(ctx context.Context, id domain.ID) (*domain.StaffUser, error)
+	FindByUsername(ctx context.Context, username string) (*domain.StaffUser, error)
+	List(ctx context.Context) ([]*domain.StaffUser, error)
+	// update the staff user if still at data.Version, see domain.ErrVersionConflict
+	Patch(ctx context.Context, id domain.ID, data *domain.StaffUser) error
+}
+
+// StaffService is an interface that wraps the operations for the staff users.
+type StaffService interface {
+	Create(ctx context.Context, username string, name string, password string, role domain.Role) (*domain.StaffUser, error)
+	List(ctx context.Context) ([]*domain.StaffUser, error)
+	Delete(ctx context.Context, id domain.ID) error
+	Authenticate(ctx context.Context, username string, password string) (*domain.StaffUser, error)
+}
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+)
+
+// Transactor is a unit of work: it runs several repository calls atomically.
+//
+// The repository calls made with the context given to fn are committed
+// together when fn returns nil, and rolled back when it returns an error.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/port"
+)
+
+type AuthService struct {
+	customerRepository     port.CustomerRepository
+	staffRepository        port.StaffRepository
+	guestRepository        port.GuestRepository
+	revokedTokenRepository port.RevokedTokenRepository
+	signer                 port.TokenSigner
+	accessTTL              time.Duration
+	refreshTTL             time.Duration
+}
+
+func NewAuthService(
+	customerRepository port.CustomerRepository,
+	staffRepository port.StaffRepository,
+	guestRepository port.GuestRepository,
+	revokedTokenRepository port.RevokedTokenRepository,
+	signer port.TokenSigner,
+	accessTTL time.Duration,
+	refreshTTL time.Duration,
+) *AuthService {
+	return &AuthService{
+		customerRepository:     customerRepository,
+		staffRepository:        staffRepository,
+		guestRepository:        guestRepository,
+		revokedTokenRepository: revokedTokenRepository,
+		signer:                 signer,
+		accessTTL:              accessTTL,
+		refreshTTL:             refreshTTL,
+	}
+}
+
+// Issue signs a new pair of tokens for a customer or staff user who just
+// authenticated, or for a guest who just started a session.
+func (s *AuthService) Issue(ctx context.Context, p *domain.Principal) (*domain.TokenPair, error) {
+	access := domain.NewToken(domain.TokenTypeAccess, p, s.accessTTL)
+	refresh := domain.NewToken(domain.TokenTypeRefresh, p, s.refreshTTL)
+
+	accessToken, err := s.signer.Sign(access)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := s.signer.Sign(refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    access.ExpiresAt,
+	}, nil
+}
+
+// Refresh exchanges a refresh token for a new pair of tokens, revoking it so a
+// stolen refresh token is only good until its holder refreshes again.
+// Customers and staff users deleted meanwhile get no new tokens, nor do
+// customers signed out since, and staff users get the role they have now.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*domain.TokenPair, error) {
+	t, err := s.parse(ctx, refreshToken, domain.TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.currentPrincipal(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the first of concurrent refreshes with the same token gets through
+	revoked, err := s.revokedTokenRepository.Revoke(ctx, domain.NewRevokedToken(t))
+	if err != nil {
+		return nil, err
+	}
+	if !revoked {
+		return nil, domain.ErrorUnauthorized
+	}
+
+	return s.Issue(ctx, p)
+}
+
+// currentPrincipal reads again the customer, guest or staff user a token was
+// issued to, returning domain.ErrorUnauthorized when they were deleted
+// meanwhile, or the customer signed out since.
+func (s *AuthService) currentPrincipal(ctx context.Context, t *domain.Token) (*domain.Principal, error) {
+	p, err := s.readPrincipal(ctx, t)
+	if err != nil {
+		if err.Error() == domain.ErrorDataNotFound.Error() {
+			return nil, domain.ErrorUnauthorized
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *AuthService) readPrincipal(ctx context.Context, t *domain.Token) (*domain.Principal, error) {
+	p := &t.Principal
+
+	if p.Role.IsStaff() {
+		u, err := s.staffRepository.FindByID(ctx, p.StaffID)
+		if err != nil {
+			return nil, err
+		}
+		return domain.NewStaffPrincipal(u), nil
+	}
+
+	if p.Role == domain.RoleGuest {
+		g, err := s.guestRepository.FindByID(ctx, p.GuestID)
+		if err != nil {
+			return nil, err
+		}
+		return domain.NewGuestPrincipal(g.ID), nil
+	}
+
+	c, err := s.customerRepository.FindByID(ctx, p.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if !c.AcceptsToken(t) {
+		return nil, domain.ErrorUnauthorized
+	}
+	return domain.NewCustomerPrincipal(c.ID), nil
+}
+
+// Revoke stops accepting a token, access or refresh, before it expires.
+func (s *AuthService) Revoke(ctx context.Context, token string) error {
+	t, err := s.signer.Parse(token)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.revokedTokenRepository.Revoke(ctx, domain.NewRevokedToken(t))
+	return err
+}
+
+// Verify returns who holds an access token, as Refresh would: customers and
+// staff users deleted meanwhile are no longer let in, nor are customers signed
+// out since, and staff users have the role they have now.
+func (s *AuthService) Verify(ctx context.Context, accessToken string) (*domain.Principal, error) {
+	t, err := s.parse(ctx, accessToken, domain.TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+	return s.currentPrincipal(ctx, t)
+}
+
+// parse returns a signed token of the given type, as long as it was not
+// revoked.
+func (s *AuthService) parse(ctx context.Context, signed string, tokenType domain.TokenType) (*domain.Token, error) {
+	t, err := s.signer.Parse(signed)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Type != tokenType {
+		return nil, domain.ErrorUnauthorized
+	}
+
+	revoked, err := s.revokedTokenRepository.IsRevoked(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, domain.ErrorUnauthorized
+	}
+	return t, nil
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/config"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/token"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	mock_port "github.com/vitovidale/fastfood-app/internal/core/port/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestSigner(t *testing.T) *token.JWTSigner {
+	signer, err := token.NewJWTSigner(&config.Auth{Algorithm: "HS256", Secret: "0123456789abcdef0123456789abcdef", Issuer: "test"})
+	require.NoError(t, err)
+	return signer
+}
+
+func TestAuthService_Verify(t *testing.T) {
+	ctx := context.Background()
+	customer := &domain.Customer{ID: domain.NewID()}
+
+	t.Run("Access token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+		customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(customer, nil)
+		revokedTokenRepository := mock_port.NewMockRevokedTokenRepository(ctrl)
+		revokedTokenRepository.EXPECT().IsRevoked(ctx, gomock.Any()).Return(false, nil)
+
+		service := NewAuthService(customerRepository, nil, nil, revokedTokenRepository, newTestSigner(t), time.Minute, time.Hour)
+		pair, err := service.Issue(ctx, domain.NewCustomerPrincipal(customer.ID))
+		require.NoError(t, err)
+
+		principal, err := service.Verify(ctx, pair.AccessToken)
+		require.NoError(t, err)
+		require.Equal(t, domain.NewCustomerPrincipal(customer.ID), principal)
+	})
+
+	t.Run("Refresh token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		revokedTokenRepository := mock_port.NewMockRevokedTokenRepository(ctrl)
+
+		service := NewAuthService(nil, nil, nil, revokedTokenRepository, newTestSigner(t), time.Minute, time.Hour)
+		pair, err := service.Issue(ctx, domain.NewCustomerPrincipal(customer.ID))
+		require.NoError(t, err)
+
+		_, err = service.Verify(ctx, pair.RefreshToken)
+		require.ErrorIs(t, err, domain.ErrorUnauthorized)
+	})
+
+	t.Run("Revoked access token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		revokedTokenRepository := mock_port.NewMockRevokedTokenRepository(ctrl)
+		revokedTokenRepository.EXPECT().IsRevoked(ctx, gomock.Any()).Return(true, nil)
+
+		service := NewAuthService(nil, nil, nil, revokedTokenRepository, newTestSigner(t), time.Minute, time.Hour)
+		pair, err := service.Issue(ctx, domain.NewCustomerPrincipal(customer.ID))
+		require.NoError(t, err)
+
+		_, err = service.Verify(ctx, pair.AccessToken)
+		require.ErrorIs(t, err, domain.ErrorUnauthorized)
+	})
+
+	t.Run("Customer signed out since", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		revokedTokenRepository := mock_port.NewMockRevokedTokenRepository(ctrl)
+		revokedTokenRepository.EXPECT().IsRevoked(ctx, gomock.Any()).Return(false, nil)
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+
+		service := NewAuthService(customerRepository, nil, nil, revokedTokenRepository, newTestSigner(t), time.Minute, time.Hour)
+		pair, err := service.Issue(ctx, domain.NewCustomerPrincipal(customer.ID))
+		require.NoError(t, err)
+
+		// such as when the password was reset
+		signedOutAt := time.Now().Add(time.Second)
+		customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(&domain.Customer{ID: customer.ID, SignedOutAt: &signedOutAt}, nil)
+
+		_, err = service.Verify(ctx, pair.AccessToken)
+		require.ErrorIs(t, err, domain.ErrorUnauthorized)
+	})
+
+	t.Run("Staff user deleted", func(t *testing.T) {
+		u, err := domain.NewStaffUser("jane", "Jane Doe", "12345678", domain.RoleCashier)
+		require.NoError(t, err)
+
+		ctrl := gomock.NewController(t)
+		revokedTokenRepository := mock_port.NewMockRevokedTokenRepository(ctrl)
+		revokedTokenRepository.EXPECT().IsRevoked(ctx, gomock.Any()).Return(false, nil)
+		staffRepository := mock_port.NewMockStaffRepository(ctrl)
+		staffRepository.EXPECT().FindByID(ctx, u.ID).Return(nil, domain.ErrorDataNotFound)
+
+		service := NewAuthService(nil, staffRepository, nil, revokedTokenRepository, newTestSigner(t), time.Minute, time.Hour)
+		pair, err := service.Issue(ctx, domain.NewStaffPrincipal(u))
+		require.NoError(t, err)
+
+		_, err = service.Verify(ctx, pair.AccessToken)
+		require.ErrorIs(t, err, domain.ErrorUnauthorized)
+	})
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	ctx := context.Background()
+	customer := &domain.Customer{ID: domain.NewID()}
+
+	testCases := []struct {
+		title string
+		mocks func(customerRepository *mock_port.MockCustomerRepository, revokedTokenRepository *mock_port.MockRevokedTokenRepository)
+		err   error
+	}{
+		{
+			title: "New pair of tokens",
+			mocks: func(customerRepository *mock_port.MockCustomerRepository, revokedTokenRepository *mock_port.MockRevokedTokenRepository) {
+				revokedTokenRepository.EXPECT().IsRevoked(ctx, gomock.Any()).Return(false, nil)
+				customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(customer, nil)
+				revokedTokenRepository.EXPECT().Revoke(ctx, gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			title: "Refresh token used meanwhile",
+			mocks: func(customerRepository *mock_port.MockCustomerRepository, revokedTokenRepository *mock_port.MockRevokedTokenRepository) {
+				revokedTokenRepository.EXPECT().IsRevoked(ctx, gomock.Any()).Return(false, nil)
+				customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(customer, nil)
+				revokedTokenRepository.EXPECT().Revoke(ctx, gomock.Any()).Return(false, nil)
+			},
+			err: domain.ErrorUnauthorized,
+		},
+		{
+			title: "Revoked refresh token",
+			mocks: func(customerRepository *mock_port.MockCustomerRepository, revokedTokenRepository *mock_port.MockRevokedTokenRepository) {
+				revokedTokenRepository.EXPECT().IsRevoked(ctx, gomock.Any()).Return(true, nil)
+			},
+			err: domain.ErrorUnauthorized,
+		},
+		{
+			title: "Customer signed out since",
+			mocks: func(customerRepository *mock_port.MockCustomerRepository, revokedTokenRepository *mock_port.MockRevokedTokenRepository) {
+				signedOutAt := time.Now()
+				revokedTokenRepository.EXPECT().IsRevoked(ctx, gomock.Any()).Return(false, nil)
+				customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(&domain.Customer{ID: customer.ID, SignedOutAt: &signedOutAt}, nil)
+			},
+			err: domain.ErrorUnauthorized,
+		},
+		{
+			title: "Customer deleted",
+			mocks: func(customerRepository *mock_port.MockCustomerRepository, revokedTokenRepository *mock_port.MockRevokedTokenRepository) {
+				revokedTokenRepository.EXPECT().IsRevoked(ctx, gomock.Any()).Return(false, nil)
+				customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(nil, domain.ErrorDataNotFound)
+			},
+			err: domain.ErrorUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+			revokedTokenRepository := mock_port.NewMockRevokedTokenRepository(ctrl)
+			tc.mocks(customerRepository, revokedTokenRepository)
+
+			service := NewAuthService(customerRepository, nil, nil, revokedTokenRepository, newTestSigner(t), time.Minute, time.Hour)
+			pair, err := service.Issue(ctx, domain.NewCustomerPrincipal(customer.ID))
+			require.NoError(t, err)
+
+			next, err := service.Refresh(ctx, pair.RefreshToken)
+			require.ErrorIs(t, err, tc.err)
+			if tc.err == nil {
+				require.NotEqual(t, pair.RefreshToken, next.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestAuthService_RefreshStaff(t *testing.T) {
+	ctx := context.Background()
+	u, err := domain.NewStaffUser("jane", "Jane Doe", "12345678", domain.RoleCashier)
+	require.NoError(t, err)
+
+	t.Run("Role read again", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		staffRepository := mock_port.NewMockStaffRepository(ctrl)
+		revokedTokenRepository := mock_port.NewMockRevokedTokenRepository(ctrl)
+		revokedTokenRepository.EXPECT().IsRevoked(ctx, gomock.Any()).Return(false, nil).Times(2)
+		staffRepository.EXPECT().FindByID(ctx, u.ID).Return(&domain.StaffUser{ID: u.ID, Username: u.Username, Role: domain.RoleManager}, nil).Times(2)
+		revokedTokenRepository.EXPECT().Revoke(ctx, gomock.Any()).Return(true, nil)
+
+		service := NewAuthService(nil, staffRepository, nil, revokedTokenRepository, newTestSigner(t), time.Minute, time.Hour)
+		pair, err := service.Issue(ctx, domain.NewStaffPrincipal(u))
+		require.NoError(t, err)
+
+		next, err := service.Refresh(ctx, pair.RefreshToken)
+		require.NoError(t, err)
+
+		principal, err := service.Verify(ctx, next.AccessToken)
+		require.NoError(t, err)
+		require.Equal(t, domain.RoleManager, principal.Role)
+	})
+
+	t.Run("Staff user deleted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		staffRepository := mock_port.NewMockStaffRepository(ctrl)
+		revokedTokenRepository := mock_port.NewMockRevokedTokenRepository(ctrl)
+		revokedTokenRepository.EXPECT().IsRevoked(ctx, gomock.Any()).Return(false, nil)
+		staffRepository.EXPECT().FindByID(ctx, u.ID).Return(nil, domain.ErrorDataNotFound)
+
+		service := NewAuthService(nil, staffRepository, nil, revokedTokenRepository, newTestSigner(t), time.Minute, time.Hour)
+		pair, err := service.Issue(ctx, domain.NewStaffPrincipal(u))
+		require.NoError(t, err)
+
+		_, err = service.Refresh(ctx, pair.RefreshToken)
+		require.ErrorIs(t, err, domain.ErrorUnauthorized)
+	})
+}
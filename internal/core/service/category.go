@@ -32,6 +32,10 @@ func (s *CategoryService) GetAll(ctx context.Context) ([]*domain.Category, error
 }
 
 func (s *CategoryService) Create(ctx context.Context, c *domain.Category) (*domain.Category, error) {
+	if err := domain.CheckPermission(ctx, domain.PermissionManageCatalog); err != nil {
+		return nil, err
+	}
+
 	err := s.categoryRepository.Create(ctx, c)
 	if err != nil {
 		return nil, err
@@ -40,6 +44,10 @@ func (s *CategoryService) Create(ctx context.Context, c *domain.Category) (*doma
 }
 
 func (s *CategoryService) Update(ctx context.Context, c *domain.Category) (*domain.Category, error) {
+	if err := domain.CheckPermission(ctx, domain.PermissionManageCatalog); err != nil {
+		return nil, err
+	}
+
 	err := s.categoryRepository.Update(ctx, c)
 	if err != nil {
 		return nil, err
@@ -48,6 +56,10 @@ func (s *CategoryService) Update(ctx context.Context, c *domain.Category) (*doma
 }
 
 func (s *CategoryService) Delete(ctx context.Context, id domain.ID) error {
+	if err := domain.CheckPermission(ctx, domain.PermissionManageCatalog); err != nil {
+		return err
+	}
+
 	c, err := s.categoryRepository.FindCategoryByID(ctx, id)
 	if err != nil {
 		return err
@@ -64,6 +76,10 @@ func (s *CategoryService) Delete(ctx context.Context, id domain.ID) error {
 }
 
 func (s *CategoryService) Activate(ctx context.Context, id domain.ID) error {
+	if err := domain.CheckPermission(ctx, domain.PermissionManageCatalog); err != nil {
+		return err
+	}
+
 	c, err := s.categoryRepository.FindCategoryByID(ctx, id)
 	if err != nil {
 		return err
@@ -1,7 +1,6 @@
 package service
 
 import (
-	"context"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v7"
@@ -21,7 +20,7 @@ type createCategoryTestedOutput struct {
 }
 
 func TestCategoryService_CreateCategory(t *testing.T) {
-	ctx := context.Background()
+	ctx := systemContext()
 	categoryID, _ := domain.ParseID(gofakeit.UUID())
 	categoryName := gofakeit.ProductCategory()
 	categoryCreatedAt := gofakeit.Date()
@@ -10,14 +10,40 @@ import (
 )
 
 type CustomerService struct {
-	customerRepository port.CustomerRepository
+	customerRepository      port.CustomerRepository
+	customerTokenRepository port.CustomerTokenRepository
+	guestRepository         port.GuestRepository
+	notifier                port.Notifier
+	transactor              port.Transactor
+	passwordResetTTL        time.Duration
+	emailVerificationTTL    time.Duration
 }
 
-func NewCustomerService(customerRepository port.CustomerRepository) *CustomerService {
-	return &CustomerService{customerRepository: customerRepository}
+func NewCustomerService(
+	customerRepository port.CustomerRepository,
+	customerTokenRepository port.CustomerTokenRepository,
+	guestRepository port.GuestRepository,
+	notifier port.Notifier,
+	transactor port.Transactor,
+	passwordResetTTL time.Duration,
+	emailVerificationTTL time.Duration,
+) *CustomerService {
+	return &CustomerService{
+		customerRepository:      customerRepository,
+		customerTokenRepository: customerTokenRepository,
+		guestRepository:         guestRepository,
+		notifier:                notifier,
+		transactor:              transactor,
+		passwordResetTTL:        passwordResetTTL,
+		emailVerificationTTL:    emailVerificationTTL,
+	}
 }
 
-func (s *CustomerService) GetByID(ctx context.Context, id uint64) (*domain.Customer, error) {
+func (s *CustomerService) GetByID(ctx context.Context, id domain.ID) (*domain.Customer, error) {
+	if err := domain.CheckCustomer(ctx, id); err != nil {
+		return nil, err
+	}
+
 	c, err := s.customerRepository.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -25,8 +51,56 @@ func (s *CustomerService) GetByID(ctx context.Context, id uint64) (*domain.Custo
 	return c, nil
 }
 
+// GetByCPF returns the customer identified by the CPF, for a guest at the
+// kiosk to identify themselves before signing in. Customers only find
+// themselves, and guests only until they are attached to a customer.
+func (s *CustomerService) GetByCPF(ctx context.Context, cpf domain.CPF) (*domain.Customer, error) {
+	p := domain.PrincipalFromContext(ctx)
+	if p == nil {
+		return nil, domain.ErrorUnauthorized
+	}
+
+	if p.Role == domain.RoleGuest {
+		if err := s.checkUnattachedGuest(ctx, p.GuestID); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := s.customerRepository.FindByCPF(ctx, cpf)
+	if err != nil {
+		if err.Error() == domain.ErrorDataNotFound.Error() {
+			return nil, domain.ErrorCustomerNotFound
+		}
+		return nil, err
+	}
+
+	if p.Role == domain.RoleCustomer {
+		if err = domain.CheckCustomer(ctx, c.ID); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// checkUnattachedGuest returns ErrorForbidden when the guest was attached to
+// a customer already, and ErrorUnauthorized when the guest is gone.
+func (s *CustomerService) checkUnattachedGuest(ctx context.Context, guestId domain.ID) error {
+	g, err := s.guestRepository.FindByID(ctx, guestId)
+	if err != nil {
+		if err.Error() == domain.ErrorDataNotFound.Error() {
+			return domain.ErrorUnauthorized
+		}
+		return err
+	}
+
+	if g.CustomerID != nil {
+		return domain.ErrorForbidden
+	}
+	return nil
+}
+
 func (s *CustomerService) GetByKeys(ctx context.Context, c *domain.Customer) (*domain.Customer, error) {
-	c, err := s.customerRepository.FindByKeys(ctx, c.ID, c.Email)
+	c, err := s.customerRepository.FindByKeys(ctx, c.CPF, c.Email)
 	if err != nil {
 		return nil, err
 	}
@@ -34,20 +108,57 @@ func (s *CustomerService) GetByKeys(ctx context.Context, c *domain.Customer) (*d
 }
 
 func (s *CustomerService) Create(ctx context.Context, c *domain.Customer) (*domain.Customer, error) {
+	_, err := s.customerRepository.FindByKeys(ctx, c.CPF, c.Email)
+	if err == nil {
+		return nil, domain.ErrorCustomerAlreadyExists
+	}
+	if err.Error() != domain.ErrorDataNotFound.Error() {
+		return nil, err
+	}
+
 	hash, _ := bcrypt.GenerateFromPassword([]byte(c.Password), bcrypt.DefaultCost)
+	c.ID = domain.NewID()
 	c.Password = string(hash)
 
-	err := s.customerRepository.Create(ctx, c)
-
+	// the customer and the email verification token are created together,
+	// and the token mailed once both exist. The customer stays created when
+	// it cannot be mailed, see RequestEmailVerification
+	var n *domain.Notification
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.customerRepository.Create(ctx, c); err != nil {
+			return err
+		}
+
+		var err error
+		n, err = s.createToken(ctx, c, domain.CustomerTokenEmailVerification)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if err = s.notifier.Notify(ctx, n); err != nil {
+		return nil, err
+	}
+
 	return c, nil
 }
 
 func (s *CustomerService) Update(ctx context.Context, c *domain.Customer) (*domain.Customer, error) {
-	data := domain.Customer{}
+	if err := domain.CheckCustomer(ctx, c.ID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.customerRepository.FindByID(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = domain.CheckVersion(ctx, current.Version); err != nil {
+		return nil, err
+	}
+
+	data := domain.Customer{Version: current.Version}
 
 	if c.FirstName != "" {
 		data.FirstName = c.FirstName
@@ -61,7 +172,7 @@ func (s *CustomerService) Update(ctx context.Context, c *domain.Customer) (*doma
 		data.Email = c.Email
 	}
 
-	err := s.customerRepository.Patch(ctx, c.ID, &data)
+	err = s.customerRepository.Patch(ctx, c.ID, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -69,15 +180,23 @@ func (s *CustomerService) Update(ctx context.Context, c *domain.Customer) (*doma
 	return s.GetByID(ctx, c.ID)
 }
 
-func (s *CustomerService) Delete(ctx context.Context, id uint64) error {
-	_, err := s.customerRepository.FindByID(ctx, id)
+func (s *CustomerService) Delete(ctx context.Context, id domain.ID) error {
+	if err := domain.CheckCustomer(ctx, id); err != nil {
+		return err
+	}
+
+	c, err := s.customerRepository.FindByID(ctx, id)
 
 	if err != nil {
 		return err
 	}
 
+	if err = domain.CheckVersion(ctx, c.Version); err != nil {
+		return err
+	}
+
 	deletedAt := time.Now()
-	err = s.customerRepository.Patch(ctx, id, &domain.Customer{DeletedAt: &deletedAt})
+	err = s.customerRepository.Patch(ctx, id, &domain.Customer{DeletedAt: &deletedAt, Version: c.Version})
 
 	if err != nil {
 		return err
@@ -85,7 +204,7 @@ func (s *CustomerService) Delete(ctx context.Context, id uint64) error {
 	return nil
 }
 
-// func (s *CustomerService) Deactivate(ctx context.Context, id uint64) error {
+// func (s *CustomerService) Deactivate(ctx context.Context, id domain.ID) error {
 // 	c, err := s.customerRepository.FindByID(ctx, id)
 
 // 	if err != nil {
@@ -106,7 +225,7 @@ func (s *CustomerService) Delete(ctx context.Context, id uint64) error {
 // 	return nil
 // }
 
-// func (s *CustomerService) Activate(ctx context.Context, id uint64) error {
+// func (s *CustomerService) Activate(ctx context.Context, id domain.ID) error {
 // 	c, err := s.customerRepository.FindByID(ctx, id)
 // 	if err != nil {
 // 		return err
@@ -139,3 +258,156 @@ func (s *CustomerService) Authenticate(ctx context.Context, c *domain.Customer)
 
 	return res, nil
 }
+
+// RequestPasswordReset mails a password reset token to the customer with the
+// email. Nothing is mailed when no customer has the email, without telling
+// the caller, so the emails of the customers cannot be guessed.
+func (s *CustomerService) RequestPasswordReset(ctx context.Context, email string) error {
+	c, err := s.customerRepository.FindByKeys(ctx, "", email)
+	if err != nil {
+		if err.Error() == domain.ErrorDataNotFound.Error() {
+			return nil
+		}
+		return err
+	}
+
+	return s.mailToken(ctx, c, domain.CustomerTokenPasswordReset)
+}
+
+// ResetPassword sets the password of the customer the password reset token
+// with the secret was mailed to, using the token up, and signs the customer
+// out of every session, so whoever knew the old password is left out.
+func (s *CustomerService) ResetPassword(ctx context.Context, secret string, password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		c, err := s.useToken(ctx, secret, domain.CustomerTokenPasswordReset)
+		if err != nil {
+			return err
+		}
+
+		signedOutAt := time.Now()
+		return s.customerRepository.Patch(ctx, c.ID, &domain.Customer{Password: string(hash), SignedOutAt: &signedOutAt, Version: c.Version})
+	})
+}
+
+// RequestEmailVerification mails an email verification token to the
+// customer, such as when the one mailed on sign up expired or the email
+// changed.
+func (s *CustomerService) RequestEmailVerification(ctx context.Context, id domain.ID) error {
+	c, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if c.IsEmailVerified() {
+		return domain.ErrorCustomerEmailAlreadyVerified
+	}
+
+	return s.mailToken(ctx, c, domain.CustomerTokenEmailVerification)
+}
+
+// VerifyEmail verifies the email the email verification token with the
+// secret was mailed to, using the token up.
+func (s *CustomerService) VerifyEmail(ctx context.Context, secret string) (*domain.Customer, error) {
+	var c *domain.Customer
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if c, err = s.useToken(ctx, secret, domain.CustomerTokenEmailVerification); err != nil {
+			return err
+		}
+
+		data := domain.Customer{VerifiedEmail: c.Email, Version: c.Version}
+		if err = s.customerRepository.Patch(ctx, c.ID, &data); err != nil {
+			return err
+		}
+
+		c.VerifiedEmail = data.VerifiedEmail
+		c.Version = data.Version
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// mailToken mails the customer a new token for purpose, once the token is
+// stored, so no secret is ever mailed for a token that was rolled back.
+func (s *CustomerService) mailToken(ctx context.Context, c *domain.Customer, purpose domain.CustomerTokenPurpose) error {
+	var n *domain.Notification
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		n, err = s.createToken(ctx, c, purpose)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notifier.Notify(ctx, n)
+}
+
+// createToken stores a new token of the customer for purpose, returning the
+// notification mailing its secret.
+func (s *CustomerService) createToken(ctx context.Context, c *domain.Customer, purpose domain.CustomerTokenPurpose) (*domain.Notification, error) {
+	ttl := s.emailVerificationTTL
+	if purpose == domain.CustomerTokenPasswordReset {
+		ttl = s.passwordResetTTL
+	}
+
+	t, secret, err := domain.NewCustomerToken(c, purpose, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.customerTokenRepository.Create(ctx, t); err != nil {
+		return nil, err
+	}
+
+	if purpose == domain.CustomerTokenPasswordReset {
+		return domain.NewPasswordResetNotification(c, t, secret), nil
+	}
+	return domain.NewEmailVerificationNotification(c, t, secret), nil
+}
+
+// useToken uses up the token for purpose with the secret, returning the
+// customer it was mailed to. Tokens can only be used once, even when handed
+// back at the same time.
+func (s *CustomerService) useToken(ctx context.Context, secret string, purpose domain.CustomerTokenPurpose) (*domain.Customer, error) {
+	t, err := s.customerTokenRepository.FindByHash(ctx, domain.HashCustomerTokenSecret(secret))
+	if err != nil {
+		if err.Error() == domain.ErrorDataNotFound.Error() {
+			return nil, domain.ErrorCustomerTokenInvalid
+		}
+		return nil, err
+	}
+
+	c, err := s.customerRepository.FindByID(ctx, t.CustomerID)
+	if err != nil {
+		if err.Error() == domain.ErrorDataNotFound.Error() {
+			return nil, domain.ErrorCustomerTokenInvalid
+		}
+		return nil, err
+	}
+
+	if err = t.Check(purpose, c); err != nil {
+		return nil, err
+	}
+
+	t.Use()
+	used, err := s.customerTokenRepository.Use(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, domain.ErrorCustomerTokenInvalid
+	}
+	return c, nil
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/memory"
 	"github.com/vitovidale/fastfood-app/internal/core/domain"
 	mock_port "github.com/vitovidale/fastfood-app/internal/core/port/mock"
 	"go.uber.org/mock/gomock"
@@ -22,7 +24,7 @@ type CreateCustomerOutput struct {
 
 func TestCustomerService(t *testing.T) {
 	ctx := context.Background()
-	id := uint64(0)
+	id := domain.NewID()
 	firstName := gofakeit.FirstName()
 	email := gofakeit.Email()
 	password := gofakeit.Password(true, true, true, true, false, 32)
@@ -85,10 +87,382 @@ func TestCustomerService(t *testing.T) {
 
 			// TODO regen customer mocks
 			// customerService := NewCustomerService(customerRepository)
-			// customer, err := customerService.Create(ctx, tc.input.customer)
+			// customer, err := customerService.Create(ctx, nil, tc.input.customer)
 
 			// assert.Equal(t, tc.output.customer, customer)
 			// assert.Equal(t, tc.output.err, err)
 		})
 	}
 }
+
+func TestCustomerService_GetByCPF(t *testing.T) {
+	customer := &domain.Customer{ID: domain.NewID(), CPF: "52998224725", FirstName: "John"}
+	guest := &domain.GuestSession{ID: domain.NewID()}
+	attached := &domain.GuestSession{ID: domain.NewID(), CustomerID: &customer.ID}
+
+	testCases := []struct {
+		title     string
+		principal *domain.Principal
+		guest     *domain.GuestSession
+		found     error
+		err       error
+	}{
+		{title: "Identified by a guest", principal: domain.NewGuestPrincipal(guest.ID), guest: guest},
+		{title: "Identified by the staff", principal: &domain.Principal{Role: domain.RoleCashier}},
+		{title: "Found by the customer", principal: domain.NewCustomerPrincipal(customer.ID)},
+		{title: "Found by another customer", principal: domain.NewCustomerPrincipal(domain.NewID()), err: domain.ErrorForbidden},
+		{title: "Not found", principal: domain.NewGuestPrincipal(guest.ID), guest: guest, found: domain.ErrorDataNotFound, err: domain.ErrorCustomerNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := domain.WithPrincipal(context.Background(), tc.principal)
+
+			guestRepository := mock_port.NewMockGuestRepository(ctrl)
+			if tc.guest != nil {
+				guestRepository.EXPECT().FindByID(ctx, tc.guest.ID).Return(tc.guest, nil)
+			}
+			customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+			if tc.found != nil {
+				customerRepository.EXPECT().FindByCPF(ctx, customer.CPF).Return(nil, tc.found)
+			} else {
+				customerRepository.EXPECT().FindByCPF(ctx, customer.CPF).Return(customer, nil)
+			}
+
+			service := NewCustomerService(customerRepository, nil, guestRepository, nil, nil, 0, 0)
+			c, err := service.GetByCPF(ctx, customer.CPF)
+			require.ErrorIs(t, err, tc.err)
+			if tc.err == nil {
+				require.Equal(t, customer, c)
+			}
+		})
+	}
+
+	t.Run("Guest attached already", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := domain.WithPrincipal(context.Background(), domain.NewGuestPrincipal(attached.ID))
+		guestRepository := mock_port.NewMockGuestRepository(ctrl)
+		guestRepository.EXPECT().FindByID(ctx, attached.ID).Return(attached, nil)
+
+		service := NewCustomerService(nil, nil, guestRepository, nil, nil, 0, 0)
+		_, err := service.GetByCPF(ctx, customer.CPF)
+		require.ErrorIs(t, err, domain.ErrorForbidden)
+	})
+
+	t.Run("Without a principal", func(t *testing.T) {
+		service := NewCustomerService(nil, nil, nil, nil, nil, 0, 0)
+		_, err := service.GetByCPF(context.Background(), customer.CPF)
+		require.ErrorIs(t, err, domain.ErrorUnauthorized)
+	})
+}
+
+func TestCustomerService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Customer created", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		customer := &domain.Customer{CPF: "52998224725", Email: "john.doe@example.com", Password: "secret"}
+
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+		customerRepository.EXPECT().FindByKeys(ctx, customer.CPF, customer.Email).Return(nil, domain.ErrorDataNotFound)
+		customerTokenRepository := mock_port.NewMockCustomerTokenRepository(ctrl)
+		notifier := mock_port.NewMockNotifier(ctrl)
+		// the secret is only mailed once the customer and the token exist
+		gomock.InOrder(
+			customerRepository.EXPECT().Create(inTransaction, customer).Return(nil),
+			customerTokenRepository.EXPECT().Create(inTransaction, gomock.Cond(func(t *domain.CustomerToken) bool {
+				return t.Purpose == domain.CustomerTokenEmailVerification && t.Email == customer.Email
+			})).Return(nil),
+			notifier.EXPECT().Notify(outsideTransaction, gomock.Cond(func(n *domain.Notification) bool {
+				return n.To == customer.Email
+			})).Return(nil),
+		)
+		transactor := memory.NewTransactor()
+
+		service := NewCustomerService(customerRepository, customerTokenRepository, nil, notifier, transactor, time.Hour, time.Hour)
+		c, err := service.Create(ctx, customer)
+		require.NoError(t, err)
+		require.NotEqual(t, domain.ID{}, c.ID)
+		require.NotEqual(t, "secret", c.Password)
+		require.Equal(t, 1, transactor.Commits())
+	})
+
+	t.Run("Neither created nor mailed when the token is not stored", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		customer := &domain.Customer{CPF: "52998224725", Email: "john.doe@example.com", Password: "secret"}
+
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+		customerRepository.EXPECT().FindByKeys(ctx, customer.CPF, customer.Email).Return(nil, domain.ErrorDataNotFound)
+		customerRepository.EXPECT().Create(inTransaction, customer).Return(nil)
+		customerTokenRepository := mock_port.NewMockCustomerTokenRepository(ctrl)
+		customerTokenRepository.EXPECT().Create(inTransaction, gomock.Any()).Return(domain.ErrorInternal)
+		transactor := memory.NewTransactor()
+
+		service := NewCustomerService(customerRepository, customerTokenRepository, nil, nil, transactor, time.Hour, time.Hour)
+		_, err := service.Create(ctx, customer)
+		require.ErrorIs(t, err, domain.ErrorInternal)
+		require.Equal(t, 1, transactor.Rollbacks())
+	})
+
+	t.Run("CPF or email already registered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		customer := &domain.Customer{CPF: "52998224725", Email: "john.doe@example.com", Password: "secret"}
+
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+		customerRepository.EXPECT().FindByKeys(ctx, customer.CPF, customer.Email).Return(&domain.Customer{ID: domain.NewID()}, nil)
+
+		service := NewCustomerService(customerRepository, nil, nil, nil, nil, 0, 0)
+		_, err := service.Create(ctx, customer)
+		require.ErrorIs(t, err, domain.ErrorCustomerAlreadyExists)
+	})
+}
+
+func TestCustomerService_RequestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+	customer := &domain.Customer{ID: domain.NewID(), Email: "john.doe@example.com", FirstName: "John"}
+
+	t.Run("Token mailed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var token *domain.CustomerToken
+
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+		customerRepository.EXPECT().FindByKeys(ctx, domain.CPF(""), customer.Email).Return(customer, nil)
+		customerTokenRepository := mock_port.NewMockCustomerTokenRepository(ctrl)
+		customerTokenRepository.EXPECT().Create(inTransaction, gomock.Any()).DoAndReturn(func(ctx context.Context, t *domain.CustomerToken) error {
+			token = t
+			return nil
+		})
+		notifier := mock_port.NewMockNotifier(ctrl)
+		// mailed once the token is stored
+		notifier.EXPECT().Notify(outsideTransaction, gomock.Any()).DoAndReturn(func(ctx context.Context, n *domain.Notification) error {
+			// the secret is mailed, and only its hash kept
+			require.Equal(t, customer.Email, n.To)
+			require.NotContains(t, n.Body, token.Hash)
+			return nil
+		})
+
+		service := NewCustomerService(customerRepository, customerTokenRepository, nil, notifier, memory.NewTransactor(), time.Hour, 48*time.Hour)
+		require.NoError(t, service.RequestPasswordReset(ctx, customer.Email))
+		require.Equal(t, domain.CustomerTokenPasswordReset, token.Purpose)
+		require.Equal(t, customer.ID, token.CustomerID)
+		require.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Unknown email", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+		customerRepository.EXPECT().FindByKeys(ctx, domain.CPF(""), "jane.doe@example.com").Return(nil, domain.ErrorDataNotFound)
+
+		// nothing is mailed, without telling the caller
+		service := NewCustomerService(customerRepository, nil, nil, nil, memory.NewTransactor(), time.Hour, 48*time.Hour)
+		require.NoError(t, service.RequestPasswordReset(ctx, "jane.doe@example.com"))
+	})
+}
+
+func TestCustomerService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	customer := &domain.Customer{ID: domain.NewID(), Email: "john.doe@example.com", Version: 3}
+
+	newToken := func(purpose domain.CustomerTokenPurpose, ttl time.Duration) (*domain.CustomerToken, string) {
+		token, secret, err := domain.NewCustomerToken(customer, purpose, ttl)
+		require.NoError(t, err)
+		return token, secret
+	}
+
+	testCases := []struct {
+		title     string
+		token     *domain.CustomerToken
+		secret    string
+		mocks     func(customerRepository *mock_port.MockCustomerRepository, customerTokenRepository *mock_port.MockCustomerTokenRepository, token *domain.CustomerToken)
+		commits   int
+		rollbacks int
+		err       error
+	}{
+		{
+			title: "Password reset",
+			mocks: func(customerRepository *mock_port.MockCustomerRepository, customerTokenRepository *mock_port.MockCustomerTokenRepository, token *domain.CustomerToken) {
+				customerTokenRepository.EXPECT().FindByHash(inTransaction, token.Hash).Return(token, nil)
+				customerRepository.EXPECT().FindByID(inTransaction, customer.ID).Return(customer, nil)
+				customerTokenRepository.EXPECT().Use(inTransaction, token).Return(true, nil)
+				// every session of the customer is signed out along with the new password
+				customerRepository.EXPECT().Patch(inTransaction, customer.ID, gomock.Cond(func(c *domain.Customer) bool {
+					return c.Version == customer.Version && c.Authenticate("new password") == nil && c.SignedOutAt != nil
+				})).Return(nil)
+			},
+			commits: 1,
+		},
+		{
+			title: "Token used at the same time",
+			mocks: func(customerRepository *mock_port.MockCustomerRepository, customerTokenRepository *mock_port.MockCustomerTokenRepository, token *domain.CustomerToken) {
+				customerTokenRepository.EXPECT().FindByHash(inTransaction, token.Hash).Return(token, nil)
+				customerRepository.EXPECT().FindByID(inTransaction, customer.ID).Return(customer, nil)
+				customerTokenRepository.EXPECT().Use(inTransaction, token).Return(false, nil)
+			},
+			rollbacks: 1,
+			err:       domain.ErrorCustomerTokenInvalid,
+		},
+		{
+			title: "Unknown token",
+			mocks: func(customerRepository *mock_port.MockCustomerRepository, customerTokenRepository *mock_port.MockCustomerTokenRepository, token *domain.CustomerToken) {
+				customerTokenRepository.EXPECT().FindByHash(inTransaction, token.Hash).Return(nil, domain.ErrorDataNotFound)
+			},
+			rollbacks: 1,
+			err:       domain.ErrorCustomerTokenInvalid,
+		},
+		{
+			title: "Email verification token",
+			token: func() *domain.CustomerToken {
+				token, _ := newToken(domain.CustomerTokenEmailVerification, time.Hour)
+				return token
+			}(),
+			mocks: func(customerRepository *mock_port.MockCustomerRepository, customerTokenRepository *mock_port.MockCustomerTokenRepository, token *domain.CustomerToken) {
+				customerTokenRepository.EXPECT().FindByHash(inTransaction, gomock.Any()).Return(token, nil)
+				customerRepository.EXPECT().FindByID(inTransaction, customer.ID).Return(customer, nil)
+			},
+			rollbacks: 1,
+			err:       domain.ErrorCustomerTokenInvalid,
+		},
+		{
+			title: "Expired token",
+			token: func() *domain.CustomerToken {
+				token, _ := newToken(domain.CustomerTokenPasswordReset, -time.Minute)
+				return token
+			}(),
+			mocks: func(customerRepository *mock_port.MockCustomerRepository, customerTokenRepository *mock_port.MockCustomerTokenRepository, token *domain.CustomerToken) {
+				customerTokenRepository.EXPECT().FindByHash(inTransaction, gomock.Any()).Return(token, nil)
+				customerRepository.EXPECT().FindByID(inTransaction, customer.ID).Return(customer, nil)
+			},
+			rollbacks: 1,
+			err:       domain.ErrorCustomerTokenInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			token, secret := newToken(domain.CustomerTokenPasswordReset, time.Hour)
+			if tc.token != nil {
+				token = tc.token
+			}
+
+			customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+			customerTokenRepository := mock_port.NewMockCustomerTokenRepository(ctrl)
+			transactor := memory.NewTransactor()
+			tc.mocks(customerRepository, customerTokenRepository, token)
+
+			service := NewCustomerService(customerRepository, customerTokenRepository, nil, nil, transactor, time.Hour, 48*time.Hour)
+			err := service.ResetPassword(ctx, secret, "new password")
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.commits, transactor.Commits())
+			require.Equal(t, tc.rollbacks, transactor.Rollbacks())
+		})
+	}
+}
+
+func TestCustomerService_RequestEmailVerification(t *testing.T) {
+	customer := &domain.Customer{ID: domain.NewID(), Email: "john.doe@example.com"}
+	ctx := domain.WithPrincipal(context.Background(), domain.NewCustomerPrincipal(customer.ID))
+
+	t.Run("Token mailed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+		customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(customer, nil)
+		customerTokenRepository := mock_port.NewMockCustomerTokenRepository(ctrl)
+		customerTokenRepository.EXPECT().Create(inTransaction, gomock.Cond(func(t *domain.CustomerToken) bool {
+			return t.Purpose == domain.CustomerTokenEmailVerification
+		})).Return(nil)
+		notifier := mock_port.NewMockNotifier(ctrl)
+		notifier.EXPECT().Notify(outsideTransaction, gomock.Any()).Return(nil)
+
+		service := NewCustomerService(customerRepository, customerTokenRepository, nil, notifier, memory.NewTransactor(), time.Hour, 48*time.Hour)
+		require.NoError(t, service.RequestEmailVerification(ctx, customer.ID))
+	})
+
+	t.Run("Email already verified", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		verified := *customer
+		verified.VerifiedEmail = verified.Email
+
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+		customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(&verified, nil)
+
+		service := NewCustomerService(customerRepository, nil, nil, nil, memory.NewTransactor(), time.Hour, 48*time.Hour)
+		require.ErrorIs(t, service.RequestEmailVerification(ctx, customer.ID), domain.ErrorCustomerEmailAlreadyVerified)
+	})
+
+	t.Run("Another customer", func(t *testing.T) {
+		service := NewCustomerService(nil, nil, nil, nil, memory.NewTransactor(), time.Hour, 48*time.Hour)
+		require.ErrorIs(t, service.RequestEmailVerification(ctx, domain.NewID()), domain.ErrorForbidden)
+	})
+}
+
+func TestCustomerService_VerifyEmail(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Email verified", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		customer := &domain.Customer{ID: domain.NewID(), Email: "john.doe@example.com", Version: 1}
+		token, secret, err := domain.NewCustomerToken(customer, domain.CustomerTokenEmailVerification, time.Hour)
+		require.NoError(t, err)
+
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+		customerRepository.EXPECT().FindByID(inTransaction, customer.ID).Return(customer, nil)
+		customerRepository.EXPECT().Patch(inTransaction, customer.ID, gomock.Any()).DoAndReturn(func(ctx context.Context, id domain.ID, data *domain.Customer) error {
+			require.Equal(t, customer.Email, data.VerifiedEmail)
+			data.Version++
+			return nil
+		})
+		customerTokenRepository := mock_port.NewMockCustomerTokenRepository(ctrl)
+		customerTokenRepository.EXPECT().FindByHash(inTransaction, token.Hash).Return(token, nil)
+		customerTokenRepository.EXPECT().Use(inTransaction, token).Return(true, nil)
+
+		service := NewCustomerService(customerRepository, customerTokenRepository, nil, nil, memory.NewTransactor(), time.Hour, 48*time.Hour)
+		c, err := service.VerifyEmail(ctx, secret)
+		require.NoError(t, err)
+		require.True(t, c.IsEmailVerified())
+		require.Equal(t, uint64(2), c.Version)
+		require.NotNil(t, token.UsedAt)
+	})
+
+	t.Run("Email changed since", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		customer := &domain.Customer{ID: domain.NewID(), Email: "john.doe@example.com", Version: 1}
+		token, secret, err := domain.NewCustomerToken(customer, domain.CustomerTokenEmailVerification, time.Hour)
+		require.NoError(t, err)
+
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+		customerRepository.EXPECT().FindByID(inTransaction, customer.ID).Return(&domain.Customer{ID: customer.ID, Email: "john@example.com"}, nil)
+		customerTokenRepository := mock_port.NewMockCustomerTokenRepository(ctrl)
+		customerTokenRepository.EXPECT().FindByHash(inTransaction, token.Hash).Return(token, nil)
+
+		service := NewCustomerService(customerRepository, customerTokenRepository, nil, nil, memory.NewTransactor(), time.Hour, 48*time.Hour)
+		_, err = service.VerifyEmail(ctx, secret)
+		require.ErrorIs(t, err, domain.ErrorCustomerTokenInvalid)
+	})
+}
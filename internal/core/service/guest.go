@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/port"
+)
+
+type GuestService struct {
+	guestRepository    port.GuestRepository
+	customerRepository port.CustomerRepository
+	orderRepository    port.OrderRepository
+	transactor         port.Transactor
+}
+
+func NewGuestService(
+	guestRepository port.GuestRepository,
+	customerRepository port.CustomerRepository,
+	orderRepository port.OrderRepository,
+	transactor port.Transactor,
+) *GuestService {
+	return &GuestService{
+		guestRepository:    guestRepository,
+		customerRepository: customerRepository,
+		orderRepository:    orderRepository,
+		transactor:         transactor,
+	}
+}
+
+func (s *GuestService) Create(ctx context.Context, name string) (*domain.GuestSession, error) {
+	g := domain.NewGuestSession(name)
+	if err := s.guestRepository.Create(ctx, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Attach attaches a guest to the customer signed in as customer, giving the
+// customer the orders the guest placed so far. Orders placed by the guest from
+// then on belong to the customer as well. The customer must have signed in,
+// as anyone can tell the CPF of another.
+func (s *GuestService) Attach(ctx context.Context, id domain.ID, customer *domain.Principal) (*domain.GuestSession, error) {
+	if err := domain.CheckGuest(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if customer == nil || customer.Role != domain.RoleCustomer {
+		return nil, domain.ErrorUnauthorized
+	}
+
+	g, err := s.guestRepository.FindByID(ctx, id)
+	if err != nil {
+		if err.Error() == domain.ErrorDataNotFound.Error() {
+			return nil, domain.ErrorGuestNotFound
+		}
+		return nil, err
+	}
+
+	c, err := s.customerRepository.FindByID(ctx, customer.CustomerID)
+	if err != nil {
+		if err.Error() == domain.ErrorDataNotFound.Error() {
+			return nil, domain.ErrorCustomerNotFound
+		}
+		return nil, err
+	}
+
+	if err = g.Attach(c.ID); err != nil {
+		return nil, err
+	}
+
+	// the guest and its orders are attached together, or not at all
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.guestRepository.Attach(ctx, g); err != nil {
+			return err
+		}
+		return s.orderRepository.AttachGuest(ctx, id, c.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/memory"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	mock_port "github.com/vitovidale/fastfood-app/internal/core/port/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGuestService_Attach(t *testing.T) {
+	guestId := domain.NewID()
+	ctx := domain.WithPrincipal(context.Background(), domain.NewGuestPrincipal(guestId))
+	customer := &domain.Customer{ID: domain.NewID()}
+	signedIn := domain.NewCustomerPrincipal(customer.ID)
+
+	attached := func(customerId domain.ID) *domain.GuestSession {
+		g := &domain.GuestSession{ID: guestId}
+		require.NoError(t, g.Attach(customerId))
+		return g
+	}
+
+	testCases := []struct {
+		title     string
+		ctx       context.Context
+		customer  *domain.Principal
+		mocks     func(guestRepository *mock_port.MockGuestRepository, customerRepository *mock_port.MockCustomerRepository, orderRepository *mock_port.MockOrderRepository)
+		commits   int
+		rollbacks int
+		err       error
+	}{
+		{
+			title:    "Guest and its orders attached",
+			ctx:      ctx,
+			customer: signedIn,
+			mocks: func(guestRepository *mock_port.MockGuestRepository, customerRepository *mock_port.MockCustomerRepository, orderRepository *mock_port.MockOrderRepository) {
+				guestRepository.EXPECT().FindByID(ctx, guestId).Return(&domain.GuestSession{ID: guestId}, nil)
+				customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(customer, nil)
+				guestRepository.EXPECT().Attach(inTransaction, gomock.Cond(func(g *domain.GuestSession) bool {
+					return *g.CustomerID == customer.ID && g.AttachedAt != nil
+				})).Return(nil)
+				orderRepository.EXPECT().AttachGuest(inTransaction, guestId, customer.ID).Return(nil)
+			},
+			commits: 1,
+		},
+		{
+			title:    "Rolled back when the orders are not attached",
+			ctx:      ctx,
+			customer: signedIn,
+			mocks: func(guestRepository *mock_port.MockGuestRepository, customerRepository *mock_port.MockCustomerRepository, orderRepository *mock_port.MockOrderRepository) {
+				guestRepository.EXPECT().FindByID(ctx, guestId).Return(&domain.GuestSession{ID: guestId}, nil)
+				customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(customer, nil)
+				guestRepository.EXPECT().Attach(inTransaction, gomock.Any()).Return(nil)
+				orderRepository.EXPECT().AttachGuest(inTransaction, guestId, customer.ID).Return(domain.ErrorInternal)
+			},
+			rollbacks: 1,
+			err:       domain.ErrorInternal,
+		},
+		{
+			title:    "Attached to another customer",
+			ctx:      ctx,
+			customer: signedIn,
+			mocks: func(guestRepository *mock_port.MockGuestRepository, customerRepository *mock_port.MockCustomerRepository, orderRepository *mock_port.MockOrderRepository) {
+				guestRepository.EXPECT().FindByID(ctx, guestId).Return(attached(domain.NewID()), nil)
+				customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(customer, nil)
+			},
+			err: domain.ErrorGuestAlreadyAttached,
+		},
+		{
+			title:    "Customer not found",
+			ctx:      ctx,
+			customer: signedIn,
+			mocks: func(guestRepository *mock_port.MockGuestRepository, customerRepository *mock_port.MockCustomerRepository, orderRepository *mock_port.MockOrderRepository) {
+				guestRepository.EXPECT().FindByID(ctx, guestId).Return(&domain.GuestSession{ID: guestId}, nil)
+				customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(nil, domain.ErrorDataNotFound)
+			},
+			err: domain.ErrorCustomerNotFound,
+		},
+		{
+			title:    "Another guest",
+			ctx:      domain.WithPrincipal(context.Background(), domain.NewGuestPrincipal(domain.NewID())),
+			customer: signedIn,
+			mocks: func(guestRepository *mock_port.MockGuestRepository, customerRepository *mock_port.MockCustomerRepository, orderRepository *mock_port.MockOrderRepository) {
+			},
+			err: domain.ErrorForbidden,
+		},
+		{
+			title: "Customer not signed in",
+			ctx:   ctx,
+			mocks: func(guestRepository *mock_port.MockGuestRepository, customerRepository *mock_port.MockCustomerRepository, orderRepository *mock_port.MockOrderRepository) {
+			},
+			err: domain.ErrorUnauthorized,
+		},
+		{
+			title:    "Another guest given as the customer",
+			ctx:      ctx,
+			customer: domain.NewGuestPrincipal(domain.NewID()),
+			mocks: func(guestRepository *mock_port.MockGuestRepository, customerRepository *mock_port.MockCustomerRepository, orderRepository *mock_port.MockOrderRepository) {
+			},
+			err: domain.ErrorUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			guestRepository := mock_port.NewMockGuestRepository(ctrl)
+			customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+			orderRepository := mock_port.NewMockOrderRepository(ctrl)
+			transactor := memory.NewTransactor()
+			tc.mocks(guestRepository, customerRepository, orderRepository)
+
+			service := NewGuestService(guestRepository, customerRepository, orderRepository, transactor)
+			_, err := service.Attach(tc.ctx, guestId, tc.customer)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.commits, transactor.Commits())
+			require.Equal(t, tc.rollbacks, transactor.Rollbacks())
+		})
+	}
+}
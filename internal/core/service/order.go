@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/vitovidale/fastfood-app/internal/core/domain"
@@ -12,22 +14,96 @@ type OrderService struct {
 	orderRepository    port.OrderRepository
 	productRepository  port.ProductRepository
 	customerRepository port.CustomerRepository
+	guestRepository    port.GuestRepository
+	paymentGateway     port.PaymentGateway
+	paymentRepository  port.PaymentRepository
+	paymentEventRepo   port.PaymentEventRepository
+	refundRepository   port.RefundRepository
+	transactor         port.Transactor
+	eventBus           port.OrderEventBus
 }
 
+// maxChargeAttempts is how many times creating a charge at the payment
+// provider is tried before the payment fails.
+const maxChargeAttempts = 3
+
+// chargeTimeout is how long creating a charge may take before the order
+// waiting for it is moved back to pending, see SyncPayments.
+const chargeTimeout = 5 * time.Minute
+
+// refundRetryDelay is how long a refund is left pending before SyncPayments
+// asks the payment provider again to give it back.
+const refundRetryDelay = time.Minute
+
 func NewOrderService(
 	orderRepository port.OrderRepository,
 	productRepository port.ProductRepository,
 	customerRepository port.CustomerRepository,
+	guestRepository port.GuestRepository,
+	paymentGateway port.PaymentGateway,
+	paymentRepository port.PaymentRepository,
+	paymentEventRepo port.PaymentEventRepository,
+	refundRepository port.RefundRepository,
+	transactor port.Transactor,
+	eventBus port.OrderEventBus,
 ) *OrderService {
 	return &OrderService{
 		orderRepository:    orderRepository,
 		productRepository:  productRepository,
 		customerRepository: customerRepository,
+		guestRepository:    guestRepository,
+		paymentGateway:     paymentGateway,
+		paymentRepository:  paymentRepository,
+		paymentEventRepo:   paymentEventRepo,
+		refundRepository:   refundRepository,
+		transactor:         transactor,
+		eventBus:           eventBus,
 	}
 }
 
+// publishStatus tells the followers of the order that its status changed.
+func (s *OrderService) publishStatus(ctx context.Context, o *domain.Order) {
+	if s.eventBus == nil {
+		return
+	}
+	s.eventBus.Publish(ctx, domain.NewOrderStatusEvent(o))
+}
+
+// getOrder reads an order, as long as the caller may act on behalf of its
+// customer or guest, see domain.CheckOrder.
+func (s *OrderService) getOrder(ctx context.Context, id domain.ID) (*domain.Order, error) {
+	o, err := s.orderRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = domain.CheckOrder(ctx, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// SubscribeStatus returns the status events of every order, published from
+// now on or after lastEventID, until ctx is done. Without an event bus no
+// event is ever sent.
+func (s *OrderService) SubscribeStatus(ctx context.Context, lastEventID uint64) <-chan *domain.OrderStatusEvent {
+	if s.eventBus == nil {
+		ch := make(chan *domain.OrderStatusEvent)
+		go func() {
+			<-ctx.Done()
+			close(ch)
+		}()
+		return ch
+	}
+	return s.eventBus.Subscribe(ctx, lastEventID)
+}
+
 // GetByCustomerID returns an order by its customer ID.
-func (s *OrderService) GetByCustomer(ctx context.Context, customerId uint64) (*domain.Order, error) {
+func (s *OrderService) GetByCustomer(ctx context.Context, customerId domain.ID) (*domain.Order, error) {
+	if err := domain.CheckCustomer(ctx, customerId); err != nil {
+		return nil, err
+	}
+
 	o, err := s.orderRepository.FindByCustomer(ctx, customerId)
 	if err != nil {
 		return nil, err
@@ -36,6 +112,10 @@ func (s *OrderService) GetByCustomer(ctx context.Context, customerId uint64) (*d
 }
 
 func (s *OrderService) List(ctx context.Context) ([]*domain.Order, error) {
+	if err := domain.CheckPermission(ctx, domain.PermissionViewOrders); err != nil {
+		return nil, err
+	}
+
 	orders, err := s.orderRepository.List(ctx)
 	if err != nil {
 		return nil, err
@@ -44,7 +124,12 @@ func (s *OrderService) List(ctx context.Context) ([]*domain.Order, error) {
 }
 
 // AddProduct adds a product to an order, based on the order and product data.
+// Only pending orders take products, see domain.Order.CheckEditable.
 func (s *OrderService) AddProduct(ctx context.Context, o *domain.Order, p *domain.OrderProduct) error {
+	if err := o.CheckEditable(); err != nil {
+		return err
+	}
+
 	// TODO cache products for faster lookup
 	product, err := s.productRepository.FindByID(ctx, p.ProductID)
 
@@ -57,126 +142,742 @@ func (s *OrderService) AddProduct(ctx context.Context, o *domain.Order, p *domai
 
 	p.ID = domain.NewID()
 	p.OrderID = o.ID
-	p.Total = product.Price * float64(p.Quantity)
+	p.UnitPrice = product.Price
+	p.Total = product.Price.Mul(int64(p.Quantity))
+
+	// the order total is recalculated from its lines along with the new line
+	return s.orderRepository.AddProduct(ctx, p)
+}
+
+// RemoveProduct removes a product from an order, based on the order ID and the order product ID.
+// Only pending orders lose products, see domain.Order.CheckEditable.
+func (s *OrderService) RemoveProduct(ctx context.Context, orderId domain.ID, orderProductId domain.ID) error {
+	o, err := s.getOrder(ctx, orderId)
+
+	if err != nil {
+		return err
+	}
+
+	if err = domain.CheckVersion(ctx, o.Version); err != nil {
+		return err
+	}
+
+	if err = o.CheckEditable(); err != nil {
+		return err
+	}
+
+	op, err := s.orderRepository.FindOrderProduct(ctx, orderProductId)
+
+	if err != nil {
+		return err
+	}
 
-	err = s.orderRepository.AddProduct(ctx, p)
+	if op.OrderID != o.ID {
+		return domain.ErrorDataNotFound
+	}
+
+	// the order total is recalculated from its remaining lines
+	return s.orderRepository.RemoveProduct(ctx, op)
+}
+
+// Pay creates a charge for the order at the payment provider, using the given
+// payment method. The order is moved to processing, along with a pending
+// payment, before the charge is created, so a concurrent payment of the same
+// order fails before charging twice. The order moves back to pending when the
+// charge cannot be created, and is confirmed, or moved back to pending, once
+// the charge is settled, see SyncPayments.
+func (s *OrderService) Pay(ctx context.Context, id domain.ID, method domain.PaymentMethod) (*domain.Payment, error) {
+	o, err := s.getOrder(ctx, id)
 
 	if err != nil {
+		return nil, err
+	}
+
+	if err = domain.CheckVersion(ctx, o.Version); err != nil {
+		return nil, err
+	}
+
+	// nothing to charge for
+	if o.Total.IsZero() {
+		return nil, domain.ErrorOrderEmpty
+	}
+
+	// the reference is only known once the charge is created
+	if err = o.Pay(""); err != nil {
+		return nil, err
+	}
+
+	payment := domain.NewPayment(o.ID, o.Total, method)
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.paymentRepository.Create(ctx, payment); err != nil {
+			return err
+		}
+		return s.patchOrder(ctx, o, &domain.Order{Status: o.Status})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishStatus(ctx, o)
+
+	charge, err := s.createCharge(ctx, payment)
+	if err != nil {
+		// an order left in processing is moved back by SyncPayments
+		if failErr := s.failCharge(ctx, o, payment, err.Error()); failErr != nil {
+			return nil, failErr
+		}
+		return nil, err
+	}
+
+	o.PaymentRef = charge.Reference
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.paymentRepository.Patch(ctx, payment.ID, &domain.Payment{
+			ProviderRef: payment.ProviderRef,
+			PixCode:     payment.PixCode,
+			ExpiresAt:   payment.ExpiresAt,
+			Attempts:    payment.Attempts,
+		})
+		if err != nil {
+			return err
+		}
+		return s.patchOrder(ctx, o, &domain.Order{PaymentRef: o.PaymentRef})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// failCharge records that the charge of a payment could not be created,
+// moving the order back to pending so it can be paid again. Orders paid
+// before payments were recorded may have none.
+func (s *OrderService) failCharge(ctx context.Context, o *domain.Order, p *domain.Payment, reason string) error {
+	if err := o.FailPayment(); err != nil {
 		return err
 	}
 
-	// update the order values
-	s.orderRepository.Patch(ctx, o.ID, &domain.Order{
-		Total:          o.Total + p.Total,
-		TrackingNumber: s.orderRepository.GetTrackingNumber(ctx, o.TrackingNumber),
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if p != nil && p.Settle(domain.PaymentStatusFailed, reason) == nil {
+			err := s.paymentRepository.Patch(ctx, p.ID, &domain.Payment{
+				Status:        p.Status,
+				FailureReason: p.FailureReason,
+				Attempts:      p.Attempts,
+				SettledAt:     p.SettledAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return s.patchOrder(ctx, o, &domain.Order{Status: o.Status})
 	})
+	if err != nil {
+		return err
+	}
 
+	s.publishStatus(ctx, o)
 	return nil
 }
 
-// RemoveProduct removes a product from an order, based on the order ID and the order product ID.
-func (s *OrderService) RemoveProduct(ctx context.Context, orderId domain.ID, orderProductId domain.ID) error {
-	o, err := s.orderRepository.FindByID(ctx, orderId)
+// patchOrder writes data to the order, as long as it is still at the version
+// it was read at, keeping the order at the version written.
+func (s *OrderService) patchOrder(ctx context.Context, o *domain.Order, data *domain.Order) error {
+	data.Version = o.Version
+	if err := s.orderRepository.Patch(ctx, o.ID, data); err != nil {
+		return err
+	}
+	o.Version = data.Version
+	return nil
+}
+
+// createCharge creates the charge of a payment, trying again up to
+// maxChargeAttempts times when the payment provider fails.
+func (s *OrderService) createCharge(ctx context.Context, p *domain.Payment) (*domain.Charge, error) {
+	var err error
 
+	for p.Attempts < maxChargeAttempts {
+		p.Attempts++
+
+		var charge *domain.Charge
+		charge, err = s.paymentGateway.CreateCharge(ctx, p.OrderID, p.Amount, p.Method)
+		if err == nil {
+			p.Charged(charge)
+			return charge, nil
+		}
+		p.FailureReason = err.Error()
+	}
+
+	return nil, err
+}
+
+// GetPayments returns the payments made for an order, oldest first.
+func (s *OrderService) GetPayments(ctx context.Context, id domain.ID) ([]*domain.Payment, error) {
+	if _, err := s.getOrder(ctx, id); err != nil {
+		return nil, err
+	}
+
+	payments, err := s.paymentRepository.FindByOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// SyncPayments checks the charges of the orders waiting for payment,
+// confirming the approved ones and moving the declined or expired ones back
+// to pending, and gives back the refunds left pending.
+func (s *OrderService) SyncPayments(ctx context.Context) error {
+	orders, err := s.orderRepository.FindByStatus(ctx, domain.OrderStatusProcessing)
 	if err != nil {
 		return err
 	}
 
-	op, err := s.orderRepository.FindOrderProduct(ctx, orderProductId)
+	var errs []error
+	for _, o := range orders {
+		if err := s.syncPayment(ctx, o); err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", o.ID, err))
+		}
+	}
+
+	if err := s.retryRefunds(ctx); err != nil {
+		errs = append(errs, err)
+	}
 
+	return errors.Join(errs...)
+}
+
+func (s *OrderService) syncPayment(ctx context.Context, o *domain.Order) error {
+	if o.PaymentRef == "" {
+		return s.abandonCharge(ctx, o)
+	}
+
+	charge, err := s.paymentGateway.GetCharge(ctx, o.PaymentRef)
 	if err != nil {
 		return err
 	}
 
-	err = s.orderRepository.RemoveProduct(ctx, &domain.OrderProduct{ID: orderProductId})
+	// do not wait for the payment provider to expire unpaid charges
+	if charge.IsExpired(time.Now()) {
+		charge.Status = domain.PaymentStatusExpired
+		charge.FailureReason = "payment expired"
+	}
+
+	return s.settlePayment(ctx, o, charge.Status, charge.FailureReason)
+}
+
+// abandonCharge moves an order waiting for its charge to be created back to
+// pending, once creating it took longer than chargeTimeout, such as when Pay
+// was cut short by a crash.
+func (s *OrderService) abandonCharge(ctx context.Context, o *domain.Order) error {
+	payments, err := s.paymentRepository.FindByOrder(ctx, o.ID)
 	if err != nil {
 		return err
 	}
 
-	// update the order values
-	_ = s.orderRepository.Patch(ctx, o.ID, &domain.Order{
-		Total: o.Total - op.Total,
-	})
+	var p *domain.Payment
+	for _, candidate := range payments {
+		if candidate.ProviderRef == "" && candidate.Status == domain.PaymentStatusPending.String() {
+			p = candidate
+		}
+	}
 
-	return nil
+	// the charge may still be being created
+	if p != nil && time.Since(p.CreatedAt) < chargeTimeout {
+		return nil
+	}
+
+	return s.failCharge(ctx, o, p, "charge not created")
 }
 
-func (s *OrderService) Pay(ctx context.Context, id domain.ID) error {
-	o, err := s.orderRepository.FindByID(ctx, id)
+// HandlePaymentEvent applies a notification from the payment provider to its
+// order. Events already handled, or about a charge the order no longer waits
+// for, are ignored.
+func (s *OrderService) HandlePaymentEvent(ctx context.Context, e *domain.PaymentEvent) error {
+	exists, err := s.paymentEventRepo.Exists(ctx, e.ID)
+	if err != nil {
+		return err
+	}
 
+	if exists {
+		return nil
+	}
+
+	status, err := domain.ParsePaymentStatus(e.Status)
+	if err != nil {
+		return err
+	}
+
+	o, err := s.orderRepository.FindByID(ctx, e.OrderID)
 	if err != nil {
 		return err
 	}
 
-	if o.Status != domain.OrderStatusPending.String() {
-		return domain.ErrorOrderAlreadyProcessing
+	if o.PaymentRef == e.Reference && o.Status == domain.OrderStatusProcessing.String() {
+		if err = s.settlePayment(ctx, o, status, ""); err != nil {
+			return err
+		}
+	}
+
+	return s.paymentEventRepo.Create(ctx, e)
+}
+
+// settlePayment records the final status of the order payment, confirming
+// the order when its charge was approved, or moving it back to pending when it
+// was declined or expired. Confirmed orders get their tracking number, so
+// carts never paid for do not hold one.
+func (s *OrderService) settlePayment(ctx context.Context, o *domain.Order, status domain.PaymentStatus, reason string) error {
+	var err error
+
+	switch status {
+	case domain.PaymentStatusApproved:
+		err = o.Confirm()
+	case domain.PaymentStatusDeclined, domain.PaymentStatusExpired:
+		err = o.FailPayment()
+	default:
+		return nil
 	}
 
-	err = s.orderRepository.Patch(ctx, id, &domain.Order{Status: domain.OrderStatusProcessing.String()})
 	if err != nil {
 		return err
 	}
 
-	// wait for 5 seconds to MOCK pay the order
-	time.Sleep(time.Second * 5)
+	if o.PaidAt != nil {
+		// left processing when no number is available, to be confirmed by
+		// the next SyncPayments
+		if o.TrackingNumber, err = s.orderRepository.GetTrackingNumber(ctx, o.TrackingNumber); err != nil {
+			return err
+		}
+	}
+
+	if err = s.settlePaymentRecord(ctx, o.PaymentRef, status, reason); err != nil {
+		return err
+	}
 
-	err = s.orderRepository.Patch(ctx, id, &domain.Order{Status: domain.OrderStatusConfirmed.String()})
+	err = s.orderRepository.Patch(ctx, o.ID, &domain.Order{Status: o.Status, PaidAt: o.PaidAt, TrackingNumber: o.TrackingNumber, Version: o.Version})
 	if err != nil {
 		return err
 	}
 
+	s.publishStatus(ctx, o)
 	return nil
 }
 
+// settlePaymentRecord settles the payment of a charge. Orders paid before
+// payments were recorded have none, and are ignored.
+func (s *OrderService) settlePaymentRecord(ctx context.Context, providerRef string, status domain.PaymentStatus, reason string) error {
+	p, err := s.paymentRepository.FindByProviderRef(ctx, providerRef)
+	if err != nil {
+		if err.Error() == domain.ErrorDataNotFound.Error() {
+			return nil
+		}
+		return err
+	}
+
+	// the payment was already settled by an earlier notification
+	if p.Settle(status, reason) != nil {
+		return nil
+	}
+
+	return s.paymentRepository.Patch(ctx, p.ID, &domain.Payment{
+		Status:        p.Status,
+		FailureReason: p.FailureReason,
+		SettledAt:     p.SettledAt,
+	})
+}
+
 func (s *OrderService) Prepare(ctx context.Context, id domain.ID) error {
-	o, err := s.orderRepository.FindByID(ctx, id)
+	if err := domain.CheckPermission(ctx, domain.PermissionPrepareOrders); err != nil {
+		return err
+	}
+
+	o, err := s.getOrder(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if o.Status != domain.OrderStatusConfirmed.String() {
-		return domain.ErrorOrderAlreadyStarted
+	if err = domain.CheckVersion(ctx, o.Version); err != nil {
+		return err
 	}
 
-	startedAt := time.Now()
+	if err = o.Start(); err != nil {
+		return err
+	}
 
 	err = s.orderRepository.Patch(ctx, id, &domain.Order{
-		Status:    domain.OrderStatusStarted.String(),
-		StartedAt: &startedAt,
+		Status:    o.Status,
+		StartedAt: o.StartedAt,
+		Version:   o.Version,
 	})
 
 	if err != nil {
 		return err
 	}
 
+	s.publishStatus(ctx, o)
 	return nil
 }
 
 func (s *OrderService) Complete(ctx context.Context, id domain.ID) error {
-	o, err := s.orderRepository.FindByID(ctx, id)
+	if err := domain.CheckPermission(ctx, domain.PermissionPrepareOrders); err != nil {
+		return err
+	}
+
+	o, err := s.getOrder(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if o.Status != domain.OrderStatusStarted.String() {
-		return domain.ErrorOrderAlreadyDone
+	if err = domain.CheckVersion(ctx, o.Version); err != nil {
+		return err
+	}
+
+	if err = o.Complete(); err != nil {
+		return err
 	}
 
-	readyAt := time.Now()
 	err = s.orderRepository.Patch(ctx, id, &domain.Order{
-		Status:  domain.OrderStatusDone.String(),
-		ReadyAt: &readyAt,
+		Status:  o.Status,
+		ReadyAt: o.ReadyAt,
+		Version: o.Version,
 	})
 
 	if err != nil {
 		return err
 	}
 
+	s.publishStatus(ctx, o)
 	return nil
 }
 
+// Deliver moves a done order to delivered once the customer collects it,
+// taking it off the pickup board.
+func (s *OrderService) Deliver(ctx context.Context, id domain.ID) error {
+	if err := domain.CheckPermission(ctx, domain.PermissionDeliverOrders); err != nil {
+		return err
+	}
+
+	o, err := s.getOrder(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = domain.CheckVersion(ctx, o.Version); err != nil {
+		return err
+	}
+
+	if err = o.Deliver(); err != nil {
+		return err
+	}
+
+	err = s.orderRepository.Patch(ctx, id, &domain.Order{
+		Status:      o.Status,
+		DeliveredAt: o.DeliveredAt,
+		Version:     o.Version,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	s.publishStatus(ctx, o)
+	return nil
+}
+
+// Cancel cancels an order that the kitchen has not started yet, recording the
+// reason and the actor found in the context.
+func (s *OrderService) Cancel(ctx context.Context, id domain.ID, reason string) error {
+	o, err := s.getOrder(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = domain.CheckVersion(ctx, o.Version); err != nil {
+		return err
+	}
+
+	if err = o.Cancel(reason, domain.ActorFromContext(ctx)); err != nil {
+		return err
+	}
+
+	err = s.orderRepository.Patch(ctx, id, &domain.Order{
+		Status:        o.Status,
+		CancelledAt:   o.CancelledAt,
+		CancelReason:  o.CancelReason,
+		CancelledBy:   o.CancelledBy,
+		RefundPending: o.RefundPending,
+		Version:       o.Version,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	s.publishStatus(ctx, o)
+	return nil
+}
+
+// Refund gives back part or all of what was paid for an order that will not
+// be fulfilled, recording it in the order refund ledger. An amount of zero
+// refunds everything not refunded yet.
+//
+// The refund is recorded as pending before the money is given back, so it is
+// always in the ledger, and refunds left pending are given back later by
+// SyncPayments.
+func (s *OrderService) Refund(ctx context.Context, id domain.ID, amount domain.Money) (*domain.Refund, error) {
+	if err := domain.CheckPermission(ctx, domain.PermissionManageOrders); err != nil {
+		return nil, err
+	}
+
+	o, err := s.getOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = domain.CheckVersion(ctx, o.Version); err != nil {
+		return nil, err
+	}
+
+	payment, err := s.paymentRepository.FindByProviderRef(ctx, o.PaymentRef)
+	if err != nil || payment.Status != domain.PaymentStatusApproved.String() {
+		return nil, domain.ErrorPaymentNotRefundable
+	}
+
+	refunds, err := s.refundRepository.FindByOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	refunded := domain.RefundedTotal(refunds)
+	remaining := payment.Amount.Sub(refunded)
+
+	if amount.IsZero() {
+		amount = remaining
+	}
+
+	if !amount.IsPositive() || remaining.Sub(amount).IsNegative() {
+		return nil, domain.ErrorRefundInvalidAmount
+	}
+
+	// do not give money back for orders that cannot be refunded
+	if err = o.CheckTransition(domain.RefundStatus(refunded.Add(amount), payment.Amount)); err != nil {
+		return nil, err
+	}
+
+	refund := domain.NewRefund(id, payment.ID, amount, domain.ActorFromContext(ctx))
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.refundRepository.Create(ctx, refund); err != nil {
+			return err
+		}
+		// a concurrent refund of the order, read along with the same ledger,
+		// fails on the order version
+		return s.patchOrder(ctx, o, &domain.Order{})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.settleRefund(ctx, o, payment, refund); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+// settleRefund gives back the money of a pending refund at the payment
+// provider, then completes the refund and moves the order to refunded, or
+// partially refunded. Refunds the payment provider refuses fail, and those
+// it could not be asked for stay pending.
+func (s *OrderService) settleRefund(ctx context.Context, o *domain.Order, p *domain.Payment, r *domain.Refund) error {
+	providerRef, err := s.paymentGateway.Refund(ctx, p.ProviderRef, r.Amount, r.IdempotencyKey())
+	if err != nil {
+		if errors.Is(err, domain.ErrorPaymentNotRefundable) {
+			r.Fail()
+			if patchErr := s.refundRepository.Patch(ctx, r.ID, &domain.Refund{Status: r.Status}); patchErr != nil {
+				return patchErr
+			}
+		}
+		return err
+	}
+
+	r.Complete(providerRef)
+
+	previous := o.Status
+
+	// the ledger and the order status are updated together
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.refundRepository.Patch(ctx, r.ID, &domain.Refund{Status: r.Status, ProviderRef: r.ProviderRef}); err != nil {
+			return err
+		}
+
+		refunds, err := s.refundRepository.FindByOrder(ctx, o.ID)
+		if err != nil {
+			return err
+		}
+
+		if err = o.Refund(domain.RefundedTotal(refunds), p.Amount); err != nil {
+			return err
+		}
+
+		// saves the whole order, as the refund may clear its refund pending flag
+		_, err = s.orderRepository.Save(ctx, o)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if o.Status != previous {
+		s.publishStatus(ctx, o)
+	}
+	return nil
+}
+
+// retryRefunds gives back the refunds left pending for longer than
+// refundRetryDelay, such as when the payment provider was unavailable.
+func (s *OrderService) retryRefunds(ctx context.Context) error {
+	refunds, err := s.refundRepository.FindPending(ctx, time.Now().Add(-refundRetryDelay))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, r := range refunds {
+		if err := s.retryRefund(ctx, r); err != nil {
+			errs = append(errs, fmt.Errorf("refund %s: %w", r.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *OrderService) retryRefund(ctx context.Context, r *domain.Refund) error {
+	o, err := s.orderRepository.FindByID(ctx, r.OrderID)
+	if err != nil {
+		return err
+	}
+
+	p, err := s.paymentRepository.FindByProviderRef(ctx, o.PaymentRef)
+	if err != nil {
+		return err
+	}
+
+	return s.settleRefund(ctx, o, p, r)
+}
+
+// GetRefunds returns the refund ledger of an order, oldest first.
+func (s *OrderService) GetRefunds(ctx context.Context, id domain.ID) ([]*domain.Refund, error) {
+	if _, err := s.getOrder(ctx, id); err != nil {
+		return nil, err
+	}
+
+	refunds, err := s.refundRepository.FindByOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return refunds, nil
+}
+
+// GetHistory returns every change made to an order, oldest first, along with
+// who made it.
+func (s *OrderService) GetHistory(ctx context.Context, id domain.ID) ([]*domain.OrderEvent, error) {
+	if _, err := s.getOrder(ctx, id); err != nil {
+		return nil, err
+	}
+
+	events, err := s.orderRepository.FindEvents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// KitchenQueue returns the paid orders the kitchen has to prepare, oldest
+// payment first, optionally only with the items of a station.
+func (s *OrderService) KitchenQueue(ctx context.Context, station string) ([]*domain.KitchenTicket, error) {
+	if err := domain.CheckPermission(ctx, domain.PermissionViewOrders); err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.orderRepository.FindKitchenQueue(ctx, station)
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+// PickupBoard returns the tracking numbers to show on the pickup board, of the
+// orders being prepared and of the orders ready for pickup. Done orders drop
+// off once delivered, or readyFor after they were done.
+func (s *OrderService) PickupBoard(ctx context.Context, readyFor time.Duration) (*domain.PickupBoard, error) {
+	board, err := s.orderRepository.FindPickupBoard(ctx, time.Now().Add(-readyFor))
+	if err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// UncollectedReport returns how long orders sit at the counter: the done
+// orders waiting right now, and how long the orders delivered since the given
+// time waited to be collected.
+func (s *OrderService) UncollectedReport(ctx context.Context, since time.Time) (*domain.UncollectedReport, error) {
+	if err := domain.CheckPermission(ctx, domain.PermissionViewReports); err != nil {
+		return nil, err
+	}
+
+	report, err := s.orderRepository.FindUncollectedReport(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ResetTrackingNumbers starts the tracking numbers over from 1. Numbers still
+// held by active orders are skipped when given again.
+func (s *OrderService) ResetTrackingNumbers(ctx context.Context) error {
+	return s.orderRepository.ResetTrackingNumbers(ctx)
+}
+
+// GetTotalMismatches returns the orders whose stored total disagrees with the
+// sum of their lines.
+func (s *OrderService) GetTotalMismatches(ctx context.Context) ([]*domain.OrderTotalMismatch, error) {
+	if err := domain.CheckPermission(ctx, domain.PermissionViewReports); err != nil {
+		return nil, err
+	}
+
+	mismatches, err := s.orderRepository.FindTotalMismatches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mismatches, nil
+}
+
+// RecalculateTotals fixes the total of an order, setting it to the sum of its
+// lines.
+func (s *OrderService) RecalculateTotals(ctx context.Context, id domain.ID) (*domain.Order, error) {
+	if err := domain.CheckPermission(ctx, domain.PermissionManageOrders); err != nil {
+		return nil, err
+	}
+
+	o, err := s.getOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	o.Total, err = s.orderRepository.RecalculateTotals(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
 // TODO better type safe custom model handling
 func (s *OrderService) GetNestedByID(ctx context.Context, id domain.ID) (any, error) {
+	if _, err := s.getOrder(ctx, id); err != nil {
+		return nil, err
+	}
+
 	o, err := s.orderRepository.FindNestedByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -184,7 +885,11 @@ func (s *OrderService) GetNestedByID(ctx context.Context, id domain.ID) (any, er
 	return o, nil
 }
 
-func (s *OrderService) Create(ctx context.Context, customerId uint64, products []domain.OrderProduct) (*domain.ID, error) {
+func (s *OrderService) GetCustomerOrders(ctx context.Context, customerId domain.ID, filter domain.OrderFilter) (*domain.OrderPage, error) {
+	if err := domain.CheckCustomer(ctx, customerId); err != nil {
+		return nil, err
+	}
+
 	_, err := s.customerRepository.FindByID(ctx, customerId)
 	if err != nil {
 		if err.Error() == domain.ErrorDataNotFound.Error() {
@@ -193,29 +898,100 @@ func (s *OrderService) Create(ctx context.Context, customerId uint64, products [
 		return nil, err
 	}
 
-	order, err := s.orderRepository.FindByCustomer(ctx, customerId)
+	return s.orderRepository.FindPageByCustomer(ctx, customerId, filter)
+}
+
+func (s *OrderService) Create(ctx context.Context, customerId domain.ID, products []domain.OrderProduct) (*domain.ID, error) {
+	if err := domain.CheckCustomer(ctx, customerId); err != nil {
+		return nil, err
+	}
+
+	_, err := s.customerRepository.FindByID(ctx, customerId)
 	if err != nil {
-		if err.Error() != domain.ErrorDataNotFound.Error() {
-			return nil, err
+		if err.Error() == domain.ErrorDataNotFound.Error() {
+			return nil, domain.ErrorCustomerNotFound
 		}
+		return nil, err
+	}
+
+	return s.createOrder(ctx, products,
+		func(ctx context.Context) (*domain.Order, error) {
+			return s.orderRepository.FindByCustomer(ctx, customerId)
+		},
+		func() *domain.Order {
+			return domain.NewOrderWithCustomer(customerId)
+		},
+	)
+}
+
+// CreateForGuest creates an order for a guest, or adds the products to the
+// active order of the guest, like Create does for customers.
+func (s *OrderService) CreateForGuest(ctx context.Context, guestId domain.ID, products []domain.OrderProduct) (*domain.ID, error) {
+	if err := domain.CheckGuest(ctx, guestId); err != nil {
+		return nil, err
+	}
 
-		// order = domain.NewOrderWithCustomer(customerId)
-		order, err = s.orderRepository.Save(ctx, domain.NewOrderWithCustomer(customerId))
+	guest, err := s.guestRepository.FindByID(ctx, guestId)
+	if err != nil {
+		if err.Error() == domain.ErrorDataNotFound.Error() {
+			return nil, domain.ErrorGuestNotFound
+		}
+		return nil, err
 	}
 
-	for _, product := range products {
-		err = s.AddProduct(ctx, order, &product)
+	return s.createOrder(ctx, products,
+		func(ctx context.Context) (*domain.Order, error) {
+			return s.orderRepository.FindByGuest(ctx, guestId)
+		},
+		func() *domain.Order {
+			return domain.NewOrderWithGuest(guest)
+		},
+	)
+}
 
+// createOrder adds the products to the active order found by find, or to a
+// new order when there is none.
+func (s *OrderService) createOrder(
+	ctx context.Context,
+	products []domain.OrderProduct,
+	find func(ctx context.Context) (*domain.Order, error),
+	newOrder func() *domain.Order,
+) (*domain.ID, error) {
+	var order *domain.Order
+
+	// the order and all its lines are created together, or not at all
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		order, err = find(ctx)
 		if err != nil {
-			return nil, err
+			if err.Error() != domain.ErrorDataNotFound.Error() {
+				return err
+			}
+
+			order, err = s.orderRepository.Save(ctx, newOrder())
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, product := range products {
+			if err := s.AddProduct(ctx, order, &product); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return &order.ID, nil
 }
 
 func (s *OrderService) GetByID(ctx context.Context, id domain.ID) (*domain.Order, error) {
-	o, err := s.orderRepository.FindByID(ctx, id)
+	o, err := s.getOrder(ctx, id)
 	if err != nil {
 		return nil, err
 	}
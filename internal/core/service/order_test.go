@@ -0,0 +1,988 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/eventbus"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/memory"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	mock_port "github.com/vitovidale/fastfood-app/internal/core/port/mock"
+	"go.uber.org/mock/gomock"
+)
+
+// inTransaction matches the contexts within a transaction of memory.Transactor.
+var inTransaction = gomock.Cond(func(ctx context.Context) bool { return memory.InTransaction(ctx) })
+
+// systemContext is the context of the work the app does on its own, allowed
+// to do anything.
+func systemContext() context.Context {
+	return domain.WithPrincipal(context.Background(), domain.NewSystemPrincipal())
+}
+
+var outsideTransaction = gomock.Cond(func(ctx context.Context) bool { return !memory.InTransaction(ctx) })
+
+// confirmedPatch matches the patch confirming an order with its payment time
+// and tracking number.
+var confirmedPatch = gomock.Cond(func(o *domain.Order) bool {
+	return o.Status == domain.OrderStatusConfirmed.String() && o.PaidAt != nil && o.TrackingNumber != nil
+})
+
+func TestOrderService_Cancel(t *testing.T) {
+	ctx := domain.WithActor(systemContext(), "kiosk")
+	id := domain.NewID()
+
+	testCases := []struct {
+		title  string
+		status domain.OrderStatus
+		mocks  func(orderRepository *mock_port.MockOrderRepository)
+		err    string
+	}{
+		{
+			title:  "Cancel a pending order",
+			status: domain.OrderStatusPending,
+			mocks: func(orderRepository *mock_port.MockOrderRepository) {
+				orderRepository.EXPECT().Patch(ctx, id, gomock.Cond(func(o *domain.Order) bool {
+					return o.Status == domain.OrderStatusCancelled.String() &&
+						o.CancelReason == "abandoned" &&
+						o.CancelledBy == "kiosk" &&
+						!o.RefundPending
+				})).Return(nil)
+			},
+		},
+		{
+			title:  "Cancel a paid order",
+			status: domain.OrderStatusConfirmed,
+			mocks: func(orderRepository *mock_port.MockOrderRepository) {
+				orderRepository.EXPECT().Patch(ctx, id, gomock.Cond(func(o *domain.Order) bool {
+					return o.RefundPending
+				})).Return(nil)
+			},
+		},
+		{
+			title:  "Refuse an order started by the kitchen",
+			status: domain.OrderStatusStarted,
+			mocks:  func(orderRepository *mock_port.MockOrderRepository) {},
+			err:    "invalid order transition from started to cancelled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepository := mock_port.NewMockOrderRepository(ctrl)
+			orderRepository.EXPECT().FindByID(ctx, id).Return(&domain.Order{ID: id, Status: tc.status.String()}, nil)
+			tc.mocks(orderRepository)
+
+			bus := eventbus.NewMemoryBus(10, 10)
+			events := bus.Subscribe(ctx, 0)
+
+			service := NewOrderService(orderRepository, nil, nil, nil, nil, nil, nil, nil, nil, bus)
+			err := service.Cancel(ctx, id, "abandoned")
+
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				require.Empty(t, events)
+				return
+			}
+			require.NoError(t, err)
+
+			event := <-events
+			require.Equal(t, id, event.OrderID)
+			require.Equal(t, domain.OrderStatusCancelled.String(), event.Status)
+		})
+	}
+}
+
+func TestOrderService_Pay(t *testing.T) {
+	ctx := systemContext()
+	id := domain.NewID()
+
+	// the order is processing, with a pending payment, before it is charged
+	processing := func(orderRepository *mock_port.MockOrderRepository, paymentRepository *mock_port.MockPaymentRepository) []any {
+		return []any{
+			paymentRepository.EXPECT().Create(inTransaction, gomock.Cond(func(p *domain.Payment) bool {
+				return p.ProviderRef == "" && p.Status == domain.PaymentStatusPending.String()
+			})).Return(nil),
+			orderRepository.EXPECT().Patch(inTransaction, id, &domain.Order{Status: domain.OrderStatusProcessing.String()}).Return(nil),
+		}
+	}
+
+	testCases := []struct {
+		title  string
+		status domain.OrderStatus
+		mocks  func(orderRepository *mock_port.MockOrderRepository, paymentRepository *mock_port.MockPaymentRepository, paymentGateway *mock_port.MockPaymentGateway)
+		err    string
+	}{
+		{
+			title:  "Charge a pending order",
+			status: domain.OrderStatusPending,
+			mocks: func(orderRepository *mock_port.MockOrderRepository, paymentRepository *mock_port.MockPaymentRepository, paymentGateway *mock_port.MockPaymentGateway) {
+				gomock.InOrder(append(processing(orderRepository, paymentRepository),
+					paymentGateway.EXPECT().CreateCharge(outsideTransaction, id, domain.NewMoney(2550), domain.PaymentMethodPix).Return(nil, domain.ErrorInternal),
+					paymentGateway.EXPECT().CreateCharge(outsideTransaction, id, domain.NewMoney(2550), domain.PaymentMethodPix).Return(&domain.Charge{Reference: "ch_1", PixCode: "000201"}, nil),
+					paymentRepository.EXPECT().Patch(inTransaction, gomock.Any(), gomock.Cond(func(p *domain.Payment) bool {
+						return p.ProviderRef == "ch_1" && p.PixCode == "000201" && p.Attempts == 2
+					})).Return(nil),
+					orderRepository.EXPECT().Patch(inTransaction, id, &domain.Order{PaymentRef: "ch_1"}).Return(nil),
+				)...)
+			},
+		},
+		{
+			title:  "Move the order back to pending when the charge cannot be created",
+			status: domain.OrderStatusPending,
+			mocks: func(orderRepository *mock_port.MockOrderRepository, paymentRepository *mock_port.MockPaymentRepository, paymentGateway *mock_port.MockPaymentGateway) {
+				gomock.InOrder(append(processing(orderRepository, paymentRepository),
+					paymentGateway.EXPECT().CreateCharge(outsideTransaction, id, domain.NewMoney(2550), domain.PaymentMethodPix).Return(nil, domain.ErrorInternal).Times(maxChargeAttempts),
+					paymentRepository.EXPECT().Patch(inTransaction, gomock.Any(), gomock.Cond(func(p *domain.Payment) bool {
+						return p.Attempts == maxChargeAttempts &&
+							p.Status == domain.PaymentStatusFailed.String() &&
+							p.FailureReason == "internal error"
+					})).Return(nil),
+					orderRepository.EXPECT().Patch(inTransaction, id, &domain.Order{Status: domain.OrderStatusPending.String()}).Return(nil),
+				)...)
+			},
+			err: "internal error",
+		},
+		{
+			title:  "Refuse to charge an order paid at the same time",
+			status: domain.OrderStatusPending,
+			mocks: func(orderRepository *mock_port.MockOrderRepository, paymentRepository *mock_port.MockPaymentRepository, paymentGateway *mock_port.MockPaymentGateway) {
+				paymentRepository.EXPECT().Create(inTransaction, gomock.Any()).Return(nil)
+				orderRepository.EXPECT().Patch(inTransaction, id, gomock.Any()).Return(&domain.ErrVersionConflict{})
+			},
+			err: "conflicting data: version 0 is no longer current",
+		},
+		{
+			title:  "Refuse to charge a confirmed order",
+			status: domain.OrderStatusConfirmed,
+			mocks: func(orderRepository *mock_port.MockOrderRepository, paymentRepository *mock_port.MockPaymentRepository, paymentGateway *mock_port.MockPaymentGateway) {
+			},
+			err: "invalid order transition from confirmed to processing",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepository := mock_port.NewMockOrderRepository(ctrl)
+			paymentRepository := mock_port.NewMockPaymentRepository(ctrl)
+			paymentGateway := mock_port.NewMockPaymentGateway(ctrl)
+			orderRepository.EXPECT().FindByID(ctx, id).Return(&domain.Order{ID: id, Status: tc.status.String(), Total: domain.NewMoney(2550)}, nil)
+			tc.mocks(orderRepository, paymentRepository, paymentGateway)
+
+			service := NewOrderService(orderRepository, nil, nil, nil, paymentGateway, paymentRepository, nil, nil, memory.NewTransactor(), nil)
+			payment, err := service.Pay(ctx, id, domain.PaymentMethodPix)
+
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, domain.PaymentMethodPix, payment.Method)
+		})
+	}
+}
+
+func TestOrderService_PayEmptyOrder(t *testing.T) {
+	ctx := systemContext()
+	o := domain.NewOrderWithCustomer(domain.NewID())
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepository := mock_port.NewMockOrderRepository(ctrl)
+	orderRepository.EXPECT().FindByID(ctx, o.ID).Return(o, nil)
+
+	// never charged, nor moved to processing
+	service := NewOrderService(orderRepository, nil, nil, nil, nil, nil, nil, nil, memory.NewTransactor(), nil)
+	_, err := service.Pay(ctx, o.ID, domain.PaymentMethodPix)
+	require.ErrorIs(t, err, domain.ErrorOrderEmpty)
+	require.Equal(t, domain.OrderStatusPending.String(), o.Status)
+}
+
+func TestOrderService_SyncPayments(t *testing.T) {
+	ctx := systemContext()
+	approved := &domain.Order{ID: domain.NewID(), Status: domain.OrderStatusProcessing.String(), PaymentRef: "ch_1"}
+	declined := &domain.Order{ID: domain.NewID(), Status: domain.OrderStatusProcessing.String(), PaymentRef: "ch_2"}
+	waiting := &domain.Order{ID: domain.NewID(), Status: domain.OrderStatusProcessing.String(), PaymentRef: "ch_3"}
+	expired := &domain.Order{ID: domain.NewID(), Status: domain.OrderStatusProcessing.String(), PaymentRef: "ch_4"}
+	expiredAt := time.Now().Add(-time.Minute)
+	trackingNumber := uint16(7)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepository := mock_port.NewMockOrderRepository(ctrl)
+	paymentRepository := mock_port.NewMockPaymentRepository(ctrl)
+	paymentGateway := mock_port.NewMockPaymentGateway(ctrl)
+	refundRepository := mock_port.NewMockRefundRepository(ctrl)
+	payment := &domain.Payment{ID: domain.NewID(), Status: domain.PaymentStatusPending.String()}
+
+	orderRepository.EXPECT().FindByStatus(ctx, domain.OrderStatusProcessing).Return([]*domain.Order{approved, declined, waiting, expired}, nil)
+	paymentGateway.EXPECT().GetCharge(ctx, "ch_1").Return(&domain.Charge{Status: domain.PaymentStatusApproved}, nil)
+	paymentGateway.EXPECT().GetCharge(ctx, "ch_2").Return(&domain.Charge{Status: domain.PaymentStatusDeclined, FailureReason: "insufficient funds"}, nil)
+	paymentGateway.EXPECT().GetCharge(ctx, "ch_3").Return(&domain.Charge{Status: domain.PaymentStatusPending}, nil)
+	paymentGateway.EXPECT().GetCharge(ctx, "ch_4").Return(&domain.Charge{Status: domain.PaymentStatusPending, ExpiresAt: &expiredAt}, nil)
+	paymentRepository.EXPECT().FindByProviderRef(ctx, "ch_1").Return(nil, domain.ErrorDataNotFound)
+	paymentRepository.EXPECT().FindByProviderRef(ctx, "ch_2").Return(payment, nil)
+	paymentRepository.EXPECT().FindByProviderRef(ctx, "ch_4").Return(nil, domain.ErrorDataNotFound)
+	paymentRepository.EXPECT().Patch(ctx, payment.ID, gomock.Cond(func(p *domain.Payment) bool {
+		return p.Status == domain.PaymentStatusDeclined.String() && p.FailureReason == "insufficient funds"
+	})).Return(nil)
+	orderRepository.EXPECT().GetTrackingNumber(ctx, nil).Return(&trackingNumber, nil)
+	orderRepository.EXPECT().Patch(ctx, approved.ID, confirmedPatch).Return(nil)
+	orderRepository.EXPECT().Patch(ctx, declined.ID, &domain.Order{Status: domain.OrderStatusPending.String()}).Return(nil)
+	orderRepository.EXPECT().Patch(ctx, expired.ID, &domain.Order{Status: domain.OrderStatusPending.String()}).Return(nil)
+	refundRepository.EXPECT().FindPending(ctx, gomock.Any()).Return(nil, nil)
+
+	service := NewOrderService(orderRepository, nil, nil, nil, paymentGateway, paymentRepository, nil, refundRepository, nil, nil)
+	require.NoError(t, service.SyncPayments(ctx))
+}
+
+func TestOrderService_SyncPaymentsWithoutCharge(t *testing.T) {
+	ctx := systemContext()
+	creating := &domain.Order{ID: domain.NewID(), Status: domain.OrderStatusProcessing.String()}
+	abandoned := &domain.Order{ID: domain.NewID(), Status: domain.OrderStatusProcessing.String()}
+	payment := &domain.Payment{ID: domain.NewID(), Status: domain.PaymentStatusPending.String(), CreatedAt: time.Now().Add(-chargeTimeout - time.Minute)}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepository := mock_port.NewMockOrderRepository(ctrl)
+	paymentRepository := mock_port.NewMockPaymentRepository(ctrl)
+	refundRepository := mock_port.NewMockRefundRepository(ctrl)
+
+	orderRepository.EXPECT().FindByStatus(ctx, domain.OrderStatusProcessing).Return([]*domain.Order{creating, abandoned}, nil)
+	paymentRepository.EXPECT().FindByOrder(ctx, creating.ID).Return([]*domain.Payment{{Status: domain.PaymentStatusPending.String(), CreatedAt: time.Now()}}, nil)
+	paymentRepository.EXPECT().FindByOrder(ctx, abandoned.ID).Return([]*domain.Payment{payment}, nil)
+	// Pay was cut short, the order can be paid again
+	paymentRepository.EXPECT().Patch(inTransaction, payment.ID, gomock.Cond(func(p *domain.Payment) bool {
+		return p.Status == domain.PaymentStatusFailed.String() && p.FailureReason == "charge not created"
+	})).Return(nil)
+	orderRepository.EXPECT().Patch(inTransaction, abandoned.ID, &domain.Order{Status: domain.OrderStatusPending.String()}).Return(nil)
+	refundRepository.EXPECT().FindPending(ctx, gomock.Any()).Return(nil, nil)
+
+	service := NewOrderService(orderRepository, nil, nil, nil, nil, paymentRepository, nil, refundRepository, memory.NewTransactor(), nil)
+	require.NoError(t, service.SyncPayments(ctx))
+	require.Equal(t, domain.OrderStatusProcessing.String(), creating.Status)
+}
+
+func TestOrderService_HandlePaymentEvent(t *testing.T) {
+	ctx := systemContext()
+	id := domain.NewID()
+	trackingNumber := uint16(7)
+	event := &domain.PaymentEvent{
+		ID:        "evt_1",
+		Reference: "ch_1",
+		OrderID:   id,
+		Status:    domain.PaymentStatusApproved.String(),
+	}
+
+	testCases := []struct {
+		title string
+		mocks func(orderRepository *mock_port.MockOrderRepository, paymentRepository *mock_port.MockPaymentRepository, paymentEventRepo *mock_port.MockPaymentEventRepository)
+	}{
+		{
+			title: "Confirm the order",
+			mocks: func(orderRepository *mock_port.MockOrderRepository, paymentRepository *mock_port.MockPaymentRepository, paymentEventRepo *mock_port.MockPaymentEventRepository) {
+				paymentEventRepo.EXPECT().Exists(ctx, "evt_1").Return(false, nil)
+				orderRepository.EXPECT().FindByID(ctx, id).Return(&domain.Order{
+					ID:         id,
+					Status:     domain.OrderStatusProcessing.String(),
+					PaymentRef: "ch_1",
+				}, nil)
+				orderRepository.EXPECT().GetTrackingNumber(ctx, nil).Return(&trackingNumber, nil)
+				paymentRepository.EXPECT().FindByProviderRef(ctx, "ch_1").Return(nil, domain.ErrorDataNotFound)
+				orderRepository.EXPECT().Patch(ctx, id, confirmedPatch).Return(nil)
+				paymentEventRepo.EXPECT().Create(ctx, event).Return(nil)
+			},
+		},
+		{
+			title: "Ignore a duplicate delivery",
+			mocks: func(orderRepository *mock_port.MockOrderRepository, paymentRepository *mock_port.MockPaymentRepository, paymentEventRepo *mock_port.MockPaymentEventRepository) {
+				paymentEventRepo.EXPECT().Exists(ctx, "evt_1").Return(true, nil)
+			},
+		},
+		{
+			title: "Ignore an event about a previous charge",
+			mocks: func(orderRepository *mock_port.MockOrderRepository, paymentRepository *mock_port.MockPaymentRepository, paymentEventRepo *mock_port.MockPaymentEventRepository) {
+				paymentEventRepo.EXPECT().Exists(ctx, "evt_1").Return(false, nil)
+				orderRepository.EXPECT().FindByID(ctx, id).Return(&domain.Order{
+					ID:         id,
+					Status:     domain.OrderStatusProcessing.String(),
+					PaymentRef: "ch_2",
+				}, nil)
+				paymentEventRepo.EXPECT().Create(ctx, event).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepository := mock_port.NewMockOrderRepository(ctrl)
+			paymentRepository := mock_port.NewMockPaymentRepository(ctrl)
+			paymentEventRepo := mock_port.NewMockPaymentEventRepository(ctrl)
+			tc.mocks(orderRepository, paymentRepository, paymentEventRepo)
+
+			service := NewOrderService(orderRepository, nil, nil, nil, nil, paymentRepository, paymentEventRepo, nil, nil, nil)
+			require.NoError(t, service.HandlePaymentEvent(ctx, event))
+		})
+	}
+}
+
+func TestOrderService_Refund(t *testing.T) {
+	ctx := domain.WithActor(systemContext(), "manager")
+	id := domain.NewID()
+	payment := &domain.Payment{
+		ID:          domain.NewID(),
+		Amount:      domain.NewMoney(2550),
+		ProviderRef: "ch_1",
+		Status:      domain.PaymentStatusApproved.String(),
+	}
+
+	// the refund is in the ledger, pending, before the money is given back
+	var refund *domain.Refund
+	pending := func(orderRepository *mock_port.MockOrderRepository, refundRepository *mock_port.MockRefundRepository, amount domain.Money) []any {
+		return []any{
+			refundRepository.EXPECT().Create(inTransaction, gomock.Cond(func(r *domain.Refund) bool {
+				refund = r
+				return r.Amount == amount && r.Status == domain.RefundStatusPending && r.CreatedBy == "manager" && r.PaymentID == payment.ID
+			})).Return(nil),
+			orderRepository.EXPECT().Patch(inTransaction, id, &domain.Order{}).Return(nil),
+		}
+	}
+	givenBack := gomock.Cond(func(key string) bool { return key == refund.IdempotencyKey() })
+
+	testCases := []struct {
+		title    string
+		amount   domain.Money
+		refunded []*domain.Refund
+		mocks    func(orderRepository *mock_port.MockOrderRepository, refundRepository *mock_port.MockRefundRepository, paymentGateway *mock_port.MockPaymentGateway)
+		status   domain.OrderStatus
+		err      error
+	}{
+		{
+			title:  "Partial refund",
+			amount: domain.NewMoney(1000),
+			mocks: func(orderRepository *mock_port.MockOrderRepository, refundRepository *mock_port.MockRefundRepository, paymentGateway *mock_port.MockPaymentGateway) {
+				gomock.InOrder(append(pending(orderRepository, refundRepository, domain.NewMoney(1000)),
+					paymentGateway.EXPECT().Refund(ctx, "ch_1", domain.NewMoney(1000), givenBack).Return("re_1", nil),
+					refundRepository.EXPECT().Patch(inTransaction, gomock.Any(), &domain.Refund{Status: domain.RefundStatusCompleted, ProviderRef: "re_1"}).Return(nil),
+					refundRepository.EXPECT().FindByOrder(inTransaction, id).Return([]*domain.Refund{{Amount: domain.NewMoney(1000), Status: domain.RefundStatusCompleted}}, nil),
+					orderRepository.EXPECT().Save(inTransaction, gomock.Any()).Return(nil, nil),
+				)...)
+			},
+			status: domain.OrderStatusPartiallyRefunded,
+		},
+		{
+			title:    "Refund what is left",
+			amount:   domain.NewMoney(0),
+			refunded: []*domain.Refund{{Amount: domain.NewMoney(1000), Status: domain.RefundStatusCompleted}},
+			mocks: func(orderRepository *mock_port.MockOrderRepository, refundRepository *mock_port.MockRefundRepository, paymentGateway *mock_port.MockPaymentGateway) {
+				gomock.InOrder(append(pending(orderRepository, refundRepository, domain.NewMoney(1550)),
+					paymentGateway.EXPECT().Refund(ctx, "ch_1", domain.NewMoney(1550), givenBack).Return("re_2", nil),
+					refundRepository.EXPECT().Patch(inTransaction, gomock.Any(), gomock.Any()).Return(nil),
+					refundRepository.EXPECT().FindByOrder(inTransaction, id).Return([]*domain.Refund{
+						{Amount: domain.NewMoney(1000), Status: domain.RefundStatusCompleted},
+						{Amount: domain.NewMoney(1550), Status: domain.RefundStatusCompleted},
+					}, nil),
+					orderRepository.EXPECT().Save(inTransaction, gomock.Cond(func(o *domain.Order) bool {
+						return !o.RefundPending
+					})).Return(nil, nil),
+				)...)
+			},
+			status: domain.OrderStatusRefunded,
+		},
+		{
+			title:    "Pending refunds are not given back again",
+			amount:   domain.NewMoney(2000),
+			refunded: []*domain.Refund{{Amount: domain.NewMoney(1000), Status: domain.RefundStatusPending}, {Amount: domain.NewMoney(2550), Status: domain.RefundStatusFailed}},
+			mocks: func(orderRepository *mock_port.MockOrderRepository, refundRepository *mock_port.MockRefundRepository, paymentGateway *mock_port.MockPaymentGateway) {
+			},
+			err: domain.ErrorRefundInvalidAmount,
+		},
+		{
+			title:  "Payment provider unavailable",
+			amount: domain.NewMoney(1000),
+			mocks: func(orderRepository *mock_port.MockOrderRepository, refundRepository *mock_port.MockRefundRepository, paymentGateway *mock_port.MockPaymentGateway) {
+				gomock.InOrder(append(pending(orderRepository, refundRepository, domain.NewMoney(1000)),
+					// left pending, to be given back by SyncPayments
+					paymentGateway.EXPECT().Refund(ctx, "ch_1", domain.NewMoney(1000), givenBack).Return("", domain.ErrorInternal),
+				)...)
+			},
+			err: domain.ErrorInternal,
+		},
+		{
+			title:  "Refused by the payment provider",
+			amount: domain.NewMoney(1000),
+			mocks: func(orderRepository *mock_port.MockOrderRepository, refundRepository *mock_port.MockRefundRepository, paymentGateway *mock_port.MockPaymentGateway) {
+				gomock.InOrder(append(pending(orderRepository, refundRepository, domain.NewMoney(1000)),
+					paymentGateway.EXPECT().Refund(ctx, "ch_1", domain.NewMoney(1000), givenBack).Return("", domain.ErrorPaymentNotRefundable),
+					refundRepository.EXPECT().Patch(ctx, gomock.Any(), &domain.Refund{Status: domain.RefundStatusFailed}).Return(nil),
+				)...)
+			},
+			err: domain.ErrorPaymentNotRefundable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepository := mock_port.NewMockOrderRepository(ctrl)
+			paymentRepository := mock_port.NewMockPaymentRepository(ctrl)
+			refundRepository := mock_port.NewMockRefundRepository(ctrl)
+			paymentGateway := mock_port.NewMockPaymentGateway(ctrl)
+
+			order := &domain.Order{ID: id, Status: domain.OrderStatusCancelled.String(), PaymentRef: "ch_1", RefundPending: true}
+			orderRepository.EXPECT().FindByID(ctx, id).Return(order, nil)
+			paymentRepository.EXPECT().FindByProviderRef(ctx, "ch_1").Return(payment, nil)
+			refundRepository.EXPECT().FindByOrder(ctx, id).Return(tc.refunded, nil)
+			tc.mocks(orderRepository, refundRepository, paymentGateway)
+
+			service := NewOrderService(orderRepository, nil, nil, nil, paymentGateway, paymentRepository, nil, refundRepository, memory.NewTransactor(), nil)
+			_, err := service.Refund(ctx, id, tc.amount)
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.status.String(), order.Status)
+			require.Equal(t, domain.RefundStatusCompleted, refund.Status)
+		})
+	}
+}
+
+func TestOrderService_RetryRefunds(t *testing.T) {
+	ctx := systemContext()
+	order := &domain.Order{ID: domain.NewID(), Status: domain.OrderStatusCancelled.String(), PaymentRef: "ch_1", RefundPending: true}
+	payment := &domain.Payment{ID: domain.NewID(), Amount: domain.NewMoney(2550), ProviderRef: "ch_1", Status: domain.PaymentStatusApproved.String()}
+	refund := domain.NewRefund(order.ID, payment.ID, domain.NewMoney(2550), "manager")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepository := mock_port.NewMockOrderRepository(ctrl)
+	paymentRepository := mock_port.NewMockPaymentRepository(ctrl)
+	refundRepository := mock_port.NewMockRefundRepository(ctrl)
+	paymentGateway := mock_port.NewMockPaymentGateway(ctrl)
+
+	orderRepository.EXPECT().FindByStatus(ctx, domain.OrderStatusProcessing).Return(nil, nil)
+	refundRepository.EXPECT().FindPending(ctx, gomock.Any()).Return([]*domain.Refund{refund}, nil)
+	orderRepository.EXPECT().FindByID(ctx, order.ID).Return(order, nil)
+	paymentRepository.EXPECT().FindByProviderRef(ctx, "ch_1").Return(payment, nil)
+	// asked for again with the same key, the money is given back once
+	paymentGateway.EXPECT().Refund(ctx, "ch_1", domain.NewMoney(2550), refund.IdempotencyKey()).Return("re_1", nil)
+	refundRepository.EXPECT().Patch(inTransaction, refund.ID, &domain.Refund{Status: domain.RefundStatusCompleted, ProviderRef: "re_1"}).Return(nil)
+	refundRepository.EXPECT().FindByOrder(inTransaction, order.ID).Return([]*domain.Refund{refund}, nil)
+	orderRepository.EXPECT().Save(inTransaction, order).Return(order, nil)
+
+	service := NewOrderService(orderRepository, nil, nil, nil, paymentGateway, paymentRepository, nil, refundRepository, memory.NewTransactor(), nil)
+	require.NoError(t, service.SyncPayments(ctx))
+	require.Equal(t, domain.OrderStatusRefunded.String(), order.Status)
+}
+
+func TestOrderService_SubscribeStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(systemContext())
+
+	// no event is sent without an event bus, until the subscriber goes away
+	service := NewOrderService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	events := service.SubscribeStatus(ctx, 1)
+	require.Empty(t, events)
+
+	cancel()
+	_, ok := <-events
+	require.False(t, ok)
+}
+
+func TestOrderService_AddProduct(t *testing.T) {
+	ctx := systemContext()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepository := mock_port.NewMockOrderRepository(ctrl)
+	productRepository := mock_port.NewMockProductRepository(ctrl)
+
+	o := domain.NewOrderWithCustomer(domain.NewID())
+	product := &domain.Product{ID: domain.NewID(), Price: domain.NewMoney(1050)}
+
+	productRepository.EXPECT().FindByID(ctx, product.ID).Return(product, nil)
+	// the total is recalculated by the repository, never patched from a stale order,
+	// and the tracking number waits for the payment
+	orderRepository.EXPECT().AddProduct(ctx, gomock.Cond(func(p *domain.OrderProduct) bool {
+		return p.OrderID == o.ID && p.UnitPrice == domain.NewMoney(1050) && p.Total == domain.NewMoney(3150)
+	})).Return(nil)
+
+	service := NewOrderService(orderRepository, productRepository, nil, nil, nil, nil, nil, nil, nil, nil)
+	err := service.AddProduct(ctx, o, &domain.OrderProduct{ProductID: product.ID, Quantity: 3})
+	require.NoError(t, err)
+}
+
+func TestOrderService_SyncPaymentsWithoutTrackingNumbers(t *testing.T) {
+	ctx := systemContext()
+	o := &domain.Order{ID: domain.NewID(), Status: domain.OrderStatusProcessing.String(), PaymentRef: "ch_1"}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepository := mock_port.NewMockOrderRepository(ctrl)
+	paymentGateway := mock_port.NewMockPaymentGateway(ctrl)
+	refundRepository := mock_port.NewMockRefundRepository(ctrl)
+
+	orderRepository.EXPECT().FindByStatus(ctx, domain.OrderStatusProcessing).Return([]*domain.Order{o}, nil)
+	paymentGateway.EXPECT().GetCharge(ctx, "ch_1").Return(&domain.Charge{Status: domain.PaymentStatusApproved}, nil)
+	orderRepository.EXPECT().GetTrackingNumber(ctx, nil).Return(nil, domain.ErrorOrderTrackingNumbersExhausted)
+	refundRepository.EXPECT().FindPending(ctx, gomock.Any()).Return(nil, nil)
+
+	// the order stays processing, to be confirmed once a number is released
+	service := NewOrderService(orderRepository, nil, nil, nil, paymentGateway, nil, nil, refundRepository, nil, nil)
+	require.ErrorIs(t, service.SyncPayments(ctx), domain.ErrorOrderTrackingNumbersExhausted)
+}
+
+func TestOrderService_RemoveProduct(t *testing.T) {
+	ctx := systemContext()
+	o := domain.NewOrderWithCustomer(domain.NewID())
+
+	testCases := []struct {
+		title string
+		line  *domain.OrderProduct
+		mocks func(orderRepository *mock_port.MockOrderRepository, line *domain.OrderProduct)
+		err   error
+	}{
+		{
+			title: "Line of the order",
+			line:  &domain.OrderProduct{ID: domain.NewID(), OrderID: o.ID},
+			mocks: func(orderRepository *mock_port.MockOrderRepository, line *domain.OrderProduct) {
+				orderRepository.EXPECT().RemoveProduct(ctx, line).Return(nil)
+			},
+		},
+		{
+			title: "Line of another order",
+			line:  &domain.OrderProduct{ID: domain.NewID(), OrderID: domain.NewID()},
+			mocks: func(orderRepository *mock_port.MockOrderRepository, line *domain.OrderProduct) {},
+			err:   domain.ErrorDataNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepository := mock_port.NewMockOrderRepository(ctrl)
+			orderRepository.EXPECT().FindByID(ctx, o.ID).Return(o, nil)
+			orderRepository.EXPECT().FindOrderProduct(ctx, tc.line.ID).Return(tc.line, nil)
+			tc.mocks(orderRepository, tc.line)
+
+			service := NewOrderService(orderRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			err := service.RemoveProduct(ctx, o.ID, tc.line.ID)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestOrderService_ChangeProductsWhileProcessing(t *testing.T) {
+	ctx := systemContext()
+	o := &domain.Order{ID: domain.NewID(), Status: domain.OrderStatusProcessing.String(), Total: domain.NewMoney(1050)}
+
+	// the lines of an order being paid for never move its total away from
+	// the amount charged
+	t.Run("Add a product", func(t *testing.T) {
+		service := NewOrderService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		err := service.AddProduct(ctx, o, &domain.OrderProduct{ProductID: domain.NewID(), Quantity: 1})
+		require.ErrorIs(t, err, domain.ErrorOrderNotEditable)
+	})
+
+	t.Run("Remove a product", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		orderRepository := mock_port.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().FindByID(ctx, o.ID).Return(o, nil)
+
+		service := NewOrderService(orderRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		err := service.RemoveProduct(ctx, o.ID, domain.NewID())
+		require.ErrorIs(t, err, domain.ErrorOrderNotEditable)
+	})
+}
+
+func TestOrderService_Create(t *testing.T) {
+	ctx := systemContext()
+	customer := &domain.Customer{ID: domain.NewID()}
+
+	product := &domain.Product{ID: domain.NewID(), Price: domain.NewMoney(1050)}
+	missing := domain.NewID()
+
+	testCases := []struct {
+		title     string
+		products  []domain.OrderProduct
+		mocks     func(orderRepository *mock_port.MockOrderRepository, productRepository *mock_port.MockProductRepository)
+		commits   int
+		rollbacks int
+		err       error
+	}{
+		{
+			title:    "Order with its lines",
+			products: []domain.OrderProduct{{ProductID: product.ID, Quantity: 1}},
+			mocks: func(orderRepository *mock_port.MockOrderRepository, productRepository *mock_port.MockProductRepository) {
+				productRepository.EXPECT().FindByID(inTransaction, product.ID).Return(product, nil)
+				orderRepository.EXPECT().AddProduct(inTransaction, gomock.Any()).Return(nil)
+			},
+			commits: 1,
+		},
+		{
+			title:    "Rolled back when a product is missing",
+			products: []domain.OrderProduct{{ProductID: product.ID, Quantity: 1}, {ProductID: missing, Quantity: 1}},
+			mocks: func(orderRepository *mock_port.MockOrderRepository, productRepository *mock_port.MockProductRepository) {
+				productRepository.EXPECT().FindByID(inTransaction, product.ID).Return(product, nil)
+				productRepository.EXPECT().FindByID(inTransaction, missing).Return(nil, domain.ErrorDataNotFound)
+				orderRepository.EXPECT().AddProduct(inTransaction, gomock.Any()).Return(nil)
+			},
+			rollbacks: 1,
+			err:       domain.ErrorProductNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepository := mock_port.NewMockOrderRepository(ctrl)
+			productRepository := mock_port.NewMockProductRepository(ctrl)
+			customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+			transactor := memory.NewTransactor()
+
+			customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(customer, nil)
+			orderRepository.EXPECT().FindByCustomer(inTransaction, customer.ID).Return(nil, domain.ErrorDataNotFound)
+			orderRepository.EXPECT().Save(inTransaction, gomock.Any()).DoAndReturn(func(ctx context.Context, o *domain.Order) (*domain.Order, error) {
+				return o, nil
+			})
+			tc.mocks(orderRepository, productRepository)
+
+			service := NewOrderService(orderRepository, productRepository, customerRepository, nil, nil, nil, nil, nil, transactor, nil)
+			id, err := service.Create(ctx, customer.ID, tc.products)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.commits, transactor.Commits())
+			require.Equal(t, tc.rollbacks, transactor.Rollbacks())
+			if tc.err == nil {
+				require.NotNil(t, id)
+			}
+		})
+	}
+}
+
+func TestOrderService_CreateForGuest(t *testing.T) {
+	guest := &domain.GuestSession{ID: domain.NewID(), Name: "John"}
+	product := &domain.Product{ID: domain.NewID(), Price: domain.NewMoney(1050)}
+
+	t.Run("Order of the guest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := domain.WithPrincipal(context.Background(), domain.NewGuestPrincipal(guest.ID))
+		guestRepository := mock_port.NewMockGuestRepository(ctrl)
+		guestRepository.EXPECT().FindByID(ctx, guest.ID).Return(guest, nil)
+		orderRepository := mock_port.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().FindByGuest(gomock.Any(), guest.ID).Return(nil, domain.ErrorDataNotFound)
+		orderRepository.EXPECT().Save(gomock.Any(), gomock.Cond(func(o *domain.Order) bool {
+			return o.CustomerID == nil && *o.GuestID == guest.ID && o.GuestName == "John"
+		})).DoAndReturn(func(ctx context.Context, o *domain.Order) (*domain.Order, error) {
+			return o, nil
+		})
+		productRepository := mock_port.NewMockProductRepository(ctrl)
+		productRepository.EXPECT().FindByID(gomock.Any(), product.ID).Return(product, nil)
+		orderRepository.EXPECT().AddProduct(gomock.Any(), gomock.Any()).Return(nil)
+
+		service := NewOrderService(orderRepository, productRepository, nil, guestRepository, nil, nil, nil, nil, memory.NewTransactor(), nil)
+		id, err := service.CreateForGuest(ctx, guest.ID, []domain.OrderProduct{{ProductID: product.ID, Quantity: 1}})
+		require.NoError(t, err)
+		require.NotNil(t, id)
+	})
+
+	t.Run("Order of another guest", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.Background(), domain.NewGuestPrincipal(domain.NewID()))
+
+		service := NewOrderService(nil, nil, nil, nil, nil, nil, nil, nil, memory.NewTransactor(), nil)
+		_, err := service.CreateForGuest(ctx, guest.ID, []domain.OrderProduct{{ProductID: product.ID, Quantity: 1}})
+		require.ErrorIs(t, err, domain.ErrorForbidden)
+	})
+}
+
+func TestOrderService_Prepare(t *testing.T) {
+	id := domain.NewID()
+
+	testCases := []struct {
+		title string
+		ctx   context.Context
+		mocks func(orderRepository *mock_port.MockOrderRepository)
+		err   error
+	}{
+		{
+			title: "Without expected version",
+			ctx:   systemContext(),
+			mocks: func(orderRepository *mock_port.MockOrderRepository) {
+				orderRepository.EXPECT().Patch(gomock.Any(), id, gomock.Cond(func(o *domain.Order) bool {
+					return o.Status == domain.OrderStatusStarted.String() && o.Version == 3
+				})).Return(nil)
+			},
+		},
+		{
+			title: "At the expected version",
+			ctx:   domain.WithExpectedVersion(systemContext(), 3),
+			mocks: func(orderRepository *mock_port.MockOrderRepository) {
+				orderRepository.EXPECT().Patch(gomock.Any(), id, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			title: "Not at the expected version",
+			ctx:   domain.WithExpectedVersion(systemContext(), 2),
+			mocks: func(orderRepository *mock_port.MockOrderRepository) {},
+			err:   domain.ErrorPreconditionFailed,
+		},
+		{
+			title: "Changed meanwhile",
+			ctx:   systemContext(),
+			mocks: func(orderRepository *mock_port.MockOrderRepository) {
+				orderRepository.EXPECT().Patch(gomock.Any(), id, gomock.Any()).Return(&domain.ErrVersionConflict{Version: 3})
+			},
+			err: domain.ErrorConflictingData,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepository := mock_port.NewMockOrderRepository(ctrl)
+			orderRepository.EXPECT().FindByID(tc.ctx, id).Return(&domain.Order{ID: id, Status: domain.OrderStatusConfirmed.String(), Version: 3}, nil)
+			tc.mocks(orderRepository)
+
+			service := NewOrderService(orderRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			err := service.Prepare(tc.ctx, id)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestOrderService_PickupBoard(t *testing.T) {
+	ctx := systemContext()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	board := &domain.PickupBoard{Preparing: []uint16{12}, Ready: []uint16{10, 11}}
+
+	orderRepository := mock_port.NewMockOrderRepository(ctrl)
+	orderRepository.EXPECT().FindPickupBoard(ctx, gomock.Cond(func(readySince time.Time) bool {
+		return time.Since(readySince) >= 10*time.Minute && time.Since(readySince) < 11*time.Minute
+	})).Return(board, nil)
+
+	service := NewOrderService(orderRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	got, err := service.PickupBoard(ctx, 10*time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, board, got)
+}
+
+func TestOrderService_Deliver(t *testing.T) {
+	ctx := systemContext()
+	id := domain.NewID()
+
+	testCases := []struct {
+		title  string
+		status domain.OrderStatus
+		mocks  func(orderRepository *mock_port.MockOrderRepository)
+		err    string
+	}{
+		{
+			title:  "Deliver a done order",
+			status: domain.OrderStatusDone,
+			mocks: func(orderRepository *mock_port.MockOrderRepository) {
+				orderRepository.EXPECT().Patch(ctx, id, gomock.Cond(func(o *domain.Order) bool {
+					return o.Status == domain.OrderStatusDelivered.String() && o.DeliveredAt != nil && o.Version == 4
+				})).Return(nil)
+			},
+		},
+		{
+			title:  "Refuse an order still being prepared",
+			status: domain.OrderStatusStarted,
+			mocks:  func(orderRepository *mock_port.MockOrderRepository) {},
+			err:    "invalid order transition from started to delivered",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepository := mock_port.NewMockOrderRepository(ctrl)
+			orderRepository.EXPECT().FindByID(ctx, id).Return(&domain.Order{ID: id, Status: tc.status.String(), Version: 4}, nil)
+			tc.mocks(orderRepository)
+
+			service := NewOrderService(orderRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			err := service.Deliver(ctx, id)
+
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOrderService_GetHistory(t *testing.T) {
+	ctx := systemContext()
+	id := domain.NewID()
+
+	t.Run("Order found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		events := []*domain.OrderEvent{
+			domain.NewOrderEvent(id, domain.OrderEventCreated, "kiosk", "", domain.OrderStatusPending.String()),
+			domain.NewOrderEvent(id, domain.OrderEventStatusChanged, "kitchen", domain.OrderStatusConfirmed.String(), domain.OrderStatusStarted.String()),
+		}
+
+		orderRepository := mock_port.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().FindByID(ctx, id).Return(&domain.Order{ID: id}, nil)
+		orderRepository.EXPECT().FindEvents(ctx, id).Return(events, nil)
+
+		service := NewOrderService(orderRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		got, err := service.GetHistory(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, events, got)
+	})
+
+	t.Run("Order not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		orderRepository := mock_port.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().FindByID(ctx, id).Return(nil, domain.ErrorDataNotFound)
+
+		service := NewOrderService(orderRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		_, err := service.GetHistory(ctx, id)
+		require.ErrorIs(t, err, domain.ErrorDataNotFound)
+	})
+}
+
+func TestOrderService_GetCustomerOrders(t *testing.T) {
+	ctx := systemContext()
+	filter := domain.OrderFilter{Status: domain.OrderStatusDelivered.String(), Limit: 20}
+	customer := &domain.Customer{ID: domain.NewID()}
+
+	t.Run("Customer found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		page := &domain.OrderPage{Orders: []*domain.OrderDetail{{Order: *domain.NewOrderWithCustomer(customer.ID)}}}
+
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+		customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(customer, nil)
+		orderRepository := mock_port.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().FindPageByCustomer(ctx, customer.ID, filter).Return(page, nil)
+
+		service := NewOrderService(orderRepository, nil, customerRepository, nil, nil, nil, nil, nil, nil, nil)
+		got, err := service.GetCustomerOrders(ctx, customer.ID, filter)
+		require.NoError(t, err)
+		require.Equal(t, page, got)
+	})
+
+	t.Run("Customer not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		customerRepository := mock_port.NewMockCustomerRepository(ctrl)
+		customerRepository.EXPECT().FindByID(ctx, customer.ID).Return(nil, domain.ErrorDataNotFound)
+
+		service := NewOrderService(nil, nil, customerRepository, nil, nil, nil, nil, nil, nil, nil)
+		_, err := service.GetCustomerOrders(ctx, customer.ID, filter)
+		require.ErrorIs(t, err, domain.ErrorCustomerNotFound)
+	})
+}
+
+func TestOrderService_GetByID(t *testing.T) {
+	guestId := domain.NewID()
+	order := domain.NewOrderWithGuest(&domain.GuestSession{ID: guestId})
+	customerId := domain.NewID()
+	order.CustomerID = &customerId
+	id := order.ID
+
+	testCases := []struct {
+		title     string
+		principal *domain.Principal
+		err       error
+	}{
+		{title: "Order of the customer", principal: domain.NewCustomerPrincipal(customerId)},
+		{title: "Order of another customer", principal: domain.NewCustomerPrincipal(domain.NewID()), err: domain.ErrorForbidden},
+		{title: "Order of the guest", principal: domain.NewGuestPrincipal(guestId)},
+		{title: "Order of another guest", principal: domain.NewGuestPrincipal(domain.NewID()), err: domain.ErrorForbidden},
+		{title: "Order read by the staff", principal: &domain.Principal{Role: domain.RoleCashier}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := domain.WithPrincipal(context.Background(), tc.principal)
+
+			orderRepository := mock_port.NewMockOrderRepository(ctrl)
+			orderRepository.EXPECT().FindByID(ctx, id).Return(order, nil)
+
+			service := NewOrderService(orderRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			_, err := service.GetByID(ctx, id)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestOrderService_Complete(t *testing.T) {
+	id := domain.NewID()
+
+	testCases := []struct {
+		title     string
+		principal *domain.Principal
+		err       error
+	}{
+		{title: "Completed by the kitchen", principal: &domain.Principal{Role: domain.RoleKitchen}},
+		{title: "Completed by a manager", principal: &domain.Principal{Role: domain.RoleManager}},
+		{title: "Completed by a cashier", principal: &domain.Principal{Role: domain.RoleCashier}, err: domain.ErrorForbidden},
+		{title: "Completed from a kiosk", principal: domain.NewCustomerPrincipal(domain.NewID()), err: domain.ErrorForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := domain.WithPrincipal(context.Background(), tc.principal)
+			orderRepository := mock_port.NewMockOrderRepository(ctrl)
+			if tc.err == nil {
+				orderRepository.EXPECT().FindByID(ctx, id).Return(&domain.Order{ID: id, Status: domain.OrderStatusStarted.String(), Version: 3}, nil)
+				orderRepository.EXPECT().Patch(ctx, id, gomock.Cond(func(o *domain.Order) bool {
+					return o.Status == domain.OrderStatusDone.String()
+				})).Return(nil)
+			}
+
+			service := NewOrderService(orderRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			err := service.Complete(ctx, id)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/port"
+)
+
+// OutboxRelay publishes the order events written to the outbox along with the
+// order changes.
+type OutboxRelay struct {
+	outboxRepository port.OutboxRepository
+	publisher        port.EventPublisher
+	transactor       port.Transactor
+	batchSize        int
+}
+
+func NewOutboxRelay(
+	outboxRepository port.OutboxRepository,
+	publisher port.EventPublisher,
+	transactor port.Transactor,
+	batchSize int,
+) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepository: outboxRepository,
+		publisher:        publisher,
+		transactor:       transactor,
+		batchSize:        batchSize,
+	}
+}
+
+// Relay publishes the pending outbox messages, in the order they were written
+// for each order. A message that fails is tried again later, and holds back
+// the messages of its order written after it.
+//
+// Only one relay runs at a time, across every instance of the app. Messages
+// are published at least once: the outcomes are written when the whole batch
+// is done, so messages may be published again after a crash.
+func (s *OutboxRelay) Relay(ctx context.Context) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		locked, err := s.outboxRepository.Lock(ctx)
+		if err != nil || !locked {
+			return err
+		}
+
+		messages, err := s.outboxRepository.FindPending(ctx, s.batchSize)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		held := map[domain.ID]bool{}
+
+		for _, m := range messages {
+			if held[m.AggregateID] {
+				continue
+			}
+
+			if !m.IsDue(now) {
+				held[m.AggregateID] = true
+				continue
+			}
+
+			if err := s.publisher.Publish(ctx, m); err != nil {
+				m.Fail(err.Error(), now)
+				held[m.AggregateID] = true
+			} else {
+				m.Publish(now)
+			}
+
+			if err := s.outboxRepository.Update(ctx, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/adapter/driven/storage/memory"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	mock_port "github.com/vitovidale/fastfood-app/internal/core/port/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOutboxRelay_Relay(t *testing.T) {
+	ctx := context.Background()
+	failing := domain.NewID()
+	other := domain.NewID()
+
+	newMessages := func() []*domain.OutboxMessage {
+		return []*domain.OutboxMessage{
+			{ID: 1, AggregateID: failing, Type: domain.OutboxOrderCreated},
+			{ID: 2, AggregateID: other, Type: domain.OutboxOrderCreated},
+			{ID: 3, AggregateID: failing, Type: domain.OutboxOrderPaid},
+			{ID: 4, AggregateID: other, Type: domain.OutboxOrderPaid},
+		}
+	}
+
+	t.Run("Hold back the later messages of an order that failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		outboxRepository := mock_port.NewMockOutboxRepository(ctrl)
+		publisher := mock_port.NewMockEventPublisher(ctrl)
+		messages := newMessages()
+
+		outboxRepository.EXPECT().Lock(inTransaction).Return(true, nil)
+		outboxRepository.EXPECT().FindPending(inTransaction, 10).Return(messages, nil)
+		gomock.InOrder(
+			publisher.EXPECT().Publish(inTransaction, messages[0]).Return(errors.New("broker unavailable")),
+			publisher.EXPECT().Publish(inTransaction, messages[1]).Return(nil),
+			publisher.EXPECT().Publish(inTransaction, messages[3]).Return(nil),
+		)
+		outboxRepository.EXPECT().Update(inTransaction, gomock.Any()).Return(nil).Times(3)
+
+		service := NewOutboxRelay(outboxRepository, publisher, memory.NewTransactor(), 10)
+		require.NoError(t, service.Relay(ctx))
+
+		require.Nil(t, messages[0].PublishedAt)
+		require.Equal(t, uint32(1), messages[0].Attempts)
+		require.Equal(t, "broker unavailable", messages[0].LastError)
+		require.NotNil(t, messages[1].PublishedAt)
+		require.Zero(t, messages[2].Attempts)
+		require.NotNil(t, messages[3].PublishedAt)
+	})
+
+	t.Run("Hold back the later messages of an order waiting for a retry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		outboxRepository := mock_port.NewMockOutboxRepository(ctrl)
+		publisher := mock_port.NewMockEventPublisher(ctrl)
+		messages := newMessages()
+		messages[0].NextAttemptAt = time.Now().Add(time.Minute)
+
+		outboxRepository.EXPECT().Lock(inTransaction).Return(true, nil)
+		outboxRepository.EXPECT().FindPending(inTransaction, 10).Return(messages, nil)
+		publisher.EXPECT().Publish(inTransaction, messages[1]).Return(nil)
+		publisher.EXPECT().Publish(inTransaction, messages[3]).Return(nil)
+		outboxRepository.EXPECT().Update(inTransaction, gomock.Any()).Return(nil).Times(2)
+
+		service := NewOutboxRelay(outboxRepository, publisher, memory.NewTransactor(), 10)
+		require.NoError(t, service.Relay(ctx))
+	})
+
+	t.Run("Publish the due messages behind a full batch waiting for a retry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		outboxRepository := mock_port.NewMockOutboxRepository(ctrl)
+		publisher := mock_port.NewMockEventPublisher(ctrl)
+
+		// the first two messages, of an order waiting for a retry, would fill
+		// a batch of two, they are left out of the pending messages instead
+		messages := []*domain.OutboxMessage{
+			{ID: 5, AggregateID: other, Type: domain.OutboxOrderCreated},
+			{ID: 6, AggregateID: other, Type: domain.OutboxOrderPaid},
+		}
+
+		outboxRepository.EXPECT().Lock(inTransaction).Return(true, nil)
+		outboxRepository.EXPECT().FindPending(inTransaction, 2).Return(messages, nil)
+		gomock.InOrder(
+			publisher.EXPECT().Publish(inTransaction, messages[0]).Return(nil),
+			publisher.EXPECT().Publish(inTransaction, messages[1]).Return(nil),
+		)
+		outboxRepository.EXPECT().Update(inTransaction, gomock.Any()).Return(nil).Times(2)
+
+		service := NewOutboxRelay(outboxRepository, publisher, memory.NewTransactor(), 2)
+		require.NoError(t, service.Relay(ctx))
+		require.NotNil(t, messages[0].PublishedAt)
+		require.NotNil(t, messages[1].PublishedAt)
+	})
+
+	t.Run("Skip the round while another relay runs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		outboxRepository := mock_port.NewMockOutboxRepository(ctrl)
+		publisher := mock_port.NewMockEventPublisher(ctrl)
+
+		outboxRepository.EXPECT().Lock(inTransaction).Return(false, nil)
+
+		service := NewOutboxRelay(outboxRepository, publisher, memory.NewTransactor(), 10)
+		require.NoError(t, service.Relay(ctx))
+	})
+}
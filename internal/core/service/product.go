@@ -42,6 +42,10 @@ func (s *ProductService) GetByCategory(ctx context.Context, id domain.ID) ([]*do
 }
 
 func (s *ProductService) Create(ctx context.Context, p *domain.Product) (*domain.Product, error) {
+	if err := domain.CheckPermission(ctx, domain.PermissionManageCatalog); err != nil {
+		return nil, err
+	}
+
 	p = domain.NewProduct(p.Name, p.Description, p.Price, p.CategoryID)
 
 	if err := s.findAndSetCategory(ctx, p); err != nil {
@@ -56,7 +60,20 @@ func (s *ProductService) Create(ctx context.Context, p *domain.Product) (*domain
 }
 
 func (s *ProductService) Update(ctx context.Context, p *domain.Product) (*domain.Product, error) {
-	product := domain.Product{}
+	if err := domain.CheckPermission(ctx, domain.PermissionManageCatalog); err != nil {
+		return nil, err
+	}
+
+	current, err := s.productRepository.FindByID(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = domain.CheckVersion(ctx, current.Version); err != nil {
+		return nil, err
+	}
+
+	product := domain.Product{Version: current.Version}
 
 	if p.Name != "" {
 		product.Name = p.Name
@@ -66,7 +83,7 @@ func (s *ProductService) Update(ctx context.Context, p *domain.Product) (*domain
 		product.Description = p.Description
 	}
 
-	if p.Price != 0 {
+	if !p.Price.IsZero() {
 		product.Price = p.Price
 	}
 
@@ -74,7 +91,7 @@ func (s *ProductService) Update(ctx context.Context, p *domain.Product) (*domain
 		return nil, err
 	}
 
-	err := s.productRepository.Patch(ctx, p.ID, &product)
+	err = s.productRepository.Patch(ctx, p.ID, &product)
 	if err != nil {
 		return nil, err
 	}
@@ -83,15 +100,22 @@ func (s *ProductService) Update(ctx context.Context, p *domain.Product) (*domain
 }
 
 func (s *ProductService) Delete(ctx context.Context, id domain.ID) error {
+	if err := domain.CheckPermission(ctx, domain.PermissionManageCatalog); err != nil {
+		return err
+	}
+
 	p, err := s.productRepository.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err = domain.CheckVersion(ctx, p.Version); err != nil {
+		return err
+	}
 	err = p.Inactivate()
 	if err != nil {
 		return err
 	}
-	err = s.productRepository.Patch(ctx, id, &domain.Product{DeletedAt: p.DeletedAt})
+	err = s.productRepository.Patch(ctx, id, &domain.Product{DeletedAt: p.DeletedAt, Version: p.Version})
 	if err != nil {
 		return err
 	}
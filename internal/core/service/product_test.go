@@ -35,7 +35,7 @@ func TestProductService_CreateProduct(t *testing.T) {
 
 	productID, _ := domain.ParseID(gofakeit.UUID())
 	productName := gofakeit.ProductName()
-	productPrice := gofakeit.Price(10, 100)
+	productPrice := domain.NewMoney(int64(gofakeit.Number(1000, 10000)))
 	productCreatedAt := gofakeit.Date()
 
 	productInput := &domain.Product{
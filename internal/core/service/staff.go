@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	"github.com/vitovidale/fastfood-app/internal/core/port"
+)
+
+type StaffService struct {
+	staffRepository port.StaffRepository
+}
+
+func NewStaffService(staffRepository port.StaffRepository) *StaffService {
+	return &StaffService{staffRepository: staffRepository}
+}
+
+func (s *StaffService) Create(ctx context.Context, username string, name string, password string, role domain.Role) (*domain.StaffUser, error) {
+	if err := domain.CheckPermission(ctx, domain.PermissionManageStaff); err != nil {
+		return nil, err
+	}
+
+	_, err := s.staffRepository.FindByUsername(ctx, username)
+	if err == nil {
+		return nil, domain.ErrorStaffUserAlreadyExists
+	}
+	if err.Error() != domain.ErrorDataNotFound.Error() {
+		return nil, err
+	}
+
+	u, err := domain.NewStaffUser(username, name, password, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.staffRepository.Create(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (s *StaffService) List(ctx context.Context) ([]*domain.StaffUser, error) {
+	if err := domain.CheckPermission(ctx, domain.PermissionManageStaff); err != nil {
+		return nil, err
+	}
+
+	users, err := s.staffRepository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (s *StaffService) Delete(ctx context.Context, id domain.ID) error {
+	if err := domain.CheckPermission(ctx, domain.PermissionManageStaff); err != nil {
+		return err
+	}
+
+	u, err := s.staffRepository.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = domain.CheckVersion(ctx, u.Version); err != nil {
+		return err
+	}
+
+	deletedAt := time.Now()
+	return s.staffRepository.Patch(ctx, id, &domain.StaffUser{DeletedAt: &deletedAt, Version: u.Version})
+}
+
+// Authenticate returns the staff user with the given credentials. Unknown
+// usernames and wrong passwords are told apart from each other by no one.
+func (s *StaffService) Authenticate(ctx context.Context, username string, password string) (*domain.StaffUser, error) {
+	u, err := s.staffRepository.FindByUsername(ctx, username)
+	if err != nil {
+		if err.Error() == domain.ErrorDataNotFound.Error() {
+			return nil, domain.ErrorUnauthorized
+		}
+		return nil, err
+	}
+
+	if err = u.Authenticate(password); err != nil {
+		return nil, domain.ErrorUnauthorized
+	}
+	return u, nil
+}
+
+// Bootstrap creates the first admin, so staff users can be created at all,
+// when there is no staff user yet.
+func (s *StaffService) Bootstrap(ctx context.Context, username string, password string) error {
+	users, err := s.staffRepository.List(ctx)
+	if err != nil || len(users) > 0 {
+		return err
+	}
+
+	u, err := domain.NewStaffUser(username, "Administrator", password, domain.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	return s.staffRepository.Create(ctx, u)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vitovidale/fastfood-app/internal/core/domain"
+	mock_port "github.com/vitovidale/fastfood-app/internal/core/port/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStaffService_Create(t *testing.T) {
+	admin := domain.WithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleAdmin})
+	manager := domain.WithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleManager})
+
+	testCases := []struct {
+		title string
+		ctx   context.Context
+		role  domain.Role
+		mocks func(staffRepository *mock_port.MockStaffRepository)
+		err   error
+	}{
+		{
+			title: "Created by an admin",
+			ctx:   admin,
+			role:  domain.RoleCashier,
+			mocks: func(staffRepository *mock_port.MockStaffRepository) {
+				staffRepository.EXPECT().FindByUsername(admin, "jane").Return(nil, domain.ErrorDataNotFound)
+				staffRepository.EXPECT().Create(admin, gomock.Cond(func(u *domain.StaffUser) bool {
+					return u.Username == "jane" && u.Role == domain.RoleCashier && u.Authenticate("12345678") == nil
+				})).Return(nil)
+			},
+		},
+		{
+			title: "Created by a manager",
+			ctx:   manager,
+			role:  domain.RoleCashier,
+			mocks: func(staffRepository *mock_port.MockStaffRepository) {},
+			err:   domain.ErrorForbidden,
+		},
+		{
+			title: "Username taken",
+			ctx:   admin,
+			role:  domain.RoleCashier,
+			mocks: func(staffRepository *mock_port.MockStaffRepository) {
+				staffRepository.EXPECT().FindByUsername(admin, "jane").Return(&domain.StaffUser{Username: "jane"}, nil)
+			},
+			err: domain.ErrorStaffUserAlreadyExists,
+		},
+		{
+			title: "Customer role",
+			ctx:   admin,
+			role:  domain.RoleCustomer,
+			mocks: func(staffRepository *mock_port.MockStaffRepository) {
+				staffRepository.EXPECT().FindByUsername(admin, "jane").Return(nil, domain.ErrorDataNotFound)
+			},
+			err: domain.ErrorRoleUnknown,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			staffRepository := mock_port.NewMockStaffRepository(ctrl)
+			tc.mocks(staffRepository)
+
+			service := NewStaffService(staffRepository)
+			_, err := service.Create(tc.ctx, "jane", "Jane Doe", "12345678", tc.role)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestStaffService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	u, err := domain.NewStaffUser("jane", "Jane Doe", "12345678", domain.RoleKitchen)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	staffRepository := mock_port.NewMockStaffRepository(ctrl)
+	staffRepository.EXPECT().FindByUsername(ctx, "jane").Return(u, nil).Times(2)
+	staffRepository.EXPECT().FindByUsername(ctx, "john").Return(nil, domain.ErrorDataNotFound)
+
+	service := NewStaffService(staffRepository)
+
+	found, err := service.Authenticate(ctx, "jane", "12345678")
+	require.NoError(t, err)
+	require.Equal(t, u.ID, found.ID)
+
+	_, err = service.Authenticate(ctx, "jane", "wrong")
+	require.ErrorIs(t, err, domain.ErrorUnauthorized)
+
+	_, err = service.Authenticate(ctx, "john", "12345678")
+	require.ErrorIs(t, err, domain.ErrorUnauthorized)
+}
+
+func TestStaffService_Bootstrap(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("No staff users", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		staffRepository := mock_port.NewMockStaffRepository(ctrl)
+		staffRepository.EXPECT().List(ctx).Return(nil, nil)
+		staffRepository.EXPECT().Create(ctx, gomock.Cond(func(u *domain.StaffUser) bool {
+			return u.Username == "admin" && u.Role == domain.RoleAdmin
+		})).Return(nil)
+
+		require.NoError(t, NewStaffService(staffRepository).Bootstrap(ctx, "admin", "12345678"))
+	})
+
+	t.Run("Staff users already created", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		staffRepository := mock_port.NewMockStaffRepository(ctrl)
+		staffRepository.EXPECT().List(ctx).Return([]*domain.StaffUser{{Username: "jane"}}, nil)
+
+		require.NoError(t, NewStaffService(staffRepository).Bootstrap(ctx, "admin", "12345678"))
+	})
+}